@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+)
+
+// cborGenFile holds the generated MarshalCBOR and UnmarshalCBOR methods
+const cborGenFile = "cbor_gen.go"
+
+// cbor-gen works by reflecting on compiled types, so the marshalers are made
+// by a small program that imports the freshly written package. The union
+// wrappers call their variants' CBOR methods, so until that program has run
+// the package is given stub methods to let it compile.
+
+var stubTemplate = template.Must(template.New("stub").Parse(`// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package {{ .Package }}
+
+import "io"
+{{ range .Types }}
+func (t *{{ . }}) MarshalCBOR(w io.Writer) error   { panic("cbor-gen has not run") }
+func (t *{{ . }}) UnmarshalCBOR(r io.Reader) error { panic("cbor-gen has not run") }
+{{ end }}`))
+
+var mainTemplate = template.Must(template.New("main").Parse(`package main
+
+import (
+	"fmt"
+	"os"
+
+	target "{{ .Import }}"
+
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+func main() {
+	gen := cbg.Gen{MaxStringLength: 1_000_000}
+	if err := gen.WriteMapEncodersToFile(os.Args[1], "{{ .Package }}",
+{{- range .Types }}
+		target.{{ . }}{},
+{{- end }}
+	); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`))
+
+// generateCBOR writes cbor_gen.go for the given struct types in dir
+func generateCBOR(dir, importPath, pkg string, types []string) error {
+	data := struct {
+		Package string
+		Import  string
+		Types   []string
+	}{pkg, importPath, types}
+
+	var stub bytes.Buffer
+	if err := stubTemplate.Execute(&stub, data); err != nil {
+		return err
+	}
+	out := filepath.Join(dir, cborGenFile)
+	if err := os.WriteFile(out, stub.Bytes(), 0o644); err != nil {
+		return err
+	}
+
+	// The program must live inside the module to import the package
+	tmp, err := os.MkdirTemp(dir, "cborgen")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	var prog bytes.Buffer
+	if err := mainTemplate.Execute(&prog, data); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "main.go"), prog.Bytes(), 0o644); err != nil {
+		return err
+	}
+
+	// Run it as a package, so the output path isn't taken for a source file
+	pkgDir, err := filepath.Abs(tmp)
+	if err != nil {
+		return err
+	}
+	abs, err := filepath.Abs(out)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("go", "run", pkgDir, abs)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("running cbor-gen: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,540 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	lexicon "github.com/bluesky-social/indigo/atproto/lexicon"
+)
+
+// generatedHeader marks files written by this command, so stale ones can be
+// removed when a schema is deleted
+const generatedHeader = "// Code generated by cmd/generate-lexicon; DO NOT EDIT."
+
+// goStruct is a Go struct generated for a record or object definition
+type goStruct struct {
+	Name        string
+	Schema      string // NSID of the schema file that defines it
+	DefName     string // Name of the definition within the schema, e.g. "main"
+	Description string
+	TypeID      string // Value of the $type field, or "" if the type has none
+	Record      bool
+	Fields      []goField
+}
+
+type goField struct {
+	Name        string
+	JSONName    string
+	Type        string
+	Description string
+	OmitEmpty   bool
+}
+
+// goUnion is a wrapper struct holding one of several variants, selected by $type
+type goUnion struct {
+	Name     string
+	Schema   string
+	Closed   bool
+	Variants []unionVariant
+}
+
+type unionVariant struct {
+	TypeID string
+	GoType string
+}
+
+// schemaDef is a definition loaded from a lexicon file
+type schemaDef struct {
+	NSID string
+	Name string
+	Def  any
+}
+
+// generator turns the record definitions of a set of lexicons, and every
+// definition they reach, into Go types
+type generator struct {
+	prefix string
+	defs   map[string]schemaDef // Keyed by full ref, "nsid#name"
+
+	structs map[string]*goStruct
+	unions  map[string]*goUnion
+	queue   []string // Full refs of definitions waiting to be generated
+	members map[string]bool
+}
+
+// loadSchemas reads every lexicon JSON file under dir
+func loadSchemas(dir string) (map[string]schemaDef, error) {
+	defs := make(map[string]schemaDef)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var sf lexicon.SchemaFile
+		if err := json.Unmarshal(data, &sf); err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+		for name, def := range sf.Defs {
+			defs[sf.ID+"#"+name] = schemaDef{NSID: sf.ID, Name: name, Def: def.Inner}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return defs, nil
+}
+
+// generate returns the source of each generated file, keyed by file name,
+// and the names of the structs that need cbor-gen marshalers
+func generate(defs map[string]schemaDef, prefix string, pkg string) (map[string][]byte, []string, error) {
+	g := &generator{
+		prefix:  prefix,
+		defs:    defs,
+		structs: make(map[string]*goStruct),
+		unions:  make(map[string]*goUnion),
+		members: make(map[string]bool),
+	}
+
+	// Every record under the prefix is a root; everything it references follows
+	for _, ref := range sortedKeys(defs) {
+		def := defs[ref]
+		if _, ok := def.Def.(lexicon.SchemaRecord); ok && strings.HasPrefix(def.NSID, prefix+".") {
+			g.queue = append(g.queue, ref)
+		}
+	}
+	done := make(map[string]bool)
+	for len(g.queue) > 0 {
+		ref := g.queue[0]
+		g.queue = g.queue[1:]
+		if done[ref] {
+			continue
+		}
+		done[ref] = true
+		if err := g.genDef(ref); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Objects that appear in unions carry their $type
+	for ref := range g.members {
+		def := defs[ref]
+		if s, ok := g.structs[g.typeName(def.NSID, def.Name)]; ok && s.TypeID == "" {
+			s.TypeID = typeID(def.NSID, def.Name)
+		}
+	}
+
+	return g.render(pkg)
+}
+
+// genDef generates the Go type for the definition at ref
+func (g *generator) genDef(ref string) error {
+	def := g.defs[ref]
+	name := g.typeName(def.NSID, def.Name)
+
+	switch d := def.Def.(type) {
+	case lexicon.SchemaRecord:
+		s, err := g.genStruct(name, def.NSID, def.Name, d.Description, d.Record)
+		if err != nil {
+			return err
+		}
+		s.Record = true
+		s.TypeID = def.NSID
+	case lexicon.SchemaObject:
+		if _, err := g.genStruct(name, def.NSID, def.Name, d.Description, d); err != nil {
+			return err
+		}
+	}
+	// Other definitions, such as strings and tokens, are used inline
+	return nil
+}
+
+// genStruct generates a struct for an object and queues what its fields reference
+func (g *generator) genStruct(name, nsid, defName string, desc *string, obj lexicon.SchemaObject) (*goStruct, error) {
+	s := &goStruct{
+		Name:        name,
+		Schema:      nsid,
+		DefName:     defName,
+		Description: deref(desc),
+	}
+	g.structs[name] = s
+
+	required := make(map[string]bool)
+	for _, k := range obj.Required {
+		required[k] = true
+	}
+
+	for _, k := range sortedKeys(obj.Properties) {
+		// $type is written from the type's TypeID
+		if k == "$type" {
+			continue
+		}
+
+		optional := !required[k] || obj.IsNullable(k)
+		typ, err := g.fieldType(nsid, name+"_"+title(k), obj.Properties[k].Inner, optional)
+		if err != nil {
+			return nil, fmt.Errorf("%s: field %s: %w", name, k, err)
+		}
+		s.Fields = append(s.Fields, goField{
+			Name:        title(k),
+			JSONName:    k,
+			Type:        typ,
+			Description: description(obj.Properties[k].Inner),
+			OmitEmpty:   !required[k],
+		})
+	}
+	return s, nil
+}
+
+// fieldType returns the Go type for a field's schema. nested names any type
+// that has to be generated for the field itself, such as a union wrapper.
+func (g *generator) fieldType(nsid, nested string, def any, optional bool) (string, error) {
+	ptr := ""
+	if optional {
+		ptr = "*"
+	}
+
+	switch d := def.(type) {
+	case lexicon.SchemaString:
+		return ptr + "string", nil
+	case lexicon.SchemaInteger:
+		return ptr + "int64", nil
+	case lexicon.SchemaBoolean:
+		return ptr + "bool", nil
+	case lexicon.SchemaBytes:
+		return "util.LexBytes", nil
+	case lexicon.SchemaCIDLink:
+		return ptr + "util.LexLink", nil
+	case lexicon.SchemaBlob:
+		return "*util.LexBlob", nil
+	case lexicon.SchemaUnknown:
+		// Any object; kept in the data model so it round-trips losslessly
+		return "*atrepo.GenericRecord", nil
+	case lexicon.SchemaArray:
+		elem, err := g.fieldType(nsid, nested+"_Elem", d.Items.Inner, false)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case lexicon.SchemaObject:
+		if _, err := g.genStruct(nested, nsid, "", d.Description, d); err != nil {
+			return "", err
+		}
+		return "*" + nested, nil
+	case lexicon.SchemaRef:
+		target, err := g.resolve(nsid, d.Ref)
+		if err != nil {
+			return "", err
+		}
+		switch td := target.Def.(type) {
+		case lexicon.SchemaObject, lexicon.SchemaRecord:
+			g.queue = append(g.queue, target.NSID+"#"+target.Name)
+			return "*" + g.typeName(target.NSID, target.Name), nil
+		case lexicon.SchemaToken:
+			return ptr + "string", nil
+		default:
+			return g.fieldType(target.NSID, nested, td, optional)
+		}
+	case lexicon.SchemaUnion:
+		if err := g.genUnion(nsid, nested, d); err != nil {
+			return "", err
+		}
+		return "*" + nested, nil
+	default:
+		return "", fmt.Errorf("unsupported schema type %T", def)
+	}
+}
+
+// genUnion generates a wrapper for a union and queues its variants
+func (g *generator) genUnion(nsid, name string, d lexicon.SchemaUnion) error {
+	u := &goUnion{
+		Name:   name,
+		Schema: nsid,
+		Closed: d.Closed != nil && *d.Closed,
+	}
+	for _, ref := range d.Refs {
+		target, err := g.resolve(nsid, ref)
+		if err != nil {
+			return err
+		}
+		switch target.Def.(type) {
+		case lexicon.SchemaObject, lexicon.SchemaRecord:
+		default:
+			return fmt.Errorf("union variant %s is not an object", ref)
+		}
+		full := target.NSID + "#" + target.Name
+		g.queue = append(g.queue, full)
+		g.members[full] = true
+		u.Variants = append(u.Variants, unionVariant{
+			TypeID: typeID(target.NSID, target.Name),
+			GoType: g.typeName(target.NSID, target.Name),
+		})
+	}
+	g.unions[name] = u
+	return nil
+}
+
+// resolve finds the definition a ref points to; refs starting with # are
+// relative to nsid
+func (g *generator) resolve(nsid, ref string) (schemaDef, error) {
+	full := ref
+	if strings.HasPrefix(ref, "#") {
+		full = nsid + ref
+	} else if !strings.Contains(ref, "#") {
+		full = ref + "#main"
+	}
+	def, ok := g.defs[full]
+	if !ok {
+		return schemaDef{}, fmt.Errorf("unresolved ref %q in %s", ref, nsid)
+	}
+	if !strings.HasPrefix(def.NSID, g.prefix+".") {
+		return schemaDef{}, fmt.Errorf("ref %q in %s is outside %s", ref, nsid, g.prefix)
+	}
+	return def, nil
+}
+
+// typeName names the Go type for a definition: social.coves.richtext.facet#byteSlice
+// becomes RichtextFacet_ByteSlice
+func (g *generator) typeName(nsid, defName string) string {
+	var name string
+	for _, part := range strings.Split(strings.TrimPrefix(nsid, g.prefix+"."), ".") {
+		name += title(part)
+	}
+	if defName != "main" {
+		name += "_" + title(defName)
+	}
+	return name
+}
+
+// typeID is the $type value for a definition
+func typeID(nsid, defName string) string {
+	if defName == "main" {
+		return nsid
+	}
+	return nsid + "#" + defName
+}
+
+// render writes one file per schema, with its structs and unions in name order
+func (g *generator) render(pkg string) (map[string][]byte, []string, error) {
+	bySchema := make(map[string][]string)
+	for name, s := range g.structs {
+		bySchema[s.Schema] = append(bySchema[s.Schema], name)
+	}
+	for name, u := range g.unions {
+		bySchema[u.Schema] = append(bySchema[u.Schema], name)
+	}
+
+	files := make(map[string][]byte)
+	for _, nsid := range sortedKeys(bySchema) {
+		names := bySchema[nsid]
+		sort.Strings(names)
+
+		var body bytes.Buffer
+		var records []string
+		usesUtil, usesUnion, usesRepo := false, false, false
+		for _, name := range names {
+			if s, ok := g.structs[name]; ok {
+				writeStruct(&body, s)
+				if s.Record {
+					records = append(records, name)
+				}
+				for _, f := range s.Fields {
+					usesUtil = usesUtil || strings.Contains(f.Type, "util.")
+					usesRepo = usesRepo || strings.Contains(f.Type, "atrepo.")
+				}
+			} else {
+				writeUnion(&body, g.unions[name])
+				usesUnion = true
+			}
+		}
+
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "%s\n\npackage %s\n\n// schema: %s\n\n", generatedHeader, pkg, nsid)
+		buf.WriteString("import (\n")
+		if usesUnion {
+			buf.WriteString("\t\"bytes\"\n\t\"encoding/json\"\n\t\"fmt\"\n\t\"io\"\n\n")
+		}
+		if usesRepo {
+			buf.WriteString("\tatrepo \"Coves/internal/atproto/repo\"\n\n")
+		}
+		if usesUnion || usesUtil || len(records) > 0 {
+			buf.WriteString("\t\"github.com/bluesky-social/indigo/lex/util\"\n")
+		}
+		if usesUnion {
+			buf.WriteString("\tcbg \"github.com/whyrusleeping/cbor-gen\"\n")
+		}
+		buf.WriteString(")\n\n")
+		if len(records) > 0 {
+			buf.WriteString("func init() {\n")
+			for _, name := range records {
+				fmt.Fprintf(&buf, "\tutil.RegisterType(%q, &%s{})\n", g.structs[name].TypeID, name)
+			}
+			buf.WriteString("}\n\n")
+		}
+		buf.Write(body.Bytes())
+
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, nil, fmt.Errorf("formatting %s: %w\n%s", nsid, err, buf.Bytes())
+		}
+		files[fileName(nsid, g.prefix)] = src
+	}
+
+	return files, sortedKeys(g.structs), nil
+}
+
+func writeStruct(w *bytes.Buffer, s *goStruct) {
+	if s.DefName != "" {
+		fmt.Fprintf(w, "// %s is a %q in the %s schema.\n", s.Name, s.DefName, s.Schema)
+	} else {
+		fmt.Fprintf(w, "// %s is an inline object in the %s schema.\n", s.Name, s.Schema)
+	}
+	if s.Description != "" {
+		fmt.Fprintf(w, "//\n// %s\n", comment(s.Description))
+	}
+	if s.Record {
+		fmt.Fprintf(w, "//\n// RECORDTYPE: %s\n", s.Name)
+	}
+
+	fmt.Fprintf(w, "type %s struct {\n", s.Name)
+	if s.TypeID != "" {
+		fmt.Fprintf(w, "\tLexiconTypeID string `json:\"$type,const=%s\" cborgen:\"$type,const=%s\"`\n", s.TypeID, s.TypeID)
+	}
+	for _, f := range s.Fields {
+		if f.Description != "" {
+			fmt.Fprintf(w, "\t// %s: %s\n", f.JSONName, comment(f.Description))
+		}
+		tag := f.JSONName
+		if f.OmitEmpty {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(w, "\t%s %s `json:%q cborgen:%q`\n", f.Name, f.Type, tag, tag)
+	}
+	w.WriteString("}\n\n")
+}
+
+// writeUnion writes a union wrapper with JSON and CBOR methods that dispatch on $type
+func writeUnion(w *bytes.Buffer, u *goUnion) {
+	fmt.Fprintf(w, "type %s struct {\n", u.Name)
+	for _, v := range u.Variants {
+		fmt.Fprintf(w, "\t%s *%s\n", v.GoType, v.GoType)
+	}
+	w.WriteString("}\n\n")
+
+	fmt.Fprintf(w, "func (t *%s) MarshalJSON() ([]byte, error) {\n", u.Name)
+	for _, v := range u.Variants {
+		fmt.Fprintf(w, "\tif t.%s != nil {\n\t\tt.%s.LexiconTypeID = %q\n\t\treturn json.Marshal(t.%s)\n\t}\n", v.GoType, v.GoType, v.TypeID, v.GoType)
+	}
+	w.WriteString("\treturn nil, fmt.Errorf(\"cannot marshal empty enum\")\n}\n\n")
+
+	fmt.Fprintf(w, "func (t *%s) UnmarshalJSON(b []byte) error {\n", u.Name)
+	w.WriteString("\ttyp, err := util.TypeExtract(b)\n\tif err != nil {\n\t\treturn err\n\t}\n\n\tswitch typ {\n")
+	for _, v := range u.Variants {
+		fmt.Fprintf(w, "\tcase %q:\n\t\tt.%s = new(%s)\n\t\treturn json.Unmarshal(b, t.%s)\n", v.TypeID, v.GoType, v.GoType, v.GoType)
+	}
+	writeUnknownVariant(w, u)
+
+	fmt.Fprintf(w, "func (t *%s) MarshalCBOR(w io.Writer) error {\n", u.Name)
+	w.WriteString("\tif t == nil {\n\t\t_, err := w.Write(cbg.CborNull)\n\t\treturn err\n\t}\n")
+	for _, v := range u.Variants {
+		fmt.Fprintf(w, "\tif t.%s != nil {\n\t\treturn t.%s.MarshalCBOR(w)\n\t}\n", v.GoType, v.GoType)
+	}
+	w.WriteString("\treturn fmt.Errorf(\"cannot cbor marshal empty enum\")\n}\n\n")
+
+	fmt.Fprintf(w, "func (t *%s) UnmarshalCBOR(r io.Reader) error {\n", u.Name)
+	w.WriteString("\ttyp, b, err := util.CborTypeExtractReader(r)\n\tif err != nil {\n\t\treturn err\n\t}\n\n\tswitch typ {\n")
+	for _, v := range u.Variants {
+		fmt.Fprintf(w, "\tcase %q:\n\t\tt.%s = new(%s)\n\t\treturn t.%s.UnmarshalCBOR(bytes.NewReader(b))\n", v.TypeID, v.GoType, v.GoType, v.GoType)
+	}
+	writeUnknownVariant(w, u)
+}
+
+// writeUnknownVariant ends a $type switch. Closed unions reject types they
+// don't list; open unions ignore them, since they may hold types added later.
+func writeUnknownVariant(w *bytes.Buffer, u *goUnion) {
+	if u.Closed {
+		w.WriteString("\tdefault:\n\t\treturn fmt.Errorf(\"unexpected $type %q in closed union\", typ)\n\t}\n}\n\n")
+	} else {
+		w.WriteString("\tdefault:\n\t\treturn nil\n\t}\n}\n\n")
+	}
+}
+
+// fileName names the file for a schema: social.coves.post.record is postrecord.go
+func fileName(nsid, prefix string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(nsid, prefix+"."), ".", "")) + ".go"
+}
+
+// description returns a field's description, if its schema has one
+func description(def any) string {
+	var desc *string
+	switch d := def.(type) {
+	case lexicon.SchemaString:
+		desc = d.Description
+	case lexicon.SchemaInteger:
+		desc = d.Description
+	case lexicon.SchemaBoolean:
+		desc = d.Description
+	case lexicon.SchemaBytes:
+		desc = d.Description
+	case lexicon.SchemaCIDLink:
+		desc = d.Description
+	case lexicon.SchemaBlob:
+		desc = d.Description
+	case lexicon.SchemaUnknown:
+		desc = d.Description
+	case lexicon.SchemaArray:
+		desc = d.Description
+	case lexicon.SchemaObject:
+		desc = d.Description
+	case lexicon.SchemaRef:
+		desc = d.Description
+	case lexicon.SchemaUnion:
+		desc = d.Description
+	}
+	return deref(desc)
+}
+
+// comment flattens text onto one comment line
+func comment(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// title upper-cases the first letter of s
+func title(s string) string {
+	r := []rune(s)
+	if len(r) == 0 {
+		return s
+	}
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
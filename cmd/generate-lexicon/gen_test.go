@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// The checked-in package must match what the generator produces from the
+// current lexicons, so a schema change can't land without regenerating
+func TestGeneratedTypesUpToDate(t *testing.T) {
+	defs, err := loadSchemas("../../internal/atproto/lexicon")
+	if err != nil {
+		t.Fatalf("Failed to load schemas: %v", err)
+	}
+	files, _, err := generate(defs, "social.coves", "coves")
+	if err != nil {
+		t.Fatalf("Failed to generate types: %v", err)
+	}
+
+	dir := "../../internal/atproto/coves"
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s: %v (run go run ./cmd/generate-lexicon)", name, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date (run go run ./cmd/generate-lexicon)", name)
+		}
+	}
+}
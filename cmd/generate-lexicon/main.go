@@ -0,0 +1,90 @@
+// Command generate-lexicon generates Go types for the Coves lexicons. Each
+// record, and every object and union it references, becomes a struct with
+// JSON tags and cbor-gen MarshalCBOR/UnmarshalCBOR methods.
+//
+// Run it from the repository root after changing a lexicon:
+//
+//	go run ./cmd/generate-lexicon
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		schemaPath = flag.String("path", "internal/atproto/lexicon", "Path to lexicon schemas directory")
+		outDir     = flag.String("out", "internal/atproto/coves", "Directory to write the generated package to")
+		importPath = flag.String("import", "Coves/internal/atproto/coves", "Import path of the generated package")
+		prefix     = flag.String("prefix", "social.coves", "NSID prefix of the records to generate")
+		verbose    = flag.Bool("v", false, "Verbose output")
+	)
+	flag.Parse()
+
+	defs, err := loadSchemas(*schemaPath)
+	if err != nil {
+		log.Fatalf("Failed to load schemas: %v", err)
+	}
+
+	pkg := filepath.Base(*outDir)
+	files, structs, err := generate(defs, *prefix, pkg)
+	if err != nil {
+		log.Fatalf("Failed to generate types: %v", err)
+	}
+
+	if err := writeFiles(*outDir, files, *verbose); err != nil {
+		log.Fatalf("Failed to write types: %v", err)
+	}
+	if err := generateCBOR(*outDir, *importPath, pkg, structs); err != nil {
+		log.Fatalf("Failed to generate CBOR marshalers: %v", err)
+	}
+
+	fmt.Printf("✅ Generated %d types in %d files under %s\n", len(structs), len(files)+1, *outDir)
+}
+
+// writeFiles replaces the generated files in dir, removing any left over
+// from schemas that no longer exist. Hand-written files are left alone.
+func writeFiles(dir string, files map[string][]byte, verbose bool) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	existing, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+	for _, path := range existing {
+		name := filepath.Base(path)
+		if _, ok := files[name]; ok || name == cborGenFile || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(string(data), generatedHeader) {
+			if verbose {
+				fmt.Printf("  removing %s\n", path)
+			}
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(files) {
+		path := filepath.Join(dir, name)
+		if verbose {
+			fmt.Printf("  writing %s\n", path)
+		}
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,24 +1,42 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"encoding/base64"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/bluesky-social/indigo/atproto/crypto"
+	atid "github.com/bluesky-social/indigo/atproto/identity"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	_ "github.com/lib/pq"
 	"github.com/pressly/goose/v3"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
 	"Coves/internal/api/routes"
+	"Coves/internal/api/xrpc"
 	"Coves/internal/atproto/carstore"
+	"Coves/internal/atproto/identity"
+	"Coves/internal/atproto/plc"
+	atrepo "Coves/internal/atproto/repo"
+	"Coves/internal/core/auth"
+	"Coves/internal/core/blobs"
+	"Coves/internal/core/compaction"
+	"Coves/internal/core/events"
+	"Coves/internal/core/keys"
+	"Coves/internal/core/migration"
 	"Coves/internal/core/repository"
 	"Coves/internal/core/users"
 	postgresRepo "Coves/internal/db/postgres"
+	"Coves/internal/validation"
 )
 
 func main() {
@@ -66,20 +84,170 @@ func main() {
 	userRepo := postgresRepo.NewUserRepository(db)
 	_ = users.NewUserService(userRepo) // TODO: Use when UserRoutes is fixed
 
-	// Initialize carstore for ATProto repository storage
-	carDirs := []string{"./data/carstore"}
-	repoStore, err := carstore.NewRepoStore(gormDB, carDirs)
+	// Initialize carstore for ATProto repository storage. The file backend
+	// keeps its metadata in Postgres; sqlite and memory need no schema.
+	carstoreConfig := carstore.Config{
+		Backend: os.Getenv("CARSTORE_BACKEND"),
+		Dir:     os.Getenv("CARSTORE_DIR"),
+	}
+	if carstoreConfig.Dir == "" {
+		carstoreConfig.Dir = "./data/carstore"
+	}
+	if v := os.Getenv("CARSTORE_MAPPING_CACHE_SIZE"); v != "" {
+		carstoreConfig.MappingCacheSize, err = strconv.Atoi(v)
+		if err != nil {
+			log.Fatal("Invalid CARSTORE_MAPPING_CACHE_SIZE:", err)
+		}
+	}
+	repoStore, err := carstore.OpenRepoStore(carstoreConfig, gormDB)
 	if err != nil {
 		log.Fatal("Failed to initialize repo store:", err)
 	}
+	defer repoStore.Close()
+	if carstoreConfig.Backend == carstore.BackendMemory {
+		log.Println("Using the in-memory carstore; repositories are lost on restart")
+	}
+
+	// Initialize signing key management; private keys are encrypted at rest
+	keySecret, err := base64.StdEncoding.DecodeString(os.Getenv("SIGNING_KEY_SECRET"))
+	if err != nil {
+		log.Fatal("Failed to decode SIGNING_KEY_SECRET:", err)
+	}
+	keyEncryptor, err := keys.NewEncryptor(keySecret)
+	if err != nil {
+		log.Fatal("Invalid SIGNING_KEY_SECRET (expected base64-encoded 32 bytes):", err)
+	}
+	keyAlgorithm := os.Getenv("SIGNING_KEY_ALGORITHM")
+	if keyAlgorithm == "" {
+		keyAlgorithm = keys.AlgorithmK256
+	}
+	keyService, err := keys.NewService(postgresRepo.NewSigningKeyRepo(db), keyEncryptor, keyAlgorithm)
+	if err != nil {
+		log.Fatal("Failed to initialize key service:", err)
+	}
 
 	repositoryRepo := postgresRepo.NewRepositoryRepo(db)
-	repositoryService := repository.NewService(repositoryRepo, repoStore)
+	// Imported repositories are verified against the signing key in the DID document
+	keyResolver := identity.NewDirectoryResolver(atid.DefaultDirectory())
+	repositoryService := repository.NewService(repositoryRepo, repoStore, keyService, keyResolver)
+	importLimits := atrepo.DefaultImportLimits
+	if v := os.Getenv("IMPORT_MAX_BYTES"); v != "" {
+		importLimits.MaxBytes, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			log.Fatal("Invalid IMPORT_MAX_BYTES:", err)
+		}
+	}
+	if v := os.Getenv("IMPORT_MAX_BLOCKS"); v != "" {
+		importLimits.MaxBlocks, err = strconv.Atoi(v)
+		if err != nil {
+			log.Fatal("Invalid IMPORT_MAX_BLOCKS:", err)
+		}
+	}
+	repositoryService.SetImportLimits(importLimits)
+
+	// Records are checked against the lexicons they declare before they're written
+	lexiconPath := os.Getenv("LEXICON_PATH")
+	if lexiconPath == "" {
+		lexiconPath = "internal/atproto/lexicon"
+	}
+	lexiconValidator, err := validation.NewLexiconValidator(lexiconPath, false)
+	if err != nil {
+		log.Fatal("Failed to load lexicons:", err)
+	}
+	repositoryService.SetRecordValidator(lexiconValidator)
+
+	// Writes to a repository are serialised across instances with advisory locks
+	repositoryService.SetRepoLocker(postgresRepo.NewAdvisoryLocker(db))
+
+	// Repository events are sequenced in Postgres and served over subscribeRepos
+	eventRetention := 72 * time.Hour
+	if v := os.Getenv("EVENT_RETENTION"); v != "" {
+		eventRetention, err = time.ParseDuration(v)
+		if err != nil {
+			log.Fatal("Invalid EVENT_RETENTION:", err)
+		}
+	}
+	eventService := events.NewService(postgresRepo.NewEventRepo(db), eventRetention)
+	repositoryService.SetEventPublisher(eventService)
+	go func() {
+		for range time.Tick(time.Hour) {
+			if n, err := eventService.Prune(); err != nil {
+				log.Println("Failed to prune events:", err)
+			} else if n > 0 {
+				log.Printf("Pruned %d events older than %s", n, eventRetention)
+			}
+		}
+	}()
+
+	// Carstore shards are compacted in the background; COMPACTION_INTERVAL=0
+	// leaves compaction to the admin methods
+	compactionPolicy := compaction.DefaultPolicy
+	if v := os.Getenv("COMPACTION_INTERVAL"); v != "" {
+		compactionPolicy.Interval, err = time.ParseDuration(v)
+		if err != nil {
+			log.Fatal("Invalid COMPACTION_INTERVAL:", err)
+		}
+	}
+	if v := os.Getenv("COMPACTION_CONCURRENCY"); v != "" {
+		compactionPolicy.Concurrency, err = strconv.Atoi(v)
+		if err != nil {
+			log.Fatal("Invalid COMPACTION_CONCURRENCY:", err)
+		}
+	}
+	compactor := compaction.NewScheduler(repositoryService, postgresRepo.NewCompactionRepo(db), compactionPolicy)
+	go compactor.Run(context.Background())
+
+	// Blobs are kept on disk with their metadata in Postgres
+	blobDir := os.Getenv("BLOB_DIR")
+	if blobDir == "" {
+		blobDir = "./data/blobs"
+	}
+	blobStore, err := blobs.NewDiskStore(blobDir)
+	if err != nil {
+		log.Fatal("Failed to initialize blob store:", err)
+	}
+	blobService := blobs.NewService(postgresRepo.NewBlobRepo(db), blobStore, repositoryService)
+
+	// Accounts migrating in or out update their DID through the PLC directory.
+	// PUBLIC_URL is the endpoint put in their DID documents.
+	plcURL := os.Getenv("PLC_URL")
+	if plcURL == "" {
+		plcURL = plc.DefaultDirectoryURL
+	}
+	publicURL := os.Getenv("PUBLIC_URL")
+	migrationService := migration.NewService(repositoryService, keyService, plc.NewClient(plcURL), publicURL)
+	if v := os.Getenv("PLC_ROTATION_KEY"); v != "" {
+		rotationKey, err := crypto.ParsePrivateMultibase(v)
+		if err != nil {
+			log.Fatal("Invalid PLC_ROTATION_KEY:", err)
+		}
+		migrationService.SetRotationKey(rotationKey)
+	}
 
 	// Mount routes
 	// TODO: Fix UserRoutes to accept *UserService
 	// r.Mount("/api/users", routes.UserRoutes(userService))
-	r.Mount("/", routes.RepositoryRoutes(repositoryService))
+	// XRPC methods are dispatched by NSID and checked against the lexicons
+	xrpcServer := xrpc.NewServer(lexiconValidator)
+	// Account owners authenticate with the access token createRepo returns
+	tokenService := auth.NewService(postgresRepo.NewAccessTokenRepo(db))
+	routes.RepositoryRoutes(xrpcServer, repositoryService, tokenService)
+	routes.BlobRoutes(xrpcServer, blobService, tokenService)
+	adminPassword := os.Getenv("ADMIN_PASSWORD")
+	if adminPassword != "" {
+		routes.AdminRoutes(xrpcServer, repositoryService, compactor, tokenService, adminPassword)
+	} else {
+		log.Println("ADMIN_PASSWORD not set; admin methods are disabled")
+	}
+	if publicURL != "" {
+		routes.IdentityRoutes(xrpcServer, migrationService, adminPassword)
+	} else {
+		log.Println("PUBLIC_URL not set; identity methods are disabled")
+	}
+	r.Handle("/xrpc/*", xrpcServer)
+	r.Mount(routes.SubscribeReposPath, routes.EventRoutes(eventService))
+
+	r.Handle("/metrics", promhttp.Handler())
 
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
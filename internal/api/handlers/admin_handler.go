@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"Coves/internal/api/xrpc"
+	atrepo "Coves/internal/atproto/repo"
+	"Coves/internal/core/auth"
+	"Coves/internal/core/compaction"
+	"Coves/internal/core/repository"
+)
+
+// AdminHandler serves the social.coves.admin.* methods. They are registered
+// behind xrpc.AdminAuth, so handlers don't check credentials themselves.
+type AdminHandler struct {
+	service    repository.RepositoryService
+	compaction *compaction.Scheduler
+	tokens     auth.TokenService
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(service repository.RepositoryService, compaction *compaction.Scheduler) *AdminHandler {
+	return &AdminHandler{service: service, compaction: compaction}
+}
+
+// SetTokenService sets the service createAccessToken issues tokens from
+func (h *AdminHandler) SetTokenService(tokens auth.TokenService) {
+	h.tokens = tokens
+}
+
+// RepoStatusOutput represents a repository's account status in admin responses
+type RepoStatusOutput struct {
+	DID    string `json:"did"`
+	Active bool   `json:"active"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// UpdateRepoStatus serves social.coves.admin.updateRepoStatus
+func (h *AdminHandler) UpdateRepoStatus(ctx context.Context, req *xrpc.Request) (interface{}, error) {
+	var input struct {
+		DID    string `json:"did"`
+		Status string `json:"status"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(req.Input, &input); err != nil {
+		return nil, xrpc.Errorf(http.StatusBadRequest, "InvalidRequest", "invalid input: %v", err)
+	}
+
+	repo, err := h.service.UpdateRepositoryStatus(input.DID, input.Status, input.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update repository status: %w", err)
+	}
+
+	return RepoStatusOutput{
+		DID:    repo.DID,
+		Active: repo.Active(),
+		Status: repo.Status,
+		Reason: repo.StatusReason,
+	}, nil
+}
+
+// AccessTokenOutput represents an issued access token in admin responses
+type AccessTokenOutput struct {
+	DID         string `json:"did"`
+	AccessToken string `json:"accessToken"`
+}
+
+// CreateAccessToken serves social.coves.admin.createAccessToken
+func (h *AdminHandler) CreateAccessToken(ctx context.Context, req *xrpc.Request) (interface{}, error) {
+	var input struct {
+		DID string `json:"did"`
+	}
+	if err := json.Unmarshal(req.Input, &input); err != nil {
+		return nil, xrpc.Errorf(http.StatusBadRequest, "InvalidRequest", "invalid input: %v", err)
+	}
+
+	if _, err := h.service.GetRepository(input.DID); err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+	token, err := h.tokens.IssueToken(input.DID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue access token: %w", err)
+	}
+
+	return AccessTokenOutput{DID: input.DID, AccessToken: token}, nil
+}
+
+// CollectionCount is the number of records in one collection
+type CollectionCount struct {
+	Collection string `json:"collection"`
+	Records    int    `json:"records"`
+}
+
+// RepoStatsOutput represents a repository's records and storage in admin responses
+type RepoStatsOutput struct {
+	DID             string            `json:"did"`
+	Status          string            `json:"status"`
+	Rev             string            `json:"rev,omitempty"`
+	Shards          int               `json:"shards"`
+	DiskBytes       int64             `json:"diskBytes"`
+	RecordCount     int               `json:"recordCount"`
+	Collections     []CollectionCount `json:"collections"`
+	LastWriteAt     string            `json:"lastWriteAt,omitempty"`
+	LastCompactedAt string            `json:"lastCompactedAt,omitempty"`
+}
+
+// GetRepoStats serves social.coves.admin.getRepoStats
+func (h *AdminHandler) GetRepoStats(ctx context.Context, req *xrpc.Request) (interface{}, error) {
+	did := req.Params.String("did")
+
+	stats, err := h.service.GetRepositoryStats(did)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository stats: %w", err)
+	}
+	last, err := h.compaction.LastCompaction(did)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last compaction: %w", err)
+	}
+
+	out := RepoStatsOutput{
+		DID:         stats.DID,
+		Status:      stats.Status,
+		Rev:         stats.Revision,
+		Shards:      stats.Shards,
+		DiskBytes:   stats.DiskBytes,
+		RecordCount: stats.RecordCount,
+		Collections: make([]CollectionCount, 0, len(stats.Collections)),
+	}
+	for collection, records := range stats.Collections {
+		out.Collections = append(out.Collections, CollectionCount{Collection: collection, Records: records})
+	}
+	sort.Slice(out.Collections, func(i, j int) bool { return out.Collections[i].Collection < out.Collections[j].Collection })
+	if !stats.UpdatedAt.IsZero() {
+		out.LastWriteAt = stats.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	if last != nil {
+		out.LastCompactedAt = last.CreatedAt.UTC().Format(time.RFC3339)
+	}
+
+	return out, nil
+}
+
+// RecordChangeOutput represents a changed record in admin responses
+type RecordChangeOutput struct {
+	Action     string          `json:"action"`
+	URI        string          `json:"uri"`
+	Collection string          `json:"collection"`
+	RecordKey  string          `json:"rkey"`
+	PrevCID    string          `json:"prevCid,omitempty"`
+	CID        string          `json:"cid,omitempty"`
+	PrevValue  json.RawMessage `json:"prevValue,omitempty"`
+	Value      json.RawMessage `json:"value,omitempty"`
+}
+
+// RepoDiffOutput represents the changes between two revisions in admin responses
+type RepoDiffOutput struct {
+	DID        string               `json:"did"`
+	From       string               `json:"from,omitempty"`
+	FromCommit string               `json:"fromCommit,omitempty"`
+	To         string               `json:"to"`
+	ToCommit   string               `json:"toCommit"`
+	Changes    []RecordChangeOutput `json:"changes"`
+}
+
+// GetRepoDiff serves social.coves.admin.getRepoDiff
+func (h *AdminHandler) GetRepoDiff(ctx context.Context, req *xrpc.Request) (interface{}, error) {
+	did := req.Params.String("did")
+
+	diff, err := h.service.DiffRepository(ctx, did, req.Params.String("from"), req.Params.String("to"), req.Params.Bool("values"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff repository: %w", err)
+	}
+
+	out := RepoDiffOutput{
+		DID:      diff.DID,
+		From:     diff.FromRevision,
+		To:       diff.ToRevision,
+		ToCommit: diff.ToCommit.String(),
+		Changes:  make([]RecordChangeOutput, len(diff.Changes)),
+	}
+	if diff.FromCommit.Defined() {
+		out.FromCommit = diff.FromCommit.String()
+	}
+	for i, change := range diff.Changes {
+		c := RecordChangeOutput{
+			Action:     change.Action,
+			URI:        change.URI,
+			Collection: change.Collection,
+			RecordKey:  change.RecordKey,
+		}
+		if change.PrevCID.Defined() {
+			c.PrevCID = change.PrevCID.String()
+		}
+		if change.CID.Defined() {
+			c.CID = change.CID.String()
+		}
+		if change.PrevValue != nil {
+			if c.PrevValue, err = atrepo.RecordCBORToJSON(change.PrevValue); err != nil {
+				return nil, fmt.Errorf("failed to encode record %s: %w", change.URI, err)
+			}
+		}
+		if change.Value != nil {
+			if c.Value, err = atrepo.RecordCBORToJSON(change.Value); err != nil {
+				return nil, fmt.Errorf("failed to encode record %s: %w", change.URI, err)
+			}
+		}
+		out.Changes[i] = c
+	}
+
+	return out, nil
+}
+
+// CompactionOutput represents a compaction result in admin responses
+type CompactionOutput struct {
+	DID           string `json:"did"`
+	Aggressive    bool   `json:"aggressive"`
+	Trigger       string `json:"trigger"`
+	StartShards   int    `json:"startShards"`
+	EndShards     int    `json:"endShards"`
+	ShardsDeleted int    `json:"shardsDeleted"`
+	DurationMS    int64  `json:"durationMs"`
+	Error         string `json:"error,omitempty"`
+	CreatedAt     string `json:"createdAt,omitempty"`
+}
+
+func compactionOutput(r *compaction.Result) CompactionOutput {
+	out := CompactionOutput{
+		DID:           r.DID,
+		Aggressive:    r.Aggressive,
+		Trigger:       r.Trigger,
+		StartShards:   r.StartShards,
+		EndShards:     r.EndShards,
+		ShardsDeleted: r.ShardsDeleted,
+		DurationMS:    r.Duration.Milliseconds(),
+		Error:         r.Error,
+	}
+	if !r.CreatedAt.IsZero() {
+		out.CreatedAt = r.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	return out
+}
+
+// CompactRepo serves social.coves.admin.compactRepo
+func (h *AdminHandler) CompactRepo(ctx context.Context, req *xrpc.Request) (interface{}, error) {
+	var input struct {
+		DID        string `json:"did"`
+		Aggressive bool   `json:"aggressive"`
+	}
+	if err := json.Unmarshal(req.Input, &input); err != nil {
+		return nil, xrpc.Errorf(http.StatusBadRequest, "InvalidRequest", "invalid input: %v", err)
+	}
+
+	result, err := h.compaction.Compact(input.DID, input.Aggressive, compaction.TriggerAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compact repository: %w", err)
+	}
+
+	return compactionOutput(result), nil
+}
+
+// RunCompaction serves social.coves.admin.runCompaction
+func (h *AdminHandler) RunCompaction(ctx context.Context, req *xrpc.Request) (interface{}, error) {
+	return map[string]interface{}{
+		"started": h.compaction.StartSweep(compaction.TriggerAdmin),
+	}, nil
+}
+
+// ListCompactions serves social.coves.admin.listCompactions
+func (h *AdminHandler) ListCompactions(ctx context.Context, req *xrpc.Request) (interface{}, error) {
+	results, err := h.compaction.Recent(int(req.Params.Int("limit")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compactions: %w", err)
+	}
+
+	outputs := make([]CompactionOutput, len(results))
+	for i, r := range results {
+		outputs[i] = compactionOutput(r)
+	}
+
+	return map[string]interface{}{"compactions": outputs}, nil
+}
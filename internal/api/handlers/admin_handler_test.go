@@ -0,0 +1,367 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"Coves/internal/api/xrpc"
+	"Coves/internal/atproto/carstore"
+	"Coves/internal/core/compaction"
+	"Coves/internal/core/repository"
+	"Coves/internal/validation"
+
+	"github.com/ipfs/go-cid"
+)
+
+// mockCompactionTarget compacts repositories down to a fixed shard count
+type mockCompactionTarget struct {
+	shards map[string]int
+}
+
+func (m *mockCompactionTarget) ListRepositories(limit int, cursor string) ([]*repository.Repository, string, error) {
+	return nil, "", nil
+}
+
+func (m *mockCompactionTarget) ShardCount(did string) (int, error) {
+	return m.shards[did], nil
+}
+
+func (m *mockCompactionTarget) CompactRepository(did string, aggressive bool) (*carstore.CompactionStats, error) {
+	before, ok := m.shards[did]
+	if !ok {
+		return nil, errors.New("repository not found")
+	}
+	m.shards[did] = 10
+	return &carstore.CompactionStats{StartShards: before, ShardsDeleted: before - 10}, nil
+}
+
+// mockCompactionResults keeps compaction results in memory
+type mockCompactionResults struct {
+	results []*compaction.Result
+}
+
+func (m *mockCompactionResults) Create(result *compaction.Result) error {
+	result.CreatedAt = time.Now()
+	m.results = append(m.results, result)
+	return nil
+}
+
+func (m *mockCompactionResults) GetLatestSuccess(did string) (*compaction.Result, error) {
+	for i := len(m.results) - 1; i >= 0; i-- {
+		if r := m.results[i]; r.DID == did && r.Error == "" {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockCompactionResults) List(limit int) ([]*compaction.Result, error) {
+	var out []*compaction.Result
+	for i := len(m.results) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, m.results[i])
+	}
+	return out, nil
+}
+
+func newAdminServer(t *testing.T, service repository.RepositoryService, target compaction.Target) *xrpc.Server {
+	lexicons, err := validation.NewLexiconValidator("../../atproto/lexicon", false)
+	if err != nil {
+		t.Fatalf("Failed to load lexicons: %v", err)
+	}
+	server := xrpc.NewServer(lexicons)
+	scheduler := compaction.NewScheduler(target, &mockCompactionResults{}, compaction.DefaultPolicy)
+	handler := NewAdminHandler(service, scheduler)
+	handler.SetTokenService(mockTokenService{})
+	server.Handle("social.coves.admin.updateRepoStatus", xrpc.AdminAuth("secret", handler.UpdateRepoStatus))
+	server.Handle("social.coves.admin.createAccessToken", xrpc.AdminAuth("secret", handler.CreateAccessToken))
+	server.Handle("social.coves.admin.getRepoStats", xrpc.AdminAuth("secret", handler.GetRepoStats))
+	server.Handle("social.coves.admin.getRepoDiff", xrpc.AdminAuth("secret", handler.GetRepoDiff))
+	server.Handle("social.coves.admin.compactRepo", xrpc.AdminAuth("secret", handler.CompactRepo))
+	server.Handle("social.coves.admin.runCompaction", xrpc.AdminAuth("secret", handler.RunCompaction))
+	server.Handle("social.coves.admin.listCompactions", xrpc.AdminAuth("secret", handler.ListCompactions))
+	return server
+}
+
+func TestUpdateRepoStatusHandler(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	mockService.CreateRepository("did:plc:test123")
+	server := newAdminServer(t, mockService, &mockCompactionTarget{})
+
+	call := func(password, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/xrpc/social.coves.admin.updateRepoStatus", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if password != "" {
+			req.SetBasicAuth("admin", password)
+		}
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		return w
+	}
+	takedown := `{"did": "did:plc:test123", "status": "takendown", "reason": "spam"}`
+
+	tests := []struct {
+		name     string
+		password string
+		body     string
+		wantCode int
+	}{
+		{"no auth", "", takedown, http.StatusUnauthorized},
+		{"wrong password", "guess", takedown, http.StatusUnauthorized},
+		{"unknown status", "secret", `{"did": "did:plc:test123", "status": "banished"}`, http.StatusBadRequest},
+		{"missing repo", "secret", `{"did": "did:plc:missing", "status": "takendown"}`, http.StatusBadRequest},
+		{"takedown", "secret", takedown, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := call(tt.password, tt.body); w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	repo := mockService.repositories["did:plc:test123"]
+	if repo.Status != repository.StatusTakendown || repo.StatusReason != "spam" {
+		t.Errorf("Expected a takedown for spam, got %q %q", repo.Status, repo.StatusReason)
+	}
+
+	// Restoring the repository brings it back as it was
+	if w := call("secret", `{"did": "did:plc:test123", "status": "active"}`); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !repo.Active() {
+		t.Errorf("Expected the repository to be active, got %q", repo.Status)
+	}
+}
+
+func TestCreateAccessTokenHandler(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	mockService.CreateRepository("did:plc:test123")
+	server := newAdminServer(t, mockService, &mockCompactionTarget{})
+
+	call := func(password, did string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/xrpc/social.coves.admin.createAccessToken", strings.NewReader(`{"did": "`+did+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if password != "" {
+			req.SetBasicAuth("admin", password)
+		}
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := call("", "did:plc:test123"); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 without auth, got %d", w.Code)
+	}
+	if w := call("secret", "did:plc:missing"); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "RepoNotFound") {
+		t.Errorf("Expected 400 RepoNotFound, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w := call("secret", "did:plc:test123")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var out AccessTokenOutput
+	if err := json.NewDecoder(w.Body).Decode(&out); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if out.DID != "did:plc:test123" || out.AccessToken != "token-did:plc:test123" {
+		t.Errorf("Unexpected token %+v", out)
+	}
+}
+
+func TestCompactionHandlers(t *testing.T) {
+	target := &mockCompactionTarget{shards: map[string]int{"did:plc:test123": 60}}
+	server := newAdminServer(t, NewMockRepositoryService(), target)
+
+	call := func(method, nsid, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/xrpc/"+nsid, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		req.SetBasicAuth("admin", "secret")
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		return w
+	}
+
+	w := call("POST", "social.coves.admin.compactRepo", `{"did": "did:plc:test123", "aggressive": true}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var result CompactionOutput
+	if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !result.Aggressive || result.Trigger != compaction.TriggerAdmin || result.StartShards != 60 || result.EndShards != 10 || result.ShardsDeleted != 50 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	// Failures are reported and still recorded
+	if w := call("POST", "social.coves.admin.compactRepo", `{"did": "did:plc:missing"}`); w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = call("GET", "social.coves.admin.listCompactions?limit=10", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var list struct {
+		Compactions []CompactionOutput `json:"compactions"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(list.Compactions) != 2 || list.Compactions[0].DID != "did:plc:missing" || list.Compactions[0].Error == "" {
+		t.Errorf("Expected both compactions newest first, got %+v", list.Compactions)
+	}
+
+	w = call("POST", "social.coves.admin.runCompaction", "")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"started":true`) {
+		t.Errorf("Expected a sweep to start, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetRepoStatsHandler(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	mockService.CreateRepository("did:plc:test123")
+	mockService.UpdateRepositoryStatus("did:plc:test123", repository.StatusTakendown, "spam")
+	for _, r := range []struct{ collection, rkey string }{
+		{"social.coves.post.record", "a"},
+		{"social.coves.post.record", "b"},
+		{"social.coves.actor.profile", "self"},
+	} {
+		mockService.CreateRecord(repository.CreateRecordInput{DID: "did:plc:test123", Collection: r.collection, RecordKey: r.rkey})
+	}
+	target := &mockCompactionTarget{shards: map[string]int{"did:plc:test123": 60}}
+	server := newAdminServer(t, mockService, target)
+
+	call := func(did string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/xrpc/social.coves.admin.getRepoStats?did="+did, nil)
+		req.SetBasicAuth("admin", "secret")
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		return w
+	}
+
+	// Taken down repositories can still be inspected
+	w := call("did:plc:test123")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var stats RepoStatsOutput
+	if err := json.NewDecoder(w.Body).Decode(&stats); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := []CollectionCount{{"social.coves.actor.profile", 1}, {"social.coves.post.record", 2}}
+	if stats.Status != repository.StatusTakendown || stats.RecordCount != 3 || len(stats.Collections) != 2 ||
+		stats.Collections[0] != want[0] || stats.Collections[1] != want[1] {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	if stats.LastCompactedAt != "" {
+		t.Errorf("Expected no compaction yet, got %s", stats.LastCompactedAt)
+	}
+
+	req := httptest.NewRequest("POST", "/xrpc/social.coves.admin.compactRepo", strings.NewReader(`{"did": "did:plc:test123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth("admin", "secret")
+	server.ServeHTTP(httptest.NewRecorder(), req)
+
+	w = call("did:plc:test123")
+	if err := json.NewDecoder(w.Body).Decode(&stats); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if stats.LastCompactedAt == "" {
+		t.Error("Expected the compaction time to be reported")
+	}
+
+	if w := call("did:plc:missing"); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "RepoNotFound") {
+		t.Errorf("Expected RepoNotFound, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetRepoDiffHandler(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	mockService.CreateRepository("did:plc:test123")
+	mockService.UpdateRepositoryStatus("did:plc:test123", repository.StatusTakendown, "spam")
+	first, _ := cid.Decode("bafyreie5737gdxlw5i64vzichcalba3z2v5n6icifvx5xytvske7mr3hpm")
+	second, _ := cid.Decode("bafyreibme22gw2h7y2h7tg2fhqotaqjucnbc24deqo72b6mkl2egezxhvy")
+	mockService.commits = []*repository.Commit{
+		{CID: first, Revision: "3k2a4b5c6d7e2"},
+		{CID: second, Revision: "3k2a4b5c6d7e3"},
+	}
+	mockService.changes = []repository.RecordChange{
+		{
+			Action:     repository.WriteActionUpdate,
+			URI:        "at://did:plc:test123/social.coves.post.record/a",
+			Collection: "social.coves.post.record",
+			RecordKey:  "a",
+			PrevCID:    first,
+			CID:        second,
+			PrevValue:  recordCBOR(`{"$type": "social.coves.post.record", "text": "before"}`),
+			Value:      recordCBOR(`{"$type": "social.coves.post.record", "text": "spam"}`),
+		},
+		{
+			Action:     repository.WriteActionDelete,
+			URI:        "at://did:plc:test123/social.coves.post.record/b",
+			Collection: "social.coves.post.record",
+			RecordKey:  "b",
+			PrevCID:    first,
+			PrevValue:  recordCBOR(`{"$type": "social.coves.post.record", "text": "gone"}`),
+		},
+	}
+	server := newAdminServer(t, mockService, &mockCompactionTarget{})
+
+	call := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/xrpc/social.coves.admin.getRepoDiff?"+query, nil)
+		req.SetBasicAuth("admin", "secret")
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		return w
+	}
+
+	// Taken down repositories can be diffed for evidence
+	w := call("did=did:plc:test123&from=3k2a4b5c6d7e2&to=3k2a4b5c6d7e3&values=true")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var diff RepoDiffOutput
+	if err := json.NewDecoder(w.Body).Decode(&diff); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if diff.From != "3k2a4b5c6d7e2" || diff.FromCommit != first.String() || diff.To != "3k2a4b5c6d7e3" || diff.ToCommit != second.String() {
+		t.Errorf("Unexpected revisions %+v", diff)
+	}
+	if len(diff.Changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %+v", diff.Changes)
+	}
+	update, del := diff.Changes[0], diff.Changes[1]
+	if update.Action != "update" || update.RecordKey != "a" || update.PrevCID != first.String() || update.CID != second.String() ||
+		!strings.Contains(string(update.PrevValue), `"before"`) || !strings.Contains(string(update.Value), `"spam"`) {
+		t.Errorf("Unexpected update %+v", update)
+	}
+	if del.Action != "delete" || del.CID != "" || del.Value != nil || !strings.Contains(string(del.PrevValue), `"gone"`) {
+		t.Errorf("Unexpected delete %+v", del)
+	}
+
+	// Values are left out unless asked for; to defaults to the head
+	w = call("did=did:plc:test123")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "value") || strings.Contains(w.Body.String(), "fromCommit") {
+		t.Errorf("Expected no values or from commit, got %s", w.Body.String())
+	}
+
+	if w := call("did=did:plc:test123&from=3k2a4b5c6d7e9"); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "RevisionNotFound") {
+		t.Errorf("Expected RevisionNotFound, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := call("did=did:plc:missing"); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "RepoNotFound") {
+		t.Errorf("Expected RepoNotFound, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"Coves/internal/api/xrpc"
+	"Coves/internal/core/blobs"
+
+	atdata "github.com/bluesky-social/indigo/atproto/data"
+	"github.com/ipfs/go-cid"
+)
+
+// BlobHandler handles HTTP requests for blobs
+type BlobHandler struct {
+	service *blobs.Service
+}
+
+// NewBlobHandler creates a new blob handler
+func NewBlobHandler(service *blobs.Service) *BlobHandler {
+	return &BlobHandler{service: service}
+}
+
+// UploadBlobResponse represents the response after uploading a blob
+type UploadBlobResponse struct {
+	Blob atdata.Blob `json:"blob"`
+}
+
+// MissingBlobOutput represents a blob in listMissingBlobs responses
+type MissingBlobOutput struct {
+	CID       string `json:"cid"`
+	RecordURI string `json:"recordUri"`
+}
+
+// ListMissingBlobsResponse represents the response when listing missing blobs
+type ListMissingBlobsResponse struct {
+	Cursor string              `json:"cursor,omitempty"`
+	Blobs  []MissingBlobOutput `json:"blobs"`
+}
+
+// ListBlobsResponse represents the response when listing a repository's blobs
+type ListBlobsResponse struct {
+	Cursor string   `json:"cursor,omitempty"`
+	CIDs   []string `json:"cids"`
+}
+
+// UploadBlob handles POST /xrpc/com.atproto.repo.uploadBlob. The body is
+// the blob and its Content-Type is recorded with it. Records reference the
+// returned blob to keep it.
+func (h *BlobHandler) UploadBlob(w http.ResponseWriter, r *http.Request) {
+	did := r.URL.Query().Get("did")
+	if did == "" {
+		writeError(w, http.StatusBadRequest, "missing did parameter")
+		return
+	}
+
+	blob, err := h.service.Upload(r.Context(), did, r.Header.Get("Content-Type"), r.Body)
+	if err != nil {
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, UploadBlobResponse{Blob: atdata.Blob{
+		Ref:      atdata.CIDLink(blob.CID),
+		MimeType: blob.MimeType,
+		Size:     blob.Size,
+	}})
+}
+
+// ListMissingBlobs handles GET /xrpc/com.atproto.repo.listMissingBlobs. It
+// reports the blobs a migrating account's imported records link to that it
+// hasn't uploaded yet.
+func (h *BlobHandler) ListMissingBlobs(w http.ResponseWriter, r *http.Request) {
+	did := r.URL.Query().Get("did")
+	if did == "" {
+		writeError(w, http.StatusBadRequest, "missing did parameter")
+		return
+	}
+
+	missing, nextCursor, err := h.service.ListMissing(did, listLimit(r), r.URL.Query().Get("cursor"))
+	if err != nil {
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+
+	resp := ListMissingBlobsResponse{
+		Cursor: nextCursor,
+		Blobs:  make([]MissingBlobOutput, len(missing)),
+	}
+	for i, m := range missing {
+		resp.Blobs[i] = MissingBlobOutput{CID: m.CID.String(), RecordURI: m.RecordURI}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ListBlobs handles GET /xrpc/com.atproto.sync.listBlobs. The since
+// parameter isn't supported, so every blob is listed.
+func (h *BlobHandler) ListBlobs(w http.ResponseWriter, r *http.Request) {
+	did := r.URL.Query().Get("did")
+	if did == "" {
+		writeError(w, http.StatusBadRequest, "missing did parameter")
+		return
+	}
+
+	cids, nextCursor, err := h.service.List(did, listLimit(r), r.URL.Query().Get("cursor"))
+	if err != nil {
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+
+	resp := ListBlobsResponse{
+		Cursor: nextCursor,
+		CIDs:   make([]string, len(cids)),
+	}
+	for i, c := range cids {
+		resp.CIDs[i] = c.String()
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetBlob handles GET /xrpc/com.atproto.sync.getBlob
+func (h *BlobHandler) GetBlob(w http.ResponseWriter, r *http.Request) {
+	did := r.URL.Query().Get("did")
+	cidStr := r.URL.Query().Get("cid")
+	if did == "" || cidStr == "" {
+		writeError(w, http.StatusBadRequest, "missing required parameters")
+		return
+	}
+
+	c, err := cid.Parse(cidStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid cid: %s", cidStr))
+		return
+	}
+
+	blob, rc, err := h.service.Get(r.Context(), did, c)
+	if err != nil {
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+	defer rc.Close()
+
+	// Blobs are user content, so browsers mustn't sniff or run them
+	w.Header().Set("Content-Type", blob.MimeType)
+	w.Header().Set("Content-Length", strconv.FormatInt(blob.Size, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; sandbox")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, rc)
+}
+
+// listLimit reads a list method's limit parameter: 500 by default, between
+// 1 and 1000
+func listLimit(r *http.Request) int {
+	limit := 500
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		fmt.Sscanf(limitStr, "%d", &limit)
+		if limit > 1000 {
+			limit = 1000
+		}
+		if limit < 1 {
+			limit = 1
+		}
+	}
+	return limit
+}
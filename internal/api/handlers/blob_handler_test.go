@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"Coves/internal/core/blobs"
+	"Coves/internal/core/repository"
+
+	"github.com/ipfs/go-cid"
+)
+
+// mockBlobRepository keeps blob metadata in memory
+type mockBlobRepository struct {
+	blobs map[string]*blobs.Blob // Keyed by DID and CID
+}
+
+func (m *mockBlobRepository) Create(blob *blobs.Blob) error {
+	key := blob.DID + " " + blob.CID.String()
+	if _, exists := m.blobs[key]; !exists {
+		m.blobs[key] = blob
+	}
+	return nil
+}
+
+func (m *mockBlobRepository) Get(did string, c cid.Cid) (*blobs.Blob, error) {
+	return m.blobs[did+" "+c.String()], nil
+}
+
+func (m *mockBlobRepository) List(did string, limit int, afterCID string) ([]*blobs.Blob, error) {
+	var out []*blobs.Blob
+	for _, blob := range m.blobs {
+		if blob.DID == did && blob.CID.String() > afterCID {
+			out = append(out, blob)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CID.String() < out[j].CID.String() })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *mockBlobRepository) Has(did string, cids []cid.Cid) (map[cid.Cid]bool, error) {
+	have := make(map[cid.Cid]bool)
+	for _, c := range cids {
+		if _, exists := m.blobs[did+" "+c.String()]; exists {
+			have[c] = true
+		}
+	}
+	return have, nil
+}
+
+func newBlobHandler(t *testing.T, repos *MockRepositoryService) *BlobHandler {
+	t.Helper()
+	store, err := blobs.NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+	return NewBlobHandler(blobs.NewService(&mockBlobRepository{blobs: map[string]*blobs.Blob{}}, store, repos))
+}
+
+func TestBlobHandler_UploadAndGet(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	mockService.CreateRepository("did:plc:test123")
+	handler := newBlobHandler(t, mockService)
+
+	req := httptest.NewRequest("POST", "/xrpc/com.atproto.repo.uploadBlob?did=did:plc:test123", strings.NewReader("image bytes"))
+	req.Header.Set("Content-Type", "image/png")
+	w := httptest.NewRecorder()
+	handler.UploadBlob(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Blob struct {
+			Type     string            `json:"$type"`
+			Ref      map[string]string `json:"ref"`
+			MimeType string            `json:"mimeType"`
+			Size     int64             `json:"size"`
+		} `json:"blob"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	ref := resp.Blob.Ref["$link"]
+	if resp.Blob.Type != "blob" || !strings.HasPrefix(ref, "bafkrei") || resp.Blob.MimeType != "image/png" || resp.Blob.Size != 11 {
+		t.Fatalf("Unexpected blob %+v", resp.Blob)
+	}
+
+	req = httptest.NewRequest("GET", "/xrpc/com.atproto.sync.getBlob?did=did:plc:test123&cid="+ref, nil)
+	w = httptest.NewRecorder()
+	handler.GetBlob(w, req)
+	if w.Code != http.StatusOK || w.Body.String() != "image bytes" {
+		t.Fatalf("Expected the blob, got %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Content-Type") != "image/png" || w.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Errorf("Unexpected headers %v", w.Header())
+	}
+
+	req = httptest.NewRequest("GET", "/xrpc/com.atproto.sync.listBlobs?did=did:plc:test123", nil)
+	w = httptest.NewRecorder()
+	handler.ListBlobs(w, req)
+	var list ListBlobsResponse
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(list.CIDs) != 1 || list.CIDs[0] != ref {
+		t.Errorf("Expected the uploaded blob to be listed, got %v", list.CIDs)
+	}
+
+	// Unknown blobs have a named error
+	req = httptest.NewRequest("GET", "/xrpc/com.atproto.sync.getBlob?did=did:plc:test123&cid=bafkreiawbvjptn2tpydsyxiyxbq5zwfdaxdzgaxl3dzgb7t2mmtqjgujuy", nil)
+	w = httptest.NewRecorder()
+	handler.GetBlob(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "BlobNotFound") {
+		t.Errorf("Expected BlobNotFound, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestBlobHandler_ListMissingBlobs(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	mockService.CreateInactiveRepository("did:plc:migrating")
+	handler := newBlobHandler(t, mockService)
+
+	missing, _ := cid.Decode("bafkreiawbvjptn2tpydsyxiyxbq5zwfdaxdzgaxl3dzgb7t2mmtqjgujuy")
+	mockService.blobRefs["did:plc:migrating"] = []repository.BlobRef{
+		{CID: missing, RecordURI: "at://did:plc:migrating/social.coves.test.image/1"},
+	}
+
+	// A deactivated account can upload, but its blobs aren't served yet
+	req := httptest.NewRequest("POST", "/xrpc/com.atproto.repo.uploadBlob?did=did:plc:migrating", strings.NewReader("image bytes"))
+	w := httptest.NewRecorder()
+	handler.UploadBlob(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	req = httptest.NewRequest("GET", "/xrpc/com.atproto.sync.listBlobs?did=did:plc:migrating", nil)
+	w = httptest.NewRecorder()
+	handler.ListBlobs(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "RepoDeactivated") {
+		t.Errorf("Expected RepoDeactivated, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/xrpc/com.atproto.repo.listMissingBlobs?did=did:plc:migrating", nil)
+	w = httptest.NewRecorder()
+	handler.ListMissingBlobs(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ListMissingBlobsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Blobs) != 1 || resp.Blobs[0].CID != missing.String() || resp.Blobs[0].RecordURI != "at://did:plc:migrating/social.coves.test.image/1" {
+		t.Errorf("Expected the missing blob, got %+v", resp.Blobs)
+	}
+
+	req = httptest.NewRequest("GET", "/xrpc/com.atproto.repo.listMissingBlobs", nil)
+	w = httptest.NewRecorder()
+	handler.ListMissingBlobs(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without a DID, got %d", w.Code)
+	}
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Coves/internal/core/events"
+	indigoevents "github.com/bluesky-social/indigo/events"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// firehoseWriteTimeout bounds how long a subscriber may take to accept a frame
+	firehoseWriteTimeout = 10 * time.Second
+
+	// firehosePingInterval is how often idle connections are pinged
+	firehosePingInterval = 30 * time.Second
+)
+
+// FirehoseHandler serves the repository event stream over WebSocket
+type FirehoseHandler struct {
+	service  events.EventService
+	upgrader websocket.Upgrader
+}
+
+// NewFirehoseHandler creates a new firehose handler
+func NewFirehoseHandler(service events.EventService) *FirehoseHandler {
+	return &FirehoseHandler{
+		service: service,
+		upgrader: websocket.Upgrader{
+			// The firehose is public and read-only, so any origin may subscribe
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// SubscribeRepos handles GET /xrpc/com.atproto.sync.subscribeRepos
+// Each event is sent as a binary message holding a DAG-CBOR header and body.
+func (h *FirehoseHandler) SubscribeRepos(w http.ResponseWriter, r *http.Request) {
+	var cursor *int64
+	if c := r.URL.Query().Get("cursor"); c != "" {
+		seq, err := strconv.ParseInt(c, 10, 64)
+		if err != nil || seq < 0 {
+			writeXRPCError(w, http.StatusBadRequest, "InvalidRequest", "cursor must be a non-negative integer")
+			return
+		}
+		cursor = &seq
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already replied with an error
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// Subscribers don't send messages, but reading handles control frames and
+	// notices when the client goes away
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(firehosePingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(firehoseWriteTimeout)); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	err = h.service.Subscribe(ctx, cursor, func(evt *indigoevents.XRPCStreamEvent) error {
+		return writeFrame(conn, evt)
+	})
+	switch {
+	case errors.Is(err, events.ErrFutureCursor):
+		writeErrorFrame(conn, "FutureCursor", err.Error())
+	case err != nil && ctx.Err() == nil:
+		writeErrorFrame(conn, "InternalError", "failed to read events")
+	}
+}
+
+// writeFrame sends evt as one binary WebSocket message
+func writeFrame(conn *websocket.Conn, evt *indigoevents.XRPCStreamEvent) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(firehoseWriteTimeout)); err != nil {
+		return err
+	}
+	wc, err := conn.NextWriter(websocket.BinaryMessage)
+	if err != nil {
+		return err
+	}
+	if err := evt.Serialize(wc); err != nil {
+		wc.Close()
+		return err
+	}
+	return wc.Close()
+}
+
+// writeErrorFrame sends an error frame and closes the stream
+func writeErrorFrame(conn *websocket.Conn, name string, message string) {
+	frame := &indigoevents.XRPCStreamEvent{Error: &indigoevents.ErrorFrame{Error: name, Message: message}}
+	if err := writeFrame(conn, frame); err != nil {
+		return
+	}
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(firehoseWriteTimeout))
+}
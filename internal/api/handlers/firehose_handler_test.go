@@ -0,0 +1,122 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"Coves/internal/api/handlers"
+	"Coves/internal/core/events"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	indigoevents "github.com/bluesky-social/indigo/events"
+	"github.com/gorilla/websocket"
+)
+
+// mockEventService replays a fixed log of identity events
+type mockEventService struct {
+	dids []string
+}
+
+func (m *mockEventService) Publish(ctx context.Context, evt *indigoevents.XRPCStreamEvent) error {
+	return nil
+}
+
+func (m *mockEventService) Appended(events []*events.Event) {}
+
+func (m *mockEventService) Subscribe(ctx context.Context, cursor *int64, cb func(*indigoevents.XRPCStreamEvent) error) error {
+	after := int64(0)
+	if cursor != nil {
+		after = *cursor
+	}
+	if after > int64(len(m.dids)) {
+		return fmt.Errorf("%w: %d", events.ErrFutureCursor, after)
+	}
+	for i := after; i < int64(len(m.dids)); i++ {
+		evt := &indigoevents.XRPCStreamEvent{RepoIdentity: &comatproto.SyncSubscribeRepos_Identity{
+			Did:  m.dids[i],
+			Seq:  i + 1,
+			Time: "2024-01-01T00:00:00.000Z",
+		}}
+		if err := cb(evt); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (m *mockEventService) Prune() (int64, error) {
+	return 0, nil
+}
+
+func dialFirehose(t *testing.T, server *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/xrpc/com.atproto.sync.subscribeRepos" + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readFrame(t *testing.T, conn *websocket.Conn) *indigoevents.XRPCStreamEvent {
+	t.Helper()
+	mt, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("Failed to read frame: %v", err)
+	}
+	if mt != websocket.BinaryMessage {
+		t.Fatalf("Expected binary message, got type %d", mt)
+	}
+	var evt indigoevents.XRPCStreamEvent
+	if err := evt.Deserialize(bytes.NewReader(data)); err != nil {
+		t.Fatalf("Failed to decode frame: %v", err)
+	}
+	return &evt
+}
+
+func TestSubscribeReposHandler(t *testing.T) {
+	handler := handlers.NewFirehoseHandler(&mockEventService{dids: []string{"did:plc:a", "did:plc:b", "did:plc:c"}})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/xrpc/com.atproto.sync.subscribeRepos", handler.SubscribeRepos)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Run("resume from cursor", func(t *testing.T) {
+		conn := dialFirehose(t, server, "?cursor=1")
+		for _, want := range []string{"did:plc:b", "did:plc:c"} {
+			evt := readFrame(t, conn)
+			if evt.RepoIdentity == nil || evt.RepoIdentity.Did != want {
+				t.Errorf("Expected identity event for %s, got %+v", want, evt)
+			}
+		}
+	})
+
+	t.Run("future cursor", func(t *testing.T) {
+		conn := dialFirehose(t, server, "?cursor=10")
+		evt := readFrame(t, conn)
+		if evt.Error == nil || evt.Error.Error != "FutureCursor" {
+			t.Errorf("Expected FutureCursor error frame, got %+v", evt)
+		}
+		if _, _, err := conn.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			t.Errorf("Expected the stream to close, got %v", err)
+		}
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/xrpc/com.atproto.sync.subscribeRepos?cursor=abc")
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", resp.StatusCode)
+		}
+	})
+}
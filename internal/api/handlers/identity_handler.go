@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"Coves/internal/api/xrpc"
+	"Coves/internal/atproto/plc"
+	"Coves/internal/core/migration"
+)
+
+// IdentityHandler handles the com.atproto.identity methods that move an
+// account's DID between servers
+type IdentityHandler struct {
+	service *migration.Service
+}
+
+// NewIdentityHandler creates a new identity handler
+func NewIdentityHandler(service *migration.Service) *IdentityHandler {
+	return &IdentityHandler{service: service}
+}
+
+// RecommendedCredentialsResponse represents the DID document entries an
+// account needs to be served by this server
+type RecommendedCredentialsResponse struct {
+	RotationKeys        []string               `json:"rotationKeys"`
+	VerificationMethods map[string]string      `json:"verificationMethods"`
+	Services            map[string]plc.Service `json:"services"`
+}
+
+// SignPLCOperationRequest represents a request to sign a PLC operation.
+// Omitted fields keep what the DID's latest operation has.
+type SignPLCOperationRequest struct {
+	DID                 string                 `json:"did"`
+	RotationKeys        []string               `json:"rotationKeys,omitempty"`
+	AlsoKnownAs         []string               `json:"alsoKnownAs,omitempty"`
+	VerificationMethods map[string]string      `json:"verificationMethods,omitempty"`
+	Services            map[string]plc.Service `json:"services,omitempty"`
+}
+
+// PLCOperationResponse represents a signed PLC operation
+type PLCOperationResponse struct {
+	Operation *plc.Operation `json:"operation"`
+}
+
+// SubmitPLCOperationRequest represents a request to submit a signed PLC operation
+type SubmitPLCOperationRequest struct {
+	DID       string         `json:"did"`
+	Operation *plc.Operation `json:"operation"`
+}
+
+// GetRecommendedDidCredentials handles GET
+// /xrpc/com.atproto.identity.getRecommendedDidCredentials. An account
+// migrating in asks its old server to sign an operation with these.
+func (h *IdentityHandler) GetRecommendedDidCredentials(w http.ResponseWriter, r *http.Request) {
+	did := r.URL.Query().Get("did")
+	if did == "" {
+		writeError(w, http.StatusBadRequest, "missing did parameter")
+		return
+	}
+
+	creds, err := h.service.RecommendedCredentials(did)
+	if err != nil {
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, RecommendedCredentialsResponse{
+		RotationKeys:        creds.RotationKeys,
+		VerificationMethods: creds.VerificationMethods,
+		Services:            creds.Services,
+	})
+}
+
+// SignPlcOperation handles POST /xrpc/com.atproto.identity.signPlcOperation
+// for an account leaving this server. It signs with the server's rotation
+// key, so it is registered behind admin auth.
+func (h *IdentityHandler) SignPlcOperation(w http.ResponseWriter, r *http.Request) {
+	var req SignPLCOperationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	if req.DID == "" {
+		writeError(w, http.StatusBadRequest, "missing did")
+		return
+	}
+
+	op, err := h.service.SignPLCOperation(r.Context(), req.DID, migration.Changes{
+		RotationKeys:        req.RotationKeys,
+		AlsoKnownAs:         req.AlsoKnownAs,
+		VerificationMethods: req.VerificationMethods,
+		Services:            req.Services,
+	})
+	if err != nil {
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PLCOperationResponse{Operation: op})
+}
+
+// SubmitPlcOperation handles POST /xrpc/com.atproto.identity.submitPlcOperation
+// for an account migrating in, once its old server has signed the operation
+func (h *IdentityHandler) SubmitPlcOperation(w http.ResponseWriter, r *http.Request) {
+	var req SubmitPLCOperationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	if req.DID == "" || req.Operation == nil {
+		writeError(w, http.StatusBadRequest, "missing did or operation")
+		return
+	}
+
+	if err := h.service.SubmitPLCOperation(r.Context(), req.DID, req.Operation); err != nil {
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("{}"))
+}
@@ -1,20 +1,22 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"strings"
 
+	"Coves/internal/api/xrpc"
+	atrepo "Coves/internal/atproto/repo"
+	"Coves/internal/core/auth"
 	"Coves/internal/core/repository"
 	"github.com/ipfs/go-cid"
-	cbornode "github.com/ipfs/go-ipld-cbor"
 )
 
 // RepositoryHandler handles HTTP requests for repository operations
 type RepositoryHandler struct {
 	service repository.RepositoryService
+	tokens  auth.TokenService
 }
 
 // NewRepositoryHandler creates a new repository handler
@@ -24,15 +26,22 @@ func NewRepositoryHandler(service repository.RepositoryService) *RepositoryHandl
 	}
 }
 
+// SetTokenService sets the service that issues an access token to whoever
+// creates a repository. Without one, createRepo doesn't return a token.
+func (h *RepositoryHandler) SetTokenService(tokens auth.TokenService) {
+	h.tokens = tokens
+}
+
 // AT Protocol XRPC request/response types
 
 // CreateRecordRequest represents a request to create a record
 type CreateRecordRequest struct {
-	Repo       string          `json:"repo"`       // DID of the repository
-	Collection string          `json:"collection"` // NSID of the collection
-	RKey       string          `json:"rkey,omitempty"` // Optional record key
-	Validate   bool            `json:"validate"`   // Whether to validate against lexicon
-	Record     json.RawMessage `json:"record"`     // The record data
+	Repo       string          `json:"repo"`                 // DID of the repository
+	Collection string          `json:"collection"`           // NSID of the collection
+	RKey       string          `json:"rkey,omitempty"`       // Optional record key
+	Validate   bool            `json:"validate"`             // Whether to validate against lexicon
+	Record     json.RawMessage `json:"record"`               // The record data
+	SwapCommit string          `json:"swapCommit,omitempty"` // Optional CID the repository head must match
 }
 
 // CreateRecordResponse represents the response after creating a record
@@ -57,11 +66,13 @@ type GetRecordResponse struct {
 
 // PutRecordRequest represents a request to update a record
 type PutRecordRequest struct {
-	Repo       string          `json:"repo"`       // DID of the repository
-	Collection string          `json:"collection"` // NSID of the collection
-	RKey       string          `json:"rkey"`       // Record key
-	Validate   bool            `json:"validate"`   // Whether to validate against lexicon
-	Record     json.RawMessage `json:"record"`     // The record data
+	Repo       string          `json:"repo"`                 // DID of the repository
+	Collection string          `json:"collection"`           // NSID of the collection
+	RKey       string          `json:"rkey"`                 // Record key
+	Validate   bool            `json:"validate"`             // Whether to validate against lexicon
+	Record     json.RawMessage `json:"record"`               // The record data
+	SwapRecord string          `json:"swapRecord,omitempty"` // Optional CID the current record must have
+	SwapCommit string          `json:"swapCommit,omitempty"` // Optional CID the repository head must match
 }
 
 // PutRecordResponse represents the response after updating a record
@@ -72,9 +83,11 @@ type PutRecordResponse struct {
 
 // DeleteRecordRequest represents a request to delete a record
 type DeleteRecordRequest struct {
-	Repo       string `json:"repo"`       // DID of the repository
-	Collection string `json:"collection"` // NSID of the collection
-	RKey       string `json:"rkey"`       // Record key
+	Repo       string `json:"repo"`                 // DID of the repository
+	Collection string `json:"collection"`           // NSID of the collection
+	RKey       string `json:"rkey"`                 // Record key
+	SwapRecord string `json:"swapRecord,omitempty"` // Optional CID the current record must have
+	SwapCommit string `json:"swapCommit,omitempty"` // Optional CID the repository head must match
 }
 
 // ListRecordsRequest represents a request to list records
@@ -98,6 +111,43 @@ type RecordOutput struct {
 	Value json.RawMessage `json:"value"`
 }
 
+// ApplyWritesOp represents one create, update or delete in an applyWrites request
+type ApplyWritesOp struct {
+	Type       string          `json:"$type"`           // com.atproto.repo.applyWrites#create, #update or #delete
+	Collection string          `json:"collection"`      // NSID of the collection
+	RKey       string          `json:"rkey,omitempty"`  // Record key (optional for creates)
+	Value      json.RawMessage `json:"value,omitempty"` // The record data (creates and updates)
+}
+
+// ApplyWritesRequest represents a request to apply a batch of writes in one commit
+type ApplyWritesRequest struct {
+	Repo       string          `json:"repo"`                 // DID of the repository
+	Validate   bool            `json:"validate"`             // Whether to validate against lexicon
+	Writes     []ApplyWritesOp `json:"writes"`               // Operations applied in order
+	SwapCommit string          `json:"swapCommit,omitempty"` // Optional CID the repository head must match
+}
+
+// CommitMeta identifies the commit produced by a write
+type CommitMeta struct {
+	CID string `json:"cid"`
+	Rev string `json:"rev"`
+}
+
+// ApplyWritesResult represents the outcome of one operation in an applyWrites response
+type ApplyWritesResult struct {
+	Type string `json:"$type"`
+	URI  string `json:"uri,omitempty"`
+	CID  string `json:"cid,omitempty"`
+}
+
+// ApplyWritesResponse represents the response after applying a batch of writes
+type ApplyWritesResponse struct {
+	Commit  CommitMeta          `json:"commit"`
+	Results []ApplyWritesResult `json:"results"`
+}
+
+const applyWritesNSID = "com.atproto.repo.applyWrites"
+
 // Handler methods
 
 // CreateRecord handles POST /xrpc/com.atproto.repo.createRecord
@@ -114,10 +164,16 @@ func (h *RepositoryHandler) CreateRecord(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Create a generic record structure for CBOR encoding
-	// In a real implementation, you would unmarshal to the specific lexicon type
-	recordData := &GenericRecord{
-		Data: req.Record,
+	recordData, err := atrepo.ParseRecordJSON(req.Record)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	swapCommit, err := parseSwapCID(req.SwapCommit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid swapCommit: %v", err))
+		return
 	}
 
 	input := repository.CreateRecordInput{
@@ -126,11 +182,12 @@ func (h *RepositoryHandler) CreateRecord(w http.ResponseWriter, r *http.Request)
 		RecordKey:  req.RKey,
 		Record:     recordData,
 		Validate:   req.Validate,
+		SwapCommit: swapCommit,
 	}
 
 	record, err := h.service.CreateRecord(input)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create record: %v", err))
+		xrpc.WriteErrorFor(w, err)
 		return
 	}
 
@@ -162,18 +219,21 @@ func (h *RepositoryHandler) GetRecord(w http.ResponseWriter, r *http.Request) {
 
 	record, err := h.service.GetRecord(input)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			writeError(w, http.StatusNotFound, "record not found")
-			return
-		}
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get record: %v", err))
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+
+	// Records are stored as DAG-CBOR; the CID refers to those bytes
+	value, err := atrepo.RecordCBORToJSON(record.Value)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to decode record: %v", err))
 		return
 	}
 
 	resp := GetRecordResponse{
 		URI:   record.URI,
 		CID:   record.CID.String(),
-		Value: json.RawMessage(record.Value),
+		Value: value,
 	}
 
 	writeJSON(w, http.StatusOK, resp)
@@ -193,9 +253,21 @@ func (h *RepositoryHandler) PutRecord(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create a generic record structure for CBOR encoding
-	recordData := &GenericRecord{
-		Data: req.Record,
+	recordData, err := atrepo.ParseRecordJSON(req.Record)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	swapRecord, err := parseSwapCID(req.SwapRecord)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid swapRecord: %v", err))
+		return
+	}
+	swapCommit, err := parseSwapCID(req.SwapCommit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid swapCommit: %v", err))
+		return
 	}
 
 	input := repository.UpdateRecordInput{
@@ -204,15 +276,13 @@ func (h *RepositoryHandler) PutRecord(w http.ResponseWriter, r *http.Request) {
 		RecordKey:  req.RKey,
 		Record:     recordData,
 		Validate:   req.Validate,
+		SwapRecord: swapRecord,
+		SwapCommit: swapCommit,
 	}
 
 	record, err := h.service.UpdateRecord(input)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			writeError(w, http.StatusNotFound, "record not found")
-			return
-		}
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update record: %v", err))
+		xrpc.WriteErrorFor(w, err)
 		return
 	}
 
@@ -238,19 +308,27 @@ func (h *RepositoryHandler) DeleteRecord(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	swapRecord, err := parseSwapCID(req.SwapRecord)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid swapRecord: %v", err))
+		return
+	}
+	swapCommit, err := parseSwapCID(req.SwapCommit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid swapCommit: %v", err))
+		return
+	}
+
 	input := repository.DeleteRecordInput{
 		DID:        req.Repo,
 		Collection: req.Collection,
 		RecordKey:  req.RKey,
+		SwapRecord: swapRecord,
+		SwapCommit: swapCommit,
 	}
 
-	err := h.service.DeleteRecord(input)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			writeError(w, http.StatusNotFound, "record not found")
-			return
-		}
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to delete record: %v", err))
+	if err := h.service.DeleteRecord(input); err != nil {
+		xrpc.WriteErrorFor(w, err)
 		return
 	}
 
@@ -258,6 +336,90 @@ func (h *RepositoryHandler) DeleteRecord(w http.ResponseWriter, r *http.Request)
 	w.Write([]byte("{}"))
 }
 
+// ApplyWrites handles POST /xrpc/com.atproto.repo.applyWrites
+func (h *RepositoryHandler) ApplyWrites(w http.ResponseWriter, r *http.Request) {
+	var req ApplyWritesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+
+	// Validate required fields
+	if req.Repo == "" || len(req.Writes) == 0 {
+		writeError(w, http.StatusBadRequest, "missing required fields")
+		return
+	}
+
+	writes := make([]repository.WriteOp, len(req.Writes))
+	for i, op := range req.Writes {
+		writes[i] = repository.WriteOp{
+			Collection: op.Collection,
+			RecordKey:  op.RKey,
+		}
+
+		switch op.Type {
+		case applyWritesNSID + "#create":
+			writes[i].Action = repository.WriteActionCreate
+		case applyWritesNSID + "#update":
+			writes[i].Action = repository.WriteActionUpdate
+		case applyWritesNSID + "#delete":
+			writes[i].Action = repository.WriteActionDelete
+		default:
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("write %d: unknown $type %q", i, op.Type))
+			return
+		}
+
+		if writes[i].Action != repository.WriteActionDelete {
+			if len(op.Value) == 0 {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("write %d: missing value", i))
+				return
+			}
+			record, err := atrepo.ParseRecordJSON(op.Value)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("write %d: %v", i, err))
+				return
+			}
+			writes[i].Record = record
+		}
+	}
+
+	swapCommit, err := parseSwapCID(req.SwapCommit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid swapCommit: %v", err))
+		return
+	}
+
+	result, err := h.service.ApplyWrites(repository.ApplyWritesInput{
+		DID:        req.Repo,
+		Writes:     writes,
+		Validate:   req.Validate,
+		SwapCommit: swapCommit,
+	})
+	if err != nil {
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+
+	resp := ApplyWritesResponse{
+		Commit: CommitMeta{
+			CID: result.CommitCID.String(),
+			Rev: result.Revision,
+		},
+		Results: make([]ApplyWritesResult, len(result.Results)),
+	}
+	for i, res := range result.Results {
+		resp.Results[i] = ApplyWritesResult{
+			Type: applyWritesNSID + "#" + res.Action + "Result",
+			URI:  res.URI,
+		}
+		if res.CID.Defined() {
+			resp.Results[i].CID = res.CID.String()
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
 // ListRecords handles GET /xrpc/com.atproto.repo.listRecords
 func (h *RepositoryHandler) ListRecords(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
@@ -281,17 +443,22 @@ func (h *RepositoryHandler) ListRecords(w http.ResponseWriter, r *http.Request)
 
 	records, nextCursor, err := h.service.ListRecords(repo, collection, limit, cursor)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to list records: %v", err))
+		xrpc.WriteErrorFor(w, err)
 		return
 	}
 
 	// Convert to output format
 	recordOutputs := make([]RecordOutput, len(records))
 	for i, record := range records {
+		value, err := atrepo.RecordCBORToJSON(record.Value)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to decode record %s: %v", record.URI, err))
+			return
+		}
 		recordOutputs[i] = RecordOutput{
 			URI:   record.URI,
 			CID:   record.CID.String(),
-			Value: json.RawMessage(record.Value),
+			Value: value,
 		}
 	}
 
@@ -312,30 +479,265 @@ func (h *RepositoryHandler) GetRepo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Export repository as CAR file
-	carData, err := h.service.ExportRepository(did)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			writeError(w, http.StatusNotFound, "repository not found")
+	// Stream the repository as a CAR file. Headers are sent with the first
+	// block, so errors before that point can still be reported as JSON.
+	cw := &carResponseWriter{w: w}
+	since := r.URL.Query().Get("since")
+	if err := h.service.ExportRepository(r.Context(), did, since, cw); err != nil {
+		if cw.started {
+			// Part of the CAR has already been sent; abort the stream
+			panic(http.ErrAbortHandler)
+		}
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+
+	// Empty repositories produce no CAR data
+	if !cw.started {
+		cw.writeHeader()
+	}
+}
+
+// ImportRepo handles POST /xrpc/com.atproto.repo.importRepo. The body is
+// the account's repository CAR as its previous server exported it; the
+// commit must be signed by the key in the DID document. Imports only fill a
+// deactivated repository created for a migration.
+func (h *RepositoryHandler) ImportRepo(w http.ResponseWriter, r *http.Request) {
+	did := r.URL.Query().Get("did")
+	if did == "" {
+		writeError(w, http.StatusBadRequest, "missing did parameter")
+		return
+	}
+
+	if _, err := h.service.ImportRepository(r.Context(), did, r.Body); err != nil {
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("{}"))
+}
+
+// GetBlocks handles GET /xrpc/com.atproto.sync.getBlocks
+func (h *RepositoryHandler) GetBlocks(w http.ResponseWriter, r *http.Request) {
+	did := r.URL.Query().Get("did")
+	cidStrs := r.URL.Query()["cids"]
+
+	if did == "" || len(cidStrs) == 0 {
+		writeError(w, http.StatusBadRequest, "missing required parameters")
+		return
+	}
+
+	cids := make([]cid.Cid, len(cidStrs))
+	for i, cidStr := range cidStrs {
+		c, err := cid.Parse(cidStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid cid: %s", cidStr))
 			return
 		}
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to export repository: %v", err))
+		cids[i] = c
+	}
+
+	cw := &carResponseWriter{w: w}
+	if err := h.service.GetBlocks(r.Context(), did, cids, cw); err != nil {
+		if cw.started {
+			panic(http.ErrAbortHandler)
+		}
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+}
+
+// SyncGetRecord handles GET /xrpc/com.atproto.sync.getRecord
+func (h *RepositoryHandler) SyncGetRecord(w http.ResponseWriter, r *http.Request) {
+	did := r.URL.Query().Get("did")
+	collection := r.URL.Query().Get("collection")
+	rkey := r.URL.Query().Get("rkey")
+
+	if did == "" || collection == "" || rkey == "" {
+		writeError(w, http.StatusBadRequest, "missing required parameters")
+		return
+	}
+
+	cw := &carResponseWriter{w: w}
+	if err := h.service.GetRecordProof(r.Context(), did, collection, rkey, cw); err != nil {
+		if cw.started {
+			panic(http.ErrAbortHandler)
+		}
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+}
+
+// RepoOutput represents a repository in sync.listRepos responses
+type RepoOutput struct {
+	DID    string `json:"did"`
+	Head   string `json:"head"`
+	Rev    string `json:"rev"`
+	Active bool   `json:"active"`
+	Status string `json:"status,omitempty"` // Set when the account isn't active
+}
+
+// ListReposResponse represents the response when listing repositories
+type ListReposResponse struct {
+	Cursor string       `json:"cursor,omitempty"`
+	Repos  []RepoOutput `json:"repos"`
+}
+
+// ListRepos handles GET /xrpc/com.atproto.sync.listRepos
+func (h *RepositoryHandler) ListRepos(w http.ResponseWriter, r *http.Request) {
+	limit := 500 // Default limit
+	cursor := r.URL.Query().Get("cursor")
+
+	// Parse limit if provided
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		fmt.Sscanf(limitStr, "%d", &limit)
+		if limit > 1000 {
+			limit = 1000 // Max limit
+		}
+		if limit < 1 {
+			limit = 1
+		}
+	}
+
+	repos, nextCursor, err := h.service.ListRepositories(limit, cursor)
+	if err != nil {
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+
+	// A repository created for a migration has no head until its import,
+	// so it isn't listed yet
+	repoOutputs := make([]RepoOutput, 0, len(repos))
+	for _, repo := range repos {
+		if !repo.HeadCID.Defined() {
+			continue
+		}
+		repoOutputs = append(repoOutputs, RepoOutput{
+			DID:    repo.DID,
+			Head:   repo.HeadCID.String(),
+			Rev:    repo.Revision,
+			Active: repo.Active(),
+			Status: inactiveStatus(repo),
+		})
+	}
+
+	resp := ListReposResponse{
+		Cursor: nextCursor,
+		Repos:  repoOutputs,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetRepoStatus handles GET /xrpc/com.atproto.sync.getRepoStatus
+func (h *RepositoryHandler) GetRepoStatus(w http.ResponseWriter, r *http.Request) {
+	did := r.URL.Query().Get("did")
+	if did == "" {
+		writeError(w, http.StatusBadRequest, "missing did parameter")
+		return
+	}
+
+	repo, err := h.service.GetRepository(did)
+	if err != nil {
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+
+	resp := struct {
+		DID    string `json:"did"`
+		Active bool   `json:"active"`
+		Status string `json:"status,omitempty"`
+		Rev    string `json:"rev,omitempty"`
+	}{
+		DID:    repo.DID,
+		Active: repo.Active(),
+		Status: inactiveStatus(repo),
+	}
+	if repo.Active() {
+		resp.Rev = repo.Revision
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// inactiveStatus returns the status sync endpoints report for a repository,
+// which is empty for active ones
+func inactiveStatus(repo *repository.Repository) string {
+	if repo.Active() {
+		return ""
+	}
+	return repo.Status
+}
+
+// AccountRequest represents a request to deactivate or activate an account
+type AccountRequest struct {
+	DID string `json:"did"`
+}
+
+// DeactivateAccount handles POST /xrpc/com.atproto.server.deactivateAccount.
+// The repository is kept but not served until the owner activates it again.
+func (h *RepositoryHandler) DeactivateAccount(w http.ResponseWriter, r *http.Request) {
+	h.changeAccountStatus(w, r, h.service.DeactivateRepository)
+}
+
+// ActivateAccount handles POST /xrpc/com.atproto.server.activateAccount
+func (h *RepositoryHandler) ActivateAccount(w http.ResponseWriter, r *http.Request) {
+	h.changeAccountStatus(w, r, h.service.ActivateRepository)
+}
+
+// changeAccountStatus decodes an AccountRequest and applies change to its DID
+func (h *RepositoryHandler) changeAccountStatus(w http.ResponseWriter, r *http.Request, change func(did string) (*repository.Repository, error)) {
+	var req AccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	if req.DID == "" {
+		writeError(w, http.StatusBadRequest, "missing did")
+		return
+	}
+
+	if _, err := change(req.DID); err != nil {
+		xrpc.WriteErrorFor(w, err)
 		return
 	}
 
-	// Set appropriate headers for CAR file
-	w.Header().Set("Content-Type", "application/vnd.ipld.car")
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(carData)))
 	w.WriteHeader(http.StatusOK)
-	w.Write(carData)
+	w.Write([]byte("{}"))
+}
+
+// carResponseWriter sends CAR headers lazily on the first write. Without a
+// Content-Length the response is sent chunked.
+type carResponseWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (cw *carResponseWriter) writeHeader() {
+	cw.w.Header().Set("Content-Type", "application/vnd.ipld.car")
+	cw.w.WriteHeader(http.StatusOK)
+	cw.started = true
+}
+
+func (cw *carResponseWriter) Write(p []byte) (int, error) {
+	if !cw.started {
+		cw.writeHeader()
+	}
+	return cw.w.Write(p)
 }
 
 // Additional repository management endpoints
 
-// CreateRepository handles POST /xrpc/com.atproto.repo.createRepo
+// CreateRepository handles POST /xrpc/com.atproto.repo.createRepo. An
+// account migrating in sets deactivated, which creates an empty repository
+// to import into; it is activated through com.atproto.server.activateAccount.
+// The response carries the account's access token, which the owner sends
+// to change the repository.
 func (h *RepositoryHandler) CreateRepository(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		DID string `json:"did"`
+		DID         string `json:"did"`
+		Deactivated bool   `json:"deactivated"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
@@ -347,22 +749,34 @@ func (h *RepositoryHandler) CreateRepository(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	repo, err := h.service.CreateRepository(req.DID)
+	create := h.service.CreateRepository
+	if req.Deactivated {
+		create = h.service.CreateInactiveRepository
+	}
+	repo, err := create(req.DID)
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			writeError(w, http.StatusConflict, "repository already exists")
-			return
-		}
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create repository: %v", err))
+		xrpc.WriteErrorFor(w, err)
 		return
 	}
 
 	resp := struct {
-		DID     string `json:"did"`
-		HeadCID string `json:"head"`
+		DID         string `json:"did"`
+		HeadCID     string `json:"head,omitempty"`
+		Active      bool   `json:"active"`
+		AccessToken string `json:"accessToken,omitempty"`
 	}{
-		DID:     repo.DID,
-		HeadCID: repo.HeadCID.String(),
+		DID:    repo.DID,
+		Active: repo.Active(),
+	}
+	if repo.HeadCID.Defined() {
+		resp.HeadCID = repo.HeadCID.String()
+	}
+	if h.tokens != nil {
+		resp.AccessToken, err = h.tokens.IssueToken(repo.DID)
+		if err != nil {
+			xrpc.WriteErrorFor(w, err)
+			return
+		}
 	}
 
 	writeJSON(w, http.StatusOK, resp)
@@ -388,11 +802,7 @@ func (h *RepositoryHandler) GetCommit(w http.ResponseWriter, r *http.Request) {
 
 	commit, err := h.service.GetCommit(did, commitCID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			writeError(w, http.StatusNotFound, "commit not found")
-			return
-		}
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get commit: %v", err))
+		xrpc.WriteErrorFor(w, err)
 		return
 	}
 
@@ -423,6 +833,78 @@ func (h *RepositoryHandler) GetCommit(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// GetLatestCommit handles GET /xrpc/com.atproto.sync.getLatestCommit
+func (h *RepositoryHandler) GetLatestCommit(w http.ResponseWriter, r *http.Request) {
+	did := r.URL.Query().Get("did")
+	if did == "" {
+		writeError(w, http.StatusBadRequest, "missing did parameter")
+		return
+	}
+
+	commit, err := h.service.GetLatestCommit(did)
+	if err != nil {
+		xrpc.WriteErrorFor(w, err)
+		return
+	}
+
+	resp := struct {
+		CID string `json:"cid"`
+		Rev string `json:"rev"`
+	}{
+		CID: commit.CID.String(),
+		Rev: commit.Revision,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CommitOutput represents a commit in history responses
+type CommitOutput struct {
+	CID          string  `json:"cid"`
+	Prev         *string `json:"prev,omitempty"`
+	Data         string  `json:"data"`
+	Rev          string  `json:"rev"`
+	Sig          string  `json:"sig"`
+	SigningKeyID string  `json:"signingKey"`
+	CreatedAt    string  `json:"createdAt"`
+}
+
+// ListCommitsResponse represents the response when listing commit history
+type ListCommitsResponse struct {
+	Cursor  string         `json:"cursor,omitempty"`
+	Commits []CommitOutput `json:"commits"`
+}
+
+// ListCommits serves social.coves.repo.listCommits. The dispatcher has
+// already checked did and applied the lexicon's limit bounds and default.
+func (h *RepositoryHandler) ListCommits(ctx context.Context, req *xrpc.Request) (interface{}, error) {
+	commits, nextCursor, err := h.service.ListCommits(req.Params.String("did"), int(req.Params.Int("limit")), req.Params.String("cursor"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	commitOutputs := make([]CommitOutput, len(commits))
+	for i, commit := range commits {
+		commitOutputs[i] = CommitOutput{
+			CID:          commit.CID.String(),
+			Data:         commit.DataCID.String(),
+			Rev:          commit.Revision,
+			Sig:          fmt.Sprintf("%x", commit.Signature),
+			SigningKeyID: commit.SigningKeyID,
+			CreatedAt:    commit.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+		if commit.PrevCID != nil {
+			prev := commit.PrevCID.String()
+			commitOutputs[i].Prev = &prev
+		}
+	}
+
+	return ListCommitsResponse{
+		Cursor:  nextCursor,
+		Commits: commitOutputs,
+	}, nil
+}
+
 // Helper functions
 
 func writeJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -431,39 +913,30 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	json.NewEncoder(w).Encode(data)
 }
 
+// writeError writes an unnamed XRPC error: InvalidRequest for a bad
+// request, InternalServerError otherwise. Service errors go through
+// xrpc.WriteErrorFor instead, which knows their names.
 func writeError(w http.ResponseWriter, status int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error":   http.StatusText(status),
-		"message": message,
-	})
+	name := "InvalidRequest"
+	if status >= http.StatusInternalServerError {
+		name = "InternalServerError"
+	}
+	writeXRPCError(w, status, name, message)
 }
 
-// GenericRecord is a temporary structure for CBOR encoding
-// In a real implementation, you would have specific types for each lexicon
-type GenericRecord struct {
-	Data json.RawMessage
+// writeXRPCError writes an error body with a named XRPC error such as InvalidSwap
+func writeXRPCError(w http.ResponseWriter, status int, name string, message string) {
+	xrpc.WriteError(w, status, name, message)
 }
 
-// MarshalCBOR implements the CBORMarshaler interface
-func (g *GenericRecord) MarshalCBOR(w io.Writer) error {
-	// Parse JSON data into a generic map for proper CBOR encoding
-	var data map[string]interface{}
-	if err := json.Unmarshal(g.Data, &data); err != nil {
-		return fmt.Errorf("failed to unmarshal JSON data: %w", err)
+// parseSwapCID parses an optional swapRecord or swapCommit CID
+func parseSwapCID(s string) (*cid.Cid, error) {
+	if s == "" {
+		return nil, nil
 	}
-	
-	// Use IPFS CBOR encoding to properly encode the data
-	cborData, err := cbornode.DumpObject(data)
+	c, err := cid.Parse(s)
 	if err != nil {
-		return fmt.Errorf("failed to marshal as CBOR: %w", err)
+		return nil, err
 	}
-	
-	_, err = w.Write(cborData)
-	if err != nil {
-		return fmt.Errorf("failed to write CBOR data: %w", err)
-	}
-	
-	return nil
-}
\ No newline at end of file
+	return &c, nil
+}
@@ -2,12 +2,21 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"Coves/internal/api/xrpc"
+	atrepo "Coves/internal/atproto/repo"
+	coreerrors "Coves/internal/core/errors"
 	"Coves/internal/core/repository"
+	"Coves/internal/validation"
 	"github.com/ipfs/go-cid"
 )
 
@@ -15,45 +24,161 @@ import (
 type MockRepositoryService struct {
 	repositories map[string]*repository.Repository
 	records      map[string]*repository.Record
+	writeErr     error // Returned by CreateRecord when set
+	commits      []*repository.Commit
+	imported     map[string][]byte // CARs passed to ImportRepository
+	blobRefs     map[string][]repository.BlobRef
+	changes      []repository.RecordChange // Returned by DiffRepository
+}
+
+// mockTokenService issues "token-<did>" for each DID
+type mockTokenService map[string]string
+
+func (m mockTokenService) IssueToken(did string) (string, error) {
+	m["token-"+did] = did
+	return "token-" + did, nil
+}
+
+func (m mockTokenService) Authenticate(token string) (string, error) {
+	did, ok := m[token]
+	if !ok {
+		return "", coreerrors.ErrUnauthorized
+	}
+	return did, nil
+}
+
+// recordCBOR encodes a JSON record as the DAG-CBOR the service stores
+func recordCBOR(record string) []byte {
+	b, err := atrepo.RecordJSONToCBOR([]byte(record))
+	if err != nil {
+		panic(err)
+	}
+	return b
 }
 
 func NewMockRepositoryService() *MockRepositoryService {
 	return &MockRepositoryService{
 		repositories: make(map[string]*repository.Repository),
 		records:      make(map[string]*repository.Record),
+		imported:     make(map[string][]byte),
+		blobRefs:     make(map[string][]repository.BlobRef),
 	}
 }
 
+// genesisCID stands in for the genesis commit the service writes for new
+// repositories
+var genesisCID, _ = cid.Decode("bafyreibme22gw2h7y2h7tg2fhqotaqjucnbc24deqo72b6mkl2egezxhvy")
+
 func (m *MockRepositoryService) CreateRepository(did string) (*repository.Repository, error) {
+	repo := &repository.Repository{
+		DID:     did,
+		HeadCID: genesisCID,
+		Status:  repository.StatusActive,
+	}
+	m.repositories[did] = repo
+	return repo, nil
+}
+
+func (m *MockRepositoryService) CreateInactiveRepository(did string) (*repository.Repository, error) {
+	if _, exists := m.repositories[did]; exists {
+		return nil, coreerrors.NewConflictError(coreerrors.ResourceRepo, "DID", did)
+	}
 	repo := &repository.Repository{
 		DID:     did,
 		HeadCID: cid.Undef,
+		Status:  repository.StatusDeactivated,
 	}
 	m.repositories[did] = repo
 	return repo, nil
 }
 
+// activeRepo mirrors the service's checks before serving a repository
+func (m *MockRepositoryService) activeRepo(did string) error {
+	repo, exists := m.repositories[did]
+	if !exists {
+		return coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	if !repo.Active() {
+		return repository.InactiveError{DID: did, Status: repo.Status}
+	}
+	return nil
+}
+
+func (m *MockRepositoryService) setStatus(did, status, reason string) (*repository.Repository, error) {
+	repo, exists := m.repositories[did]
+	if !exists {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	repo.Status = status
+	repo.StatusReason = reason
+	return repo, nil
+}
+
+func (m *MockRepositoryService) DeactivateRepository(did string) (*repository.Repository, error) {
+	return m.setStatus(did, repository.StatusDeactivated, "")
+}
+
+func (m *MockRepositoryService) ActivateRepository(did string) (*repository.Repository, error) {
+	if repo, exists := m.repositories[did]; exists && repo.Status != repository.StatusDeactivated && !repo.Active() {
+		return nil, repository.InactiveError{DID: did, Status: repo.Status}
+	}
+	return m.setStatus(did, repository.StatusActive, "")
+}
+
+func (m *MockRepositoryService) UpdateRepositoryStatus(did string, status string, reason string) (*repository.Repository, error) {
+	if !repository.ValidStatus(status) {
+		return nil, coreerrors.NewValidationError("status", "unknown account status")
+	}
+	return m.setStatus(did, status, reason)
+}
+
 func (m *MockRepositoryService) GetRepository(did string) (*repository.Repository, error) {
 	repo, exists := m.repositories[did]
 	if !exists {
-		return nil, nil
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
 	}
 	return repo, nil
 }
 
+func (m *MockRepositoryService) ListRepositories(limit int, cursor string) ([]*repository.Repository, string, error) {
+	var repos []*repository.Repository
+	for _, repo := range m.repositories {
+		repos = append(repos, repo)
+	}
+	return repos, "", nil
+}
+
 func (m *MockRepositoryService) DeleteRepository(did string) error {
 	delete(m.repositories, did)
 	return nil
 }
 
+func (m *MockRepositoryService) GetRepositoryStats(did string) (*repository.RepoStats, error) {
+	repo, exists := m.repositories[did]
+	if !exists {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	stats := &repository.RepoStats{DID: did, Status: repo.Status, Revision: repo.Revision, Collections: map[string]int{}}
+	for uri, record := range m.records {
+		if strings.HasPrefix(uri, "at://"+did+"/") {
+			stats.Collections[record.Collection]++
+			stats.RecordCount++
+		}
+	}
+	return stats, nil
+}
+
 func (m *MockRepositoryService) CreateRecord(input repository.CreateRecordInput) (*repository.Record, error) {
+	if m.writeErr != nil {
+		return nil, m.writeErr
+	}
 	uri := "at://" + input.DID + "/" + input.Collection + "/" + input.RecordKey
 	record := &repository.Record{
 		URI:        uri,
 		CID:        cid.Undef,
 		Collection: input.Collection,
 		RecordKey:  input.RecordKey,
-		Value:      []byte(`{"test": "data"}`),
+		Value:      recordCBOR(`{"test": "data"}`),
 	}
 	m.records[uri] = record
 	return record, nil
@@ -63,19 +188,25 @@ func (m *MockRepositoryService) GetRecord(input repository.GetRecordInput) (*rep
 	uri := "at://" + input.DID + "/" + input.Collection + "/" + input.RecordKey
 	record, exists := m.records[uri]
 	if !exists {
-		return nil, nil
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRecord, uri)
 	}
 	return record, nil
 }
 
 func (m *MockRepositoryService) UpdateRecord(input repository.UpdateRecordInput) (*repository.Record, error) {
 	uri := "at://" + input.DID + "/" + input.Collection + "/" + input.RecordKey
+	if input.SwapRecord != nil {
+		existing, exists := m.records[uri]
+		if !exists || !existing.CID.Equals(*input.SwapRecord) {
+			return nil, fmt.Errorf("updating record: %w", repository.ErrInvalidSwap)
+		}
+	}
 	record := &repository.Record{
 		URI:        uri,
 		CID:        cid.Undef,
 		Collection: input.Collection,
 		RecordKey:  input.RecordKey,
-		Value:      []byte(`{"test": "updated"}`),
+		Value:      recordCBOR(`{"test": "updated"}`),
 	}
 	m.records[uri] = record
 	return record, nil
@@ -87,6 +218,20 @@ func (m *MockRepositoryService) DeleteRecord(input repository.DeleteRecordInput)
 	return nil
 }
 
+func (m *MockRepositoryService) ApplyWrites(input repository.ApplyWritesInput) (*repository.ApplyWritesResult, error) {
+	result := &repository.ApplyWritesResult{CommitCID: cid.Undef, Revision: "rev"}
+	for _, op := range input.Writes {
+		uri := "at://" + input.DID + "/" + op.Collection + "/" + op.RecordKey
+		result.Results = append(result.Results, repository.WriteResult{
+			Action:    op.Action,
+			URI:       uri,
+			RecordKey: op.RecordKey,
+			CID:       cid.Undef,
+		})
+	}
+	return result, nil
+}
+
 func (m *MockRepositoryService) ListRecords(did string, collection string, limit int, cursor string) ([]*repository.Record, string, error) {
 	var records []*repository.Record
 	for _, record := range m.records {
@@ -101,16 +246,113 @@ func (m *MockRepositoryService) GetCommit(did string, cid cid.Cid) (*repository.
 	return nil, nil
 }
 
+func (m *MockRepositoryService) GetLatestCommit(did string) (*repository.Commit, error) {
+	return nil, nil
+}
+
 func (m *MockRepositoryService) ListCommits(did string, limit int, cursor string) ([]*repository.Commit, string, error) {
-	return []*repository.Commit{}, "", nil
+	if len(m.commits) > limit {
+		return m.commits[:limit], m.commits[limit-1].Revision, nil
+	}
+	return m.commits, "", nil
 }
 
-func (m *MockRepositoryService) ExportRepository(did string) ([]byte, error) {
-	return []byte("mock-car-data"), nil
+// DiffRepository returns the canned changes between any two of the mock's commits
+func (m *MockRepositoryService) DiffRepository(ctx context.Context, did string, fromRev string, toRev string, values bool) (*repository.RepoDiff, error) {
+	if _, exists := m.repositories[did]; !exists {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	commits := map[string]*repository.Commit{}
+	for _, c := range m.commits {
+		commits[c.Revision] = c
+	}
+	if toRev == "" && len(m.commits) > 0 {
+		toRev = m.commits[len(m.commits)-1].Revision
+	}
+	to, ok := commits[toRev]
+	if !ok {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRevision, toRev)
+	}
+
+	diff := &repository.RepoDiff{DID: did, FromRevision: fromRev, ToRevision: toRev, ToCommit: to.CID}
+	if fromRev != "" {
+		from, ok := commits[fromRev]
+		if !ok {
+			return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRevision, fromRev)
+		}
+		diff.FromCommit = from.CID
+	}
+	for _, change := range m.changes {
+		if !values {
+			change.PrevValue, change.Value = nil, nil
+		}
+		diff.Changes = append(diff.Changes, change)
+	}
+	return diff, nil
 }
 
-func (m *MockRepositoryService) ImportRepository(did string, carData []byte) error {
-	return nil
+func (m *MockRepositoryService) GetBlocks(ctx context.Context, did string, cids []cid.Cid, w io.Writer) error {
+	if err := m.activeRepo(did); err != nil {
+		return err
+	}
+	for _, c := range cids {
+		if c.Type() != cid.DagCBOR {
+			return coreerrors.NewNotFoundError(coreerrors.ResourceBlock, c)
+		}
+	}
+	_, err := w.Write([]byte("mock-blocks"))
+	return err
+}
+
+func (m *MockRepositoryService) GetRecordProof(ctx context.Context, did string, collection string, rkey string, w io.Writer) error {
+	uri := "at://" + did + "/" + collection + "/" + rkey
+	if _, exists := m.records[uri]; !exists {
+		return coreerrors.NewNotFoundError(coreerrors.ResourceRecord, uri)
+	}
+	_, err := w.Write([]byte("mock-proof"))
+	return err
+}
+
+func (m *MockRepositoryService) ExportRepository(ctx context.Context, did string, since string, w io.Writer) error {
+	if err := m.activeRepo(did); err != nil {
+		return err
+	}
+	data := "mock-car-data"
+	if since != "" {
+		data = "mock-car-diff-since-" + since
+	}
+	_, err := w.Write([]byte(data))
+	return err
+}
+
+func (m *MockRepositoryService) ImportRepository(ctx context.Context, did string, r io.Reader) (*repository.ImportResult, error) {
+	repo, exists := m.repositories[did]
+	if !exists {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	if repo.Active() {
+		return nil, coreerrors.NewValidationError("did", "repository is active")
+	}
+	if repo.Status != repository.StatusDeactivated {
+		return nil, repository.InactiveError{DID: did, Status: repo.Status}
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	m.imported[did] = data
+	return &repository.ImportResult{HeadCID: cid.Undef, Collections: map[string]int{}}, nil
+}
+
+func (m *MockRepositoryService) ListBlobRefs(did string) ([]repository.BlobRef, error) {
+	repo, exists := m.repositories[did]
+	if !exists {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	if !repo.Active() && repo.Status != repository.StatusDeactivated {
+		return nil, repository.InactiveError{DID: did, Status: repo.Status}
+	}
+	return m.blobRefs[did], nil
 }
 
 func TestCreateRecordHandler(t *testing.T) {
@@ -164,7 +406,7 @@ func TestGetRecordHandler(t *testing.T) {
 		CID:        cid.Undef,
 		Collection: "app.bsky.feed.post",
 		RecordKey:  "testkey",
-		Value:      []byte(`{"text": "Hello, world!"}`),
+		Value:      recordCBOR(`{"text": "Hello, world!", "likes": 3, "subject": {"$link": "bafyreie5737gdxlw5i64vzichcalba3z2v5n6icifvx5xytvske7mr3hpm"}}`),
 	}
 	mockService.records[uri] = testRecord
 
@@ -188,4 +430,546 @@ func TestGetRecordHandler(t *testing.T) {
 	if resp.URI != uri {
 		t.Errorf("Expected URI %s, got %s", uri, resp.URI)
 	}
-}
\ No newline at end of file
+
+	// The stored CBOR comes back as JSON with its integers and links intact
+	var value struct {
+		Text    string `json:"text"`
+		Likes   int64  `json:"likes"`
+		Subject struct {
+			Link string `json:"$link"`
+		} `json:"subject"`
+	}
+	if err := json.Unmarshal(resp.Value, &value); err != nil {
+		t.Fatalf("Expected record value to be JSON: %v", err)
+	}
+	if value.Text != "Hello, world!" || value.Likes != 3 || value.Subject.Link != "bafyreie5737gdxlw5i64vzichcalba3z2v5n6icifvx5xytvske7mr3hpm" {
+		t.Errorf("Unexpected record value: %s", resp.Value)
+	}
+}
+
+func TestCreateRecordHandler_InvalidJSONRecord(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	handler := NewRepositoryHandler(mockService)
+
+	reqBody, err := json.Marshal(CreateRecordRequest{
+		Repo:       "did:plc:test123",
+		Collection: "app.bsky.feed.post",
+		Record:     json.RawMessage(`{"$type": "app.bsky.feed.post", "score": 1.5}`),
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/xrpc/com.atproto.repo.createRecord", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.CreateRecord(w, req)
+
+	// Floats aren't part of the atproto data model
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestApplyWritesHandler(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	handler := NewRepositoryHandler(mockService)
+
+	reqBody := []byte(`{
+		"repo": "did:plc:test123",
+		"writes": [
+			{"$type": "com.atproto.repo.applyWrites#create", "collection": "social.coves.post.record", "rkey": "a", "value": {"text": "hi"}},
+			{"$type": "com.atproto.repo.applyWrites#delete", "collection": "social.coves.post.record", "rkey": "b"}
+		]
+	}`)
+
+	req := httptest.NewRequest("POST", "/xrpc/com.atproto.repo.applyWrites", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.ApplyWrites(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp ApplyWritesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(resp.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(resp.Results))
+	}
+	if resp.Results[0].Type != "com.atproto.repo.applyWrites#createResult" {
+		t.Errorf("Unexpected result type %s", resp.Results[0].Type)
+	}
+	if resp.Results[1].Type != "com.atproto.repo.applyWrites#deleteResult" {
+		t.Errorf("Unexpected result type %s", resp.Results[1].Type)
+	}
+}
+
+func TestApplyWritesHandler_UnknownType(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	handler := NewRepositoryHandler(mockService)
+
+	reqBody := []byte(`{"repo": "did:plc:test123", "writes": [{"$type": "bogus", "collection": "x.y.z"}]}`)
+	req := httptest.NewRequest("POST", "/xrpc/com.atproto.repo.applyWrites", bytes.NewReader(reqBody))
+	w := httptest.NewRecorder()
+
+	handler.ApplyWrites(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestPutRecordHandler_InvalidSwap(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	handler := NewRepositoryHandler(mockService)
+
+	uri := "at://did:plc:test123/app.bsky.feed.post/testkey"
+	mockService.records[uri] = &repository.Record{
+		URI:        uri,
+		CID:        cid.Undef,
+		Collection: "app.bsky.feed.post",
+		RecordKey:  "testkey",
+	}
+
+	reqData := PutRecordRequest{
+		Repo:       "did:plc:test123",
+		Collection: "app.bsky.feed.post",
+		RKey:       "testkey",
+		Record:     json.RawMessage(`{"text": "Hello again"}`),
+		SwapRecord: "bafyreie5737gdxlw5i64vzichcalba3z2v5n6icifvx5xytvske7mr3hpm",
+	}
+
+	reqBody, err := json.Marshal(reqData)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/xrpc/com.atproto.repo.putRecord", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.PutRecord(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", w.Code)
+	}
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp["error"] != "InvalidSwap" {
+		t.Errorf("Expected InvalidSwap error, got %v", resp["error"])
+	}
+}
+
+func TestCreateRecordHandler_InvalidRecord(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	mockService.writeErr = fmt.Errorf("creating record: write 0: %w: community: required field missing", repository.ErrInvalidRecord)
+	handler := NewRepositoryHandler(mockService)
+
+	reqBody, err := json.Marshal(CreateRecordRequest{
+		Repo:       "did:plc:test123",
+		Collection: "social.coves.post.record",
+		Record:     json.RawMessage(`{"$type": "social.coves.post.record", "postType": "text"}`),
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/xrpc/com.atproto.repo.createRecord", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.CreateRecord(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", w.Code)
+	}
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp["error"] != "InvalidRecord" {
+		t.Errorf("Expected InvalidRecord error, got %v", resp["error"])
+	}
+	if msg, _ := resp["message"].(string); !strings.Contains(msg, "community") {
+		t.Errorf("Expected the message to name the failing field, got %q", msg)
+	}
+}
+
+func TestGetRepoHandler(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	handler := NewRepositoryHandler(mockService)
+	mockService.CreateRepository("did:plc:test123")
+
+	req := httptest.NewRequest("GET", "/xrpc/com.atproto.sync.getRepo?did=did:plc:test123", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetRepo(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/vnd.ipld.car" {
+		t.Errorf("Expected CAR content type, got %s", ct)
+	}
+	if w.Header().Get("Content-Length") != "" {
+		t.Error("Expected streamed response without Content-Length")
+	}
+	if w.Body.String() != "mock-car-data" {
+		t.Errorf("Unexpected body %q", w.Body.String())
+	}
+}
+
+func TestGetRepoHandler_NotFound(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	handler := NewRepositoryHandler(mockService)
+
+	req := httptest.NewRequest("GET", "/xrpc/com.atproto.sync.getRepo?did=did:plc:missing", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetRepo(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected JSON error, got %s", ct)
+	}
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp["error"] != "RepoNotFound" {
+		t.Errorf("Expected RepoNotFound, got %v", resp["error"])
+	}
+}
+
+func TestGetRepoHandler_Since(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	handler := NewRepositoryHandler(mockService)
+	mockService.CreateRepository("did:plc:test123")
+
+	req := httptest.NewRequest("GET", "/xrpc/com.atproto.sync.getRepo?did=did:plc:test123&since=3kabc", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetRepo(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if w.Body.String() != "mock-car-diff-since-3kabc" {
+		t.Errorf("Expected since to be passed to the service, got body %q", w.Body.String())
+	}
+}
+
+func TestGetBlocksHandler(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	handler := NewRepositoryHandler(mockService)
+	mockService.CreateRepository("did:plc:test123")
+
+	// dag-cbor blocks exist in the mock, raw blocks don't
+	found := "bafyreie5737gdxlw5i64vzichcalba3z2v5n6icifvx5xytvske7mr3hpm"
+	missing := "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku"
+
+	tests := []struct {
+		name      string
+		query     string
+		wantCode  int
+		wantError string
+	}{
+		{"found", "did=did:plc:test123&cids=" + found, http.StatusOK, ""},
+		{"missing block", "did=did:plc:test123&cids=" + found + "&cids=" + missing, http.StatusBadRequest, "BlockNotFound"},
+		{"missing repo", "did=did:plc:missing&cids=" + found, http.StatusBadRequest, "RepoNotFound"},
+		{"invalid cid", "did=did:plc:test123&cids=notacid", http.StatusBadRequest, "InvalidRequest"},
+		{"no cids", "did=did:plc:test123", http.StatusBadRequest, "InvalidRequest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/xrpc/com.atproto.sync.getBlocks?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.GetBlocks(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if tt.wantError == "" {
+				if ct := w.Header().Get("Content-Type"); ct != "application/vnd.ipld.car" {
+					t.Errorf("Expected CAR content type, got %s", ct)
+				}
+				return
+			}
+			var resp map[string]interface{}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp["error"] != tt.wantError {
+				t.Errorf("Expected error %s, got %v", tt.wantError, resp["error"])
+			}
+		})
+	}
+}
+
+func TestSyncGetRecordHandler_NotFound(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	handler := NewRepositoryHandler(mockService)
+
+	req := httptest.NewRequest("GET", "/xrpc/com.atproto.sync.getRecord?did=did:plc:test123&collection=app.bsky.feed.post&rkey=missing", nil)
+	w := httptest.NewRecorder()
+
+	handler.SyncGetRecord(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", w.Code)
+	}
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp["error"] != "RecordNotFound" {
+		t.Errorf("Expected RecordNotFound, got %v", resp["error"])
+	}
+}
+
+func TestListReposHandler(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	handler := NewRepositoryHandler(mockService)
+	mockService.CreateRepository("did:plc:test123")
+
+	// A repository waiting for its migration import has no head yet
+	mockService.CreateInactiveRepository("did:plc:migrating")
+
+	req := httptest.NewRequest("GET", "/xrpc/com.atproto.sync.listRepos?limit=10", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListRepos(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var resp ListReposResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Repos) != 1 || resp.Repos[0].DID != "did:plc:test123" || !resp.Repos[0].Active {
+		t.Errorf("Unexpected repos %+v", resp.Repos)
+	}
+	if len(resp.Repos) == 1 && resp.Repos[0].Head != genesisCID.String() {
+		t.Errorf("Expected head %s, got %s", genesisCID, resp.Repos[0].Head)
+	}
+}
+
+// postAs posts an AccountRequest for did:plc:test123 to h with a Bearer token
+func postAs(token string, h http.HandlerFunc) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/xrpc/", strings.NewReader(`{"did": "did:plc:test123"}`))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	h(w, req)
+	return w
+}
+
+func TestAccountStatusHandlers(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	handler := NewRepositoryHandler(mockService)
+	mockService.CreateRepository("did:plc:test123")
+
+	tokens := mockTokenService{}
+	token, _ := tokens.IssueToken("did:plc:test123")
+	other, _ := tokens.IssueToken("did:plc:other")
+	post := func(h http.HandlerFunc) *httptest.ResponseRecorder {
+		return postAs(token, h)
+	}
+	getRepo := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/xrpc/com.atproto.sync.getRepo?did=did:plc:test123", nil)
+		w := httptest.NewRecorder()
+		handler.GetRepo(w, req)
+		return w
+	}
+	errorName := func(w *httptest.ResponseRecorder) string {
+		var resp map[string]interface{}
+		json.NewDecoder(w.Body).Decode(&resp)
+		name, _ := resp["error"].(string)
+		return name
+	}
+
+	// Only the owner can change the account's status
+	deactivate := xrpc.OwnerAuthHTTP(tokens, xrpc.InputDID, handler.DeactivateAccount)
+	if w := postAs("", deactivate); w.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected status 401 without auth, got %d", w.Code)
+	}
+	if w := postAs(other, deactivate); w.Code != http.StatusForbidden {
+		t.Fatalf("Expected status 403 for another account, got %d", w.Code)
+	}
+	if repo, _ := mockService.GetRepository("did:plc:test123"); !repo.Active() {
+		t.Fatal("Expected the rejected requests to leave the account active")
+	}
+
+	// A deactivated repository isn't served, but its status is
+	if w := post(deactivate); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := getRepo(); w.Code != http.StatusBadRequest || errorName(w) != "RepoDeactivated" {
+		t.Errorf("Expected 400 RepoDeactivated, got %d", w.Code)
+	}
+
+	req := httptest.NewRequest("GET", "/xrpc/com.atproto.sync.getRepoStatus?did=did:plc:test123", nil)
+	w := httptest.NewRecorder()
+	handler.GetRepoStatus(w, req)
+	var status map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&status); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if status["active"] != false || status["status"] != "deactivated" {
+		t.Errorf("Unexpected repo status %v", status)
+	}
+
+	req = httptest.NewRequest("GET", "/xrpc/com.atproto.sync.listRepos", nil)
+	w = httptest.NewRecorder()
+	handler.ListRepos(w, req)
+	var list ListReposResponse
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(list.Repos) != 1 || list.Repos[0].Active || list.Repos[0].Status != "deactivated" {
+		t.Errorf("Unexpected repos %+v", list.Repos)
+	}
+
+	// Reactivating serves it again
+	activate := xrpc.OwnerAuthHTTP(tokens, xrpc.InputDID, handler.ActivateAccount)
+	if w := post(activate); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := getRepo(); w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+
+	// Owners can't lift a takedown
+	mockService.UpdateRepositoryStatus("did:plc:test123", repository.StatusTakendown, "spam")
+	if w := post(activate); w.Code != http.StatusBadRequest || errorName(w) != "RepoTakendown" {
+		t.Errorf("Expected 400 RepoTakendown, got %d", w.Code)
+	}
+	if w := getRepo(); w.Code != http.StatusBadRequest || errorName(w) != "RepoTakendown" {
+		t.Errorf("Expected 400 RepoTakendown, got %d", w.Code)
+	}
+}
+
+func TestMigrationHandlers(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	handler := NewRepositoryHandler(mockService)
+	tokens := mockTokenService{}
+	handler.SetTokenService(tokens)
+
+	// A migrating account starts deactivated, with no head
+	req := httptest.NewRequest("POST", "/xrpc/com.atproto.repo.createRepo", strings.NewReader(`{"did": "did:plc:migrating", "deactivated": true}`))
+	w := httptest.NewRecorder()
+	handler.CreateRepository(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var created map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if created["active"] != false || created["head"] != nil {
+		t.Errorf("Expected an inactive repository without a head, got %v", created)
+	}
+	if did, err := tokens.Authenticate(fmt.Sprint(created["accessToken"])); err != nil || did != "did:plc:migrating" {
+		t.Errorf("Expected an access token for the account, got %v", created)
+	}
+
+	req = httptest.NewRequest("POST", "/xrpc/com.atproto.repo.importRepo?did=did:plc:migrating", strings.NewReader("car bytes"))
+	w = httptest.NewRecorder()
+	handler.ImportRepo(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if string(mockService.imported["did:plc:migrating"]) != "car bytes" {
+		t.Errorf("Expected the body to be imported, got %q", mockService.imported["did:plc:migrating"])
+	}
+
+	req = httptest.NewRequest("POST", "/xrpc/com.atproto.repo.importRepo", strings.NewReader("car bytes"))
+	w = httptest.NewRecorder()
+	handler.ImportRepo(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without a DID, got %d", w.Code)
+	}
+
+	// Imports don't create repositories or replace active ones
+	req = httptest.NewRequest("POST", "/xrpc/com.atproto.repo.importRepo?did=did:plc:unknown", strings.NewReader("car bytes"))
+	w = httptest.NewRecorder()
+	handler.ImportRepo(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "RepoNotFound") {
+		t.Errorf("Expected 400 RepoNotFound for a missing repository, got %d: %s", w.Code, w.Body.String())
+	}
+	mockService.CreateRepository("did:plc:active")
+	req = httptest.NewRequest("POST", "/xrpc/com.atproto.repo.importRepo?did=did:plc:active", strings.NewReader("car bytes"))
+	w = httptest.NewRecorder()
+	handler.ImportRepo(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "InvalidRequest") {
+		t.Errorf("Expected 400 InvalidRequest for an active repository, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Taken down repositories can't be overwritten
+	mockService.UpdateRepositoryStatus("did:plc:migrating", repository.StatusTakendown, "spam")
+	req = httptest.NewRequest("POST", "/xrpc/com.atproto.repo.importRepo?did=did:plc:migrating", strings.NewReader("car bytes"))
+	w = httptest.NewRecorder()
+	handler.ImportRepo(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "RepoTakendown") {
+		t.Errorf("Expected 400 RepoTakendown, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestListCommitsHandler(t *testing.T) {
+	mockService := NewMockRepositoryService()
+	handler := NewRepositoryHandler(mockService)
+
+	dataCID, _ := cid.Decode("bafyreie5737gdxlw5i64vzichcalba3z2v5n6icifvx5xytvske7mr3hpm")
+	for _, rev := range []string{"3k2a4b5c6d7e4", "3k2a4b5c6d7e3", "3k2a4b5c6d7e2"} {
+		mockService.commits = append(mockService.commits, &repository.Commit{
+			CID:          dataCID,
+			DataCID:      dataCID,
+			Revision:     rev,
+			Signature:    []byte{0xde, 0xad},
+			SigningKeyID: "did:key:zQ3shtest",
+			CreatedAt:    time.Date(2025, 1, 9, 14, 30, 0, 0, time.UTC),
+		})
+	}
+
+	// Served through the dispatcher, so the output is checked against the lexicon
+	lexicons, err := validation.NewLexiconValidator("../../atproto/lexicon", false)
+	if err != nil {
+		t.Fatalf("Failed to load lexicons: %v", err)
+	}
+	server := xrpc.NewServer(lexicons)
+	server.Handle("social.coves.repo.listCommits", handler.ListCommits)
+
+	req := httptest.NewRequest("GET", "/xrpc/social.coves.repo.listCommits?did=did:plc:test123&limit=2", nil)
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ListCommitsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Commits) != 2 || resp.Cursor != "3k2a4b5c6d7e3" {
+		t.Errorf("Expected 2 commits and a cursor, got %d commits, cursor %q", len(resp.Commits), resp.Cursor)
+	}
+	if resp.Commits[0].Sig != "dead" {
+		t.Errorf("Expected hex signature, got %q", resp.Commits[0].Sig)
+	}
+}
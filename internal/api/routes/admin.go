@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"Coves/internal/api/handlers"
+	"Coves/internal/api/xrpc"
+	"Coves/internal/core/auth"
+	"Coves/internal/core/compaction"
+	"Coves/internal/core/repository"
+)
+
+// AdminRoutes registers the admin XRPC methods on server. Every method
+// requires the admin password.
+func AdminRoutes(server *xrpc.Server, service repository.RepositoryService, compactor *compaction.Scheduler, tokens auth.TokenService, password string) {
+	handler := handlers.NewAdminHandler(service, compactor)
+	handler.SetTokenService(tokens)
+
+	// Moderation
+	server.Handle("social.coves.admin.updateRepoStatus", xrpc.AdminAuth(password, handler.UpdateRepoStatus))
+
+	// Accounts
+	server.Handle("social.coves.admin.createAccessToken", xrpc.AdminAuth(password, handler.CreateAccessToken))
+
+	// Maintenance
+	server.Handle("social.coves.admin.getRepoStats", xrpc.AdminAuth(password, handler.GetRepoStats))
+	server.Handle("social.coves.admin.getRepoDiff", xrpc.AdminAuth(password, handler.GetRepoDiff))
+	server.Handle("social.coves.admin.compactRepo", xrpc.AdminAuth(password, handler.CompactRepo))
+	server.Handle("social.coves.admin.runCompaction", xrpc.AdminAuth(password, handler.RunCompaction))
+	server.Handle("social.coves.admin.listCompactions", xrpc.AdminAuth(password, handler.ListCompactions))
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"net/http"
+
+	"Coves/internal/api/handlers"
+	"Coves/internal/api/xrpc"
+	"Coves/internal/core/auth"
+	"Coves/internal/core/blobs"
+)
+
+// BlobRoutes registers the blob XRPC methods on server. Uploads require the
+// repository owner's access token.
+func BlobRoutes(server *xrpc.Server, service *blobs.Service, tokens auth.TokenService) {
+	handler := handlers.NewBlobHandler(service)
+
+	// Uploads, including a migrating account's backfill
+	server.HandleHTTP(http.MethodPost, "com.atproto.repo.uploadBlob", xrpc.OwnerAuthHTTP(tokens, xrpc.ParamDID, handler.UploadBlob))
+	server.HandleHTTP(http.MethodGet, "com.atproto.repo.listMissingBlobs", handler.ListMissingBlobs)
+
+	// Sync operations
+	server.HandleHTTP(http.MethodGet, "com.atproto.sync.listBlobs", handler.ListBlobs)
+	server.HandleHTTP(http.MethodGet, "com.atproto.sync.getBlob", handler.GetBlob)
+}
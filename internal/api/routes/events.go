@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"Coves/internal/api/handlers"
+	"Coves/internal/core/events"
+	"github.com/go-chi/chi/v5"
+)
+
+// SubscribeReposPath is where EventRoutes should be mounted
+const SubscribeReposPath = "/xrpc/com.atproto.sync.subscribeRepos"
+
+// EventRoutes returns the com.atproto.sync.subscribeRepos event stream route
+func EventRoutes(service events.EventService) chi.Router {
+	handler := handlers.NewFirehoseHandler(service)
+
+	r := chi.NewRouter()
+
+	r.Get("/", handler.SubscribeRepos)
+
+	return r
+}
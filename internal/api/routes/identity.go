@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"net/http"
+
+	"Coves/internal/api/handlers"
+	"Coves/internal/api/xrpc"
+	"Coves/internal/core/migration"
+)
+
+// IdentityRoutes registers the DID migration XRPC methods on server.
+// Signing uses the server's rotation key, so it requires the admin password.
+func IdentityRoutes(server *xrpc.Server, service *migration.Service, adminPassword string) {
+	handler := handlers.NewIdentityHandler(service)
+
+	server.HandleHTTP(http.MethodGet, "com.atproto.identity.getRecommendedDidCredentials", handler.GetRecommendedDidCredentials)
+	server.HandleHTTP(http.MethodPost, "com.atproto.identity.signPlcOperation", xrpc.AdminAuthHTTP(adminPassword, handler.SignPlcOperation))
+	server.HandleHTTP(http.MethodPost, "com.atproto.identity.submitPlcOperation", handler.SubmitPlcOperation)
+}
@@ -1,33 +1,47 @@
 package routes
 
 import (
+	"net/http"
+
 	"Coves/internal/api/handlers"
+	"Coves/internal/api/xrpc"
+	"Coves/internal/core/auth"
 	"Coves/internal/core/repository"
-	"github.com/go-chi/chi/v5"
 )
 
-// RepositoryRoutes returns repository-related routes
-func RepositoryRoutes(service repository.RepositoryService) chi.Router {
+// RepositoryRoutes registers the repository XRPC methods on server. Methods
+// that change a repository require its owner's access token, which
+// createRepo hands out.
+func RepositoryRoutes(server *xrpc.Server, service repository.RepositoryService, tokens auth.TokenService) {
 	handler := handlers.NewRepositoryHandler(service)
-	
-	r := chi.NewRouter()
-	
-	// AT Protocol XRPC endpoints for repository operations
-	r.Route("/xrpc", func(r chi.Router) {
-		// Record operations
-		r.Post("/com.atproto.repo.createRecord", handler.CreateRecord)
-		r.Get("/com.atproto.repo.getRecord", handler.GetRecord)
-		r.Post("/com.atproto.repo.putRecord", handler.PutRecord)
-		r.Post("/com.atproto.repo.deleteRecord", handler.DeleteRecord)
-		r.Get("/com.atproto.repo.listRecords", handler.ListRecords)
-		
-		// Repository operations
-		r.Post("/com.atproto.repo.createRepo", handler.CreateRepository)
-		
-		// Sync operations
-		r.Get("/com.atproto.sync.getRepo", handler.GetRepo)
-		r.Get("/com.atproto.sync.getCommit", handler.GetCommit)
-	})
-	
-	return r
-}
\ No newline at end of file
+	handler.SetTokenService(tokens)
+
+	// Record operations
+	server.HandleHTTP(http.MethodPost, "com.atproto.repo.createRecord", xrpc.OwnerAuthHTTP(tokens, xrpc.InputRepo, handler.CreateRecord))
+	server.HandleHTTP(http.MethodGet, "com.atproto.repo.getRecord", handler.GetRecord)
+	server.HandleHTTP(http.MethodPost, "com.atproto.repo.putRecord", xrpc.OwnerAuthHTTP(tokens, xrpc.InputRepo, handler.PutRecord))
+	server.HandleHTTP(http.MethodPost, "com.atproto.repo.deleteRecord", xrpc.OwnerAuthHTTP(tokens, xrpc.InputRepo, handler.DeleteRecord))
+	server.HandleHTTP(http.MethodPost, "com.atproto.repo.applyWrites", xrpc.OwnerAuthHTTP(tokens, xrpc.InputRepo, handler.ApplyWrites))
+	server.HandleHTTP(http.MethodGet, "com.atproto.repo.listRecords", handler.ListRecords)
+
+	// Repository operations
+	server.HandleHTTP(http.MethodPost, "com.atproto.repo.createRepo", handler.CreateRepository)
+	server.HandleHTTP(http.MethodPost, "com.atproto.repo.importRepo", xrpc.OwnerAuthHTTP(tokens, xrpc.ParamDID, handler.ImportRepo))
+
+	// Account status, changed by the repository owner. Takedowns and
+	// suspensions are admin methods.
+	server.HandleHTTP(http.MethodPost, "com.atproto.server.deactivateAccount", xrpc.OwnerAuthHTTP(tokens, xrpc.InputDID, handler.DeactivateAccount))
+	server.HandleHTTP(http.MethodPost, "com.atproto.server.activateAccount", xrpc.OwnerAuthHTTP(tokens, xrpc.InputDID, handler.ActivateAccount))
+
+	// Sync operations
+	server.HandleHTTP(http.MethodGet, "com.atproto.sync.getRepo", handler.GetRepo)
+	server.HandleHTTP(http.MethodGet, "com.atproto.sync.getCommit", handler.GetCommit)
+	server.HandleHTTP(http.MethodGet, "com.atproto.sync.getLatestCommit", handler.GetLatestCommit)
+	server.HandleHTTP(http.MethodGet, "com.atproto.sync.getBlocks", handler.GetBlocks)
+	server.HandleHTTP(http.MethodGet, "com.atproto.sync.getRecord", handler.SyncGetRecord)
+	server.HandleHTTP(http.MethodGet, "com.atproto.sync.listRepos", handler.ListRepos)
+	server.HandleHTTP(http.MethodGet, "com.atproto.sync.getRepoStatus", handler.GetRepoStatus)
+
+	// Commit history
+	server.Handle("social.coves.repo.listCommits", handler.ListCommits)
+}
@@ -0,0 +1,41 @@
+package xrpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+)
+
+// adminUser is the Basic auth username for admin methods
+const adminUser = "admin"
+
+// AdminAuth wraps h so it only runs for requests with the admin password,
+// sent with HTTP Basic auth as the user "admin"
+func AdminAuth(password string, h Handler) Handler {
+	return func(ctx context.Context, req *Request) (interface{}, error) {
+		if !isAdmin(req.HTTP, password) {
+			return nil, Errorf(http.StatusUnauthorized, "AuthRequired", "admin auth required")
+		}
+		return h(ctx, req)
+	}
+}
+
+// AdminAuthHTTP is AdminAuth for handlers registered with HandleHTTP
+func AdminAuthHTTP(password string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r, password) {
+			WriteError(w, http.StatusUnauthorized, "AuthRequired", "admin auth required")
+			return
+		}
+		h(w, r)
+	}
+}
+
+// isAdmin reports whether r carries the admin password. With no password
+// set nobody is an admin.
+func isAdmin(r *http.Request, password string) bool {
+	user, pass, ok := r.BasicAuth()
+	return ok && password != "" &&
+		subtle.ConstantTimeCompare([]byte(user), []byte(adminUser)) == 1 &&
+		subtle.ConstantTimeCompare([]byte(pass), []byte(password)) == 1
+}
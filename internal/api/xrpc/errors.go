@@ -0,0 +1,112 @@
+package xrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	atrepo "Coves/internal/atproto/repo"
+	"Coves/internal/core/blobs"
+	coreerrors "Coves/internal/core/errors"
+	"Coves/internal/core/repository"
+	"Coves/internal/validation"
+)
+
+// Error is an XRPC error response. Handlers may return one to choose the
+// status and error name the client sees; other errors go through ErrorFor.
+type Error struct {
+	Status  int
+	Name    string // XRPC error name, such as InvalidRequest or RecordNotFound
+	Message string
+}
+
+// Errorf returns an *Error with a formatted message
+func Errorf(status int, name string, format string, args ...interface{}) *Error {
+	return &Error{Status: status, Name: name, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Name, e.Message)
+}
+
+// WriteError writes an XRPC error body
+func WriteError(w http.ResponseWriter, status int, name string, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error":   name,
+		"message": message,
+	})
+}
+
+// notFoundNames are the lexicon-declared error names for missing resources.
+// Like other named errors they are sent as 400s, as atproto servers do.
+var notFoundNames = map[string]string{
+	coreerrors.ResourceRepo:     "RepoNotFound",
+	coreerrors.ResourceRecord:   "RecordNotFound",
+	coreerrors.ResourceBlock:    "BlockNotFound",
+	coreerrors.ResourceBlob:     "BlobNotFound",
+	coreerrors.ResourceRevision: "RevisionNotFound",
+}
+
+// inactiveNames are the error names for repositories whose account isn't
+// active, as the sync lexicons declare them
+var inactiveNames = map[string]string{
+	repository.StatusDeactivated: "RepoDeactivated",
+	repository.StatusTakendown:   "RepoTakendown",
+	repository.StatusSuspended:   "RepoSuspended",
+}
+
+// ErrorFor maps an error from the core services to the XRPC error the
+// client sees. This is the one place errors become status codes and error
+// names, so clients can rely on the names rather than parsing messages.
+func ErrorFor(err error) *Error {
+	var xerr *Error
+	if errors.As(err, &xerr) {
+		return xerr
+	}
+
+	var notFound coreerrors.NotFoundError
+	var inactive repository.InactiveError
+	var fieldErr *validation.FieldError
+	switch {
+	case errors.As(err, &inactive):
+		name, ok := inactiveNames[inactive.Status]
+		if !ok {
+			name = "InvalidRequest"
+		}
+		return &Error{Status: http.StatusBadRequest, Name: name, Message: err.Error()}
+	case errors.As(err, &notFound):
+		if name, ok := notFoundNames[notFound.Resource]; ok {
+			return &Error{Status: http.StatusBadRequest, Name: name, Message: err.Error()}
+		}
+		return &Error{Status: http.StatusNotFound, Name: "NotFound", Message: err.Error()}
+	case errors.Is(err, repository.ErrInvalidSwap):
+		return &Error{Status: http.StatusBadRequest, Name: "InvalidSwap", Message: err.Error()}
+	case errors.Is(err, repository.ErrInvalidRecord):
+		return &Error{Status: http.StatusBadRequest, Name: "InvalidRecord", Message: err.Error()}
+	case errors.Is(err, blobs.ErrBlobTooLarge):
+		return &Error{Status: http.StatusBadRequest, Name: "BlobTooLarge", Message: err.Error()}
+	case errors.Is(err, coreerrors.ErrAlreadyExists):
+		return &Error{Status: http.StatusConflict, Name: "AlreadyExists", Message: err.Error()}
+	case errors.Is(err, coreerrors.ErrInvalidInput),
+		errors.Is(err, coreerrors.ErrValidationFailed),
+		errors.Is(err, atrepo.ErrInvalidCAR),
+		errors.Is(err, atrepo.ErrImportTooLarge),
+		errors.As(err, &fieldErr):
+		return &Error{Status: http.StatusBadRequest, Name: "InvalidRequest", Message: err.Error()}
+	case errors.Is(err, coreerrors.ErrUnauthorized):
+		return &Error{Status: http.StatusUnauthorized, Name: "AuthRequired", Message: err.Error()}
+	case errors.Is(err, coreerrors.ErrForbidden):
+		return &Error{Status: http.StatusForbidden, Name: "Forbidden", Message: err.Error()}
+	default:
+		return &Error{Status: http.StatusInternalServerError, Name: "InternalServerError", Message: err.Error()}
+	}
+}
+
+// WriteErrorFor writes the XRPC error ErrorFor maps err to
+func WriteErrorFor(w http.ResponseWriter, err error) {
+	e := ErrorFor(err)
+	WriteError(w, e.Status, e.Name, e.Message)
+}
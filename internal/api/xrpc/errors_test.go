@@ -0,0 +1,46 @@
+package xrpc_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"Coves/internal/api/xrpc"
+	atrepo "Coves/internal/atproto/repo"
+	"Coves/internal/core/blobs"
+	coreerrors "Coves/internal/core/errors"
+	"Coves/internal/core/repository"
+)
+
+func TestErrorFor(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err     error
+		status  int
+		errName string
+	}{
+		"xrpc error":       {xrpc.Errorf(http.StatusTeapot, "Teapot", "short and stout"), http.StatusTeapot, "Teapot"},
+		"missing repo":     {fmt.Errorf("get: %w", coreerrors.NewNotFoundError(coreerrors.ResourceRepo, "did:plc:x")), http.StatusBadRequest, "RepoNotFound"},
+		"missing record":   {atrepo.ErrRecordNotFound, http.StatusBadRequest, "RecordNotFound"},
+		"missing block":    {repository.ErrBlockNotFound, http.StatusBadRequest, "BlockNotFound"},
+		"missing blob":     {coreerrors.NewNotFoundError(coreerrors.ResourceBlob, "bafkrei"), http.StatusBadRequest, "BlobNotFound"},
+		"missing revision": {coreerrors.NewNotFoundError(coreerrors.ResourceRevision, "3k2a4b5c6d7e2"), http.StatusBadRequest, "RevisionNotFound"},
+		"blob too large":   {fmt.Errorf("upload: %w", blobs.ErrBlobTooLarge), http.StatusBadRequest, "BlobTooLarge"},
+		"missing user":     {coreerrors.NewNotFoundError(coreerrors.ResourceUser, "alice"), http.StatusNotFound, "NotFound"},
+		"takendown repo":   {repository.InactiveError{DID: "did:plc:x", Status: repository.StatusTakendown}, http.StatusBadRequest, "RepoTakendown"},
+		"deactivated repo": {fmt.Errorf("export: %w", repository.InactiveError{DID: "did:plc:x", Status: repository.StatusDeactivated}), http.StatusBadRequest, "RepoDeactivated"},
+		"invalid swap":     {fmt.Errorf("put: %w", repository.ErrInvalidSwap), http.StatusBadRequest, "InvalidSwap"},
+		"invalid record":   {repository.ErrInvalidRecord, http.StatusBadRequest, "InvalidRecord"},
+		"conflict":         {coreerrors.NewConflictError(coreerrors.ResourceRepo, "did", "did:plc:x"), http.StatusConflict, "AlreadyExists"},
+		"validation":       {coreerrors.NewValidationError("did", "required"), http.StatusBadRequest, "InvalidRequest"},
+		"invalid CAR":      {atrepo.ErrInvalidCAR, http.StatusBadRequest, "InvalidRequest"},
+		"unauthorized":     {coreerrors.ErrUnauthorized, http.StatusUnauthorized, "AuthRequired"},
+		"forbidden":        {coreerrors.ErrForbidden, http.StatusForbidden, "Forbidden"},
+		"unexpected error": {errors.New("disk on fire"), http.StatusInternalServerError, "InternalServerError"},
+	} {
+		e := xrpc.ErrorFor(tc.err)
+		if e.Status != tc.status || e.Name != tc.errName {
+			t.Errorf("%s: expected %d %s, got %d %s", name, tc.status, tc.errName, e.Status, e.Name)
+		}
+	}
+}
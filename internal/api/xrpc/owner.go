@@ -0,0 +1,95 @@
+package xrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxOwnerInput is how much of a JSON input OwnerAuthHTTP reads to find the
+// repository a request is for
+const maxOwnerInput = 10 << 20
+
+// TokenAuthenticator resolves an account access token to the DID it was
+// issued for
+type TokenAuthenticator interface {
+	Authenticate(token string) (string, error)
+}
+
+// RepoFunc returns the DID of the repository a request changes, read from
+// the same place its handler reads it
+type RepoFunc func(r *http.Request) (string, error)
+
+// OwnerAuthHTTP wraps h so it only runs for the owner of the repository repo
+// returns. The account's access token is sent as a Bearer token.
+func OwnerAuthHTTP(tokens TokenAuthenticator, repo RepoFunc, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			WriteError(w, http.StatusUnauthorized, "AuthRequired", "account auth required")
+			return
+		}
+		owner, err := tokens.Authenticate(token)
+		if err != nil {
+			WriteErrorFor(w, err)
+			return
+		}
+
+		did, err := repo(r)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+			return
+		}
+		if did == "" {
+			WriteError(w, http.StatusBadRequest, "InvalidRequest", "missing repository DID")
+			return
+		}
+		if did != owner {
+			WriteError(w, http.StatusForbidden, "Forbidden", "the access token is for another account")
+			return
+		}
+
+		h(w, r)
+	}
+}
+
+// ParamDID reads the repository from the did query parameter
+func ParamDID(r *http.Request) (string, error) {
+	return r.URL.Query().Get("did"), nil
+}
+
+// InputRepo reads the repository from the repo field of a JSON input
+func InputRepo(r *http.Request) (string, error) {
+	var input struct {
+		Repo string `json:"repo"`
+	}
+	err := peekInput(r, &input)
+	return input.Repo, err
+}
+
+// InputDID reads the repository from the did field of a JSON input
+func InputDID(r *http.Request) (string, error) {
+	var input struct {
+		DID string `json:"did"`
+	}
+	err := peekInput(r, &input)
+	return input.DID, err
+}
+
+// peekInput decodes r's JSON body into v and puts the body back for the
+// handler, which decodes it the same way
+func peekInput(r *http.Request, v interface{}) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxOwnerInput))
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("invalid request: %v", err)
+	}
+	return nil
+}
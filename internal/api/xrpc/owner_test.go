@@ -0,0 +1,89 @@
+package xrpc_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"Coves/internal/api/xrpc"
+	coreerrors "Coves/internal/core/errors"
+)
+
+// staticTokens maps access tokens to the DIDs they were issued for
+type staticTokens map[string]string
+
+func (s staticTokens) Authenticate(token string) (string, error) {
+	did, ok := s[token]
+	if !ok {
+		return "", coreerrors.ErrUnauthorized
+	}
+	return did, nil
+}
+
+func TestOwnerAuthHTTP(t *testing.T) {
+	tokens := staticTokens{"owner-token": testDID, "other-token": "did:plc:other"}
+
+	var got string
+	h := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = string(body)
+		w.WriteHeader(http.StatusOK)
+	}
+
+	call := func(repo xrpc.RepoFunc, token, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		xrpc.OwnerAuthHTTP(tokens, repo, h)(w, req)
+		return w
+	}
+
+	input := `{"repo": "` + testDID + `", "collection": "social.coves.test.record"}`
+	tests := []struct {
+		name     string
+		repo     xrpc.RepoFunc
+		token    string
+		target   string
+		body     string
+		wantCode int
+		wantErr  string
+	}{
+		{"no token", xrpc.InputRepo, "", "/xrpc/m", input, http.StatusUnauthorized, "AuthRequired"},
+		{"unknown token", xrpc.InputRepo, "guess", "/xrpc/m", input, http.StatusUnauthorized, "AuthRequired"},
+		{"other account", xrpc.InputRepo, "other-token", "/xrpc/m", input, http.StatusForbidden, "Forbidden"},
+		{"missing repo", xrpc.InputRepo, "owner-token", "/xrpc/m", `{"collection": "x"}`, http.StatusBadRequest, "InvalidRequest"},
+		{"invalid input", xrpc.InputRepo, "owner-token", "/xrpc/m", `{`, http.StatusBadRequest, "InvalidRequest"},
+		{"query names another repo", xrpc.InputRepo, "other-token", "/xrpc/m?did=did:plc:other", input, http.StatusForbidden, "Forbidden"},
+		{"did field", xrpc.InputDID, "other-token", "/xrpc/m", `{"repo": "did:plc:other", "did": "` + testDID + `"}`, http.StatusForbidden, "Forbidden"},
+		{"param", xrpc.ParamDID, "other-token", "/xrpc/m?did=" + testDID, "car bytes", http.StatusForbidden, "Forbidden"},
+		{"owner", xrpc.InputRepo, "owner-token", "/xrpc/m", input, http.StatusOK, ""},
+		{"owner param", xrpc.ParamDID, "owner-token", "/xrpc/m?did=" + testDID, "car bytes", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = ""
+			w := call(tt.repo, tt.token, tt.target, tt.body)
+			if w.Code != tt.wantCode {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantErr != "" {
+				if name := errorName(t, w); name != tt.wantErr {
+					t.Errorf("Expected %s, got %s", tt.wantErr, name)
+				}
+				if got != "" {
+					t.Error("Expected the handler not to run")
+				}
+				return
+			}
+			// The handler still gets the whole body
+			if got != tt.body {
+				t.Errorf("Expected the handler to read %q, got %q", tt.body, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package xrpc
+
+// Params holds a request's query parameters, typed as its lexicon declares
+// them. Parameters with defaults are always present; the accessors return
+// the zero value for optional parameters the caller left out.
+type Params map[string]interface{}
+
+// String returns a string parameter
+func (p Params) String(name string) string {
+	s, _ := p[name].(string)
+	return s
+}
+
+// Int returns an integer parameter
+func (p Params) Int(name string) int64 {
+	n, _ := p[name].(int64)
+	return n
+}
+
+// Bool returns a boolean parameter
+func (p Params) Bool(name string) bool {
+	b, _ := p[name].(bool)
+	return b
+}
+
+// Strings returns an array-of-strings parameter
+func (p Params) Strings(name string) []string {
+	items, _ := p[name].([]interface{})
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Has reports whether a parameter was given or has a default
+func (p Params) Has(name string) bool {
+	_, ok := p[name]
+	return ok
+}
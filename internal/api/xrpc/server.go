@@ -0,0 +1,222 @@
+// Package xrpc dispatches XRPC requests to handlers registered by NSID. The
+// lexicon for each method decides whether it is served over GET or POST,
+// and its parameters, input and output are checked against the lexicon, so
+// handlers only deal with requests that are already well formed.
+package xrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"Coves/internal/validation"
+)
+
+// Handler serves a lexicon method. It returns the output body: a value that
+// is marshalled to JSON for application/json outputs, or a []byte or
+// io.Reader for any other encoding. Methods without an output return nil.
+// Errors are sent as ErrorFor maps them.
+type Handler func(ctx context.Context, req *Request) (interface{}, error)
+
+// Request is a method call whose parameters and input have been checked
+// against the method's lexicon
+type Request struct {
+	NSID   string
+	Params Params
+	Input  []byte // procedure input body; nil for queries
+	HTTP   *http.Request
+}
+
+// Server routes /xrpc/{nsid} requests to registered handlers. It implements
+// http.Handler and is meant to be mounted under /xrpc/.
+type Server struct {
+	lexicons *validation.LexiconValidator
+	routes   map[string]*route
+}
+
+type route struct {
+	method     *validation.Method // nil for methods without a lexicon
+	httpMethod string
+	handler    Handler
+	raw        http.HandlerFunc
+}
+
+// NewServer creates a server that checks requests against lexicons
+func NewServer(lexicons *validation.LexiconValidator) *Server {
+	return &Server{
+		lexicons: lexicons,
+		routes:   make(map[string]*route),
+	}
+}
+
+// Handle registers h for the query or procedure nsid. It panics if the
+// method has no lexicon or is already registered, like http.ServeMux does
+// for bad patterns, since either is a programming error.
+func (s *Server) Handle(nsid string, h Handler) {
+	m, err := s.lexicons.Method(nsid)
+	if err != nil {
+		panic(fmt.Sprintf("xrpc: no lexicon for %s: %v", nsid, err))
+	}
+	s.register(nsid, &route{method: m, httpMethod: httpMethodFor(m), handler: h})
+}
+
+// HandleHTTP registers a plain HTTP handler for nsid, for methods that
+// write their own responses (such as CAR streams) or whose lexicons aren't
+// loaded (such as com.atproto.*). If the lexicon is loaded, its HTTP method
+// must be httpMethod and the query parameters are checked before h runs.
+func (s *Server) HandleHTTP(httpMethod, nsid string, h http.HandlerFunc) {
+	m, err := s.lexicons.Method(nsid)
+	if err != nil {
+		m = nil
+	} else if httpMethodFor(m) != httpMethod {
+		panic(fmt.Sprintf("xrpc: %s is a %s and must be served over %s", nsid, m.Type, httpMethodFor(m)))
+	}
+	s.register(nsid, &route{method: m, httpMethod: httpMethod, raw: h})
+}
+
+func (s *Server) register(nsid string, r *route) {
+	if _, ok := s.routes[nsid]; ok {
+		panic(fmt.Sprintf("xrpc: %s registered twice", nsid))
+	}
+	s.routes[nsid] = r
+}
+
+// ServeHTTP dispatches a request for /xrpc/{nsid}
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	nsid := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+	rt, ok := s.routes[nsid]
+	if !ok {
+		if _, err := s.lexicons.Method(nsid); err == nil {
+			WriteError(w, http.StatusNotImplemented, "MethodNotImplemented", fmt.Sprintf("%s is not implemented", nsid))
+		} else {
+			WriteError(w, http.StatusNotImplemented, "MethodNotImplemented", fmt.Sprintf("unknown method %s", nsid))
+		}
+		return
+	}
+
+	if r.Method != rt.httpMethod && !(r.Method == http.MethodHead && rt.httpMethod == http.MethodGet) {
+		w.Header().Set("Allow", rt.httpMethod)
+		WriteError(w, http.StatusMethodNotAllowed, "InvalidRequest", fmt.Sprintf("%s must be called with %s", nsid, rt.httpMethod))
+		return
+	}
+
+	var params Params
+	if rt.method != nil {
+		p, err := s.lexicons.ParseParams(rt.method, r.URL.Query())
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "InvalidRequest", fmt.Sprintf("invalid parameter %v", err))
+			return
+		}
+		params = p
+	}
+
+	if rt.raw != nil {
+		rt.raw(w, r)
+		return
+	}
+
+	req := &Request{NSID: nsid, Params: params, HTTP: r}
+	if rt.method.Type == validation.MethodProcedure {
+		input, err := s.readInput(rt.method, r)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "InvalidRequest", err.Error())
+			return
+		}
+		req.Input = input
+	}
+
+	out, err := rt.handler(r.Context(), req)
+	if err != nil {
+		WriteErrorFor(w, err)
+		return
+	}
+
+	s.writeOutput(w, rt.method, out)
+}
+
+// readInput reads a procedure's input, checking its encoding and, for JSON
+// input, its schema
+func (s *Server) readInput(m *validation.Method, r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	if m.Input == nil {
+		return body, nil
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("%s requires %s input", m.NSID, m.Input.Encoding)
+	}
+	if !encodingMatches(m.Input.Encoding, r.Header.Get("Content-Type")) {
+		return nil, fmt.Errorf("wrong input encoding %q, expected %s", r.Header.Get("Content-Type"), m.Input.Encoding)
+	}
+	if err := s.lexicons.ValidateBody(m, m.Input, body); err != nil {
+		return nil, fmt.Errorf("invalid input: %v", err)
+	}
+	return body, nil
+}
+
+// writeOutput writes a handler's output in the lexicon's encoding. JSON
+// output that doesn't match the lexicon is a server bug, so it is logged
+// and reported as a 500 rather than sent.
+func (s *Server) writeOutput(w http.ResponseWriter, m *validation.Method, out interface{}) {
+	if m.Output == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var body io.Reader
+	switch o := out.(type) {
+	case []byte:
+		body = bytes.NewReader(o)
+	case io.Reader:
+		body = o
+	default:
+		b, err := json.Marshal(out)
+		if err != nil {
+			WriteError(w, http.StatusInternalServerError, "InternalServerError", fmt.Sprintf("failed to encode output: %v", err))
+			return
+		}
+		if err := s.lexicons.ValidateBody(m, m.Output, b); err != nil {
+			log.Printf("xrpc: %s output does not match its lexicon: %v", m.NSID, err)
+			WriteError(w, http.StatusInternalServerError, "InternalServerError", "output does not match the lexicon")
+			return
+		}
+		body = bytes.NewReader(b)
+	}
+
+	w.Header().Set("Content-Type", m.Output.Encoding)
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, body)
+}
+
+// httpMethodFor returns the HTTP method a lexicon method is served over
+func httpMethodFor(m *validation.Method) string {
+	if m.Type == validation.MethodQuery {
+		return http.MethodGet
+	}
+	return http.MethodPost
+}
+
+// encodingMatches reports whether a Content-Type satisfies a lexicon
+// encoding, which may be a wildcard such as "*/*" or "image/*"
+func encodingMatches(encoding, contentType string) bool {
+	if encoding == "*/*" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(encoding, "/*"); ok {
+		return strings.HasPrefix(mediaType, prefix+"/")
+	}
+	return mediaType == encoding
+}
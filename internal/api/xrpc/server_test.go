@@ -0,0 +1,203 @@
+package xrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"Coves/internal/api/xrpc"
+	"Coves/internal/validation"
+)
+
+const testDID = "did:plc:test123"
+
+func newTestServer(t *testing.T) *xrpc.Server {
+	lexicons, err := validation.NewLexiconValidator("../../atproto/lexicon", false)
+	if err != nil {
+		t.Fatalf("Failed to load lexicons: %v", err)
+	}
+	return xrpc.NewServer(lexicons)
+}
+
+func serve(server *xrpc.Server, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	return w
+}
+
+func errorName(t *testing.T, w *httptest.ResponseRecorder) string {
+	var body struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected an XRPC error body, got %q", w.Body.String())
+	}
+	return body.Error
+}
+
+func TestServer_Query(t *testing.T) {
+	server := newTestServer(t)
+
+	var got xrpc.Params
+	server.Handle("social.coves.repo.listCommits", func(ctx context.Context, req *xrpc.Request) (interface{}, error) {
+		got = req.Params
+		return map[string]interface{}{"commits": []interface{}{}}, nil
+	})
+
+	w := serve(server, http.MethodGet, "/xrpc/social.coves.repo.listCommits?did="+testDID, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got.String("did") != testDID {
+		t.Errorf("Expected did %q, got %q", testDID, got.String("did"))
+	}
+	if got.Int("limit") != 50 {
+		t.Errorf("Expected the lexicon's default limit of 50, got %v", got["limit"])
+	}
+	if got.Has("cursor") {
+		t.Error("Expected no cursor")
+	}
+
+	for name, tc := range map[string]struct {
+		method, target string
+		status         int
+		errName        string
+	}{
+		"missing required": {http.MethodGet, "/xrpc/social.coves.repo.listCommits", http.StatusBadRequest, "InvalidRequest"},
+		"not an integer":   {http.MethodGet, "/xrpc/social.coves.repo.listCommits?did=" + testDID + "&limit=ten", http.StatusBadRequest, "InvalidRequest"},
+		"out of range":     {http.MethodGet, "/xrpc/social.coves.repo.listCommits?did=" + testDID + "&limit=500", http.StatusBadRequest, "InvalidRequest"},
+		"bad format":       {http.MethodGet, "/xrpc/social.coves.repo.listCommits?did=nope", http.StatusBadRequest, "InvalidRequest"},
+		"wrong method":     {http.MethodPost, "/xrpc/social.coves.repo.listCommits?did=" + testDID, http.StatusMethodNotAllowed, "InvalidRequest"},
+	} {
+		w := serve(server, tc.method, tc.target, "")
+		if w.Code != tc.status {
+			t.Errorf("%s: expected %d, got %d: %s", name, tc.status, w.Code, w.Body.String())
+			continue
+		}
+		if n := errorName(t, w); n != tc.errName {
+			t.Errorf("%s: expected error %s, got %s", name, tc.errName, n)
+		}
+	}
+}
+
+func TestServer_Procedure(t *testing.T) {
+	server := newTestServer(t)
+
+	var output interface{}
+	var handlerErr error
+	server.Handle("social.coves.actor.blockUser", func(ctx context.Context, req *xrpc.Request) (interface{}, error) {
+		var in struct {
+			Subject string `json:"subject"`
+		}
+		if err := json.Unmarshal(req.Input, &in); err != nil {
+			return nil, err
+		}
+		if in.Subject != testDID {
+			t.Errorf("Expected subject %s, got %s", testDID, in.Subject)
+		}
+		return output, handlerErr
+	})
+
+	// A valid call returns the handler's output
+	output = map[string]interface{}{
+		"uri": "at://" + testDID + "/social.coves.actor.block/3k2a4b5c6d7e2",
+		"cid": "bafyreie5737gdxlw5i64vzichcalba3z2v5n6icifvx5xytvske7mr3hpm",
+	}
+	w := serve(server, http.MethodPost, "/xrpc/social.coves.actor.blockUser", `{"subject": "`+testDID+`"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected application/json output, got %q", ct)
+	}
+
+	// Input that doesn't match the lexicon never reaches the handler
+	w = serve(server, http.MethodPost, "/xrpc/social.coves.actor.blockUser", `{"subject": "not-a-did"}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "subject") {
+		t.Errorf("Expected 400 naming subject, got %d: %s", w.Code, w.Body.String())
+	}
+	w = serve(server, http.MethodPost, "/xrpc/social.coves.actor.blockUser", "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for missing input, got %d", w.Code)
+	}
+
+	// Output that doesn't match the lexicon is a server error
+	output = map[string]interface{}{"uri": "at://" + testDID + "/social.coves.actor.block/3k2a4b5c6d7e2"}
+	w = serve(server, http.MethodPost, "/xrpc/social.coves.actor.blockUser", `{"subject": "`+testDID+`"}`)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 for invalid output, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Handlers pick the error the client sees
+	handlerErr = xrpc.Errorf(http.StatusBadRequest, "SubjectNotFound", "no such user")
+	w = serve(server, http.MethodPost, "/xrpc/social.coves.actor.blockUser", `{"subject": "`+testDID+`"}`)
+	if w.Code != http.StatusBadRequest || errorName(t, w) != "SubjectNotFound" {
+		t.Errorf("Expected 400 SubjectNotFound, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestServer_NotImplemented(t *testing.T) {
+	server := newTestServer(t)
+
+	for _, nsid := range []string{"social.coves.actor.getProfile", "social.coves.nope.missing"} {
+		w := serve(server, http.MethodGet, "/xrpc/"+nsid, "")
+		if w.Code != http.StatusNotImplemented {
+			t.Errorf("%s: expected 501, got %d", nsid, w.Code)
+			continue
+		}
+		if n := errorName(t, w); n != "MethodNotImplemented" {
+			t.Errorf("%s: expected MethodNotImplemented, got %s", nsid, n)
+		}
+	}
+}
+
+func TestServer_HandleHTTP(t *testing.T) {
+	server := newTestServer(t)
+
+	// Methods without a loaded lexicon are served as registered
+	server.HandleHTTP(http.MethodGet, "com.atproto.sync.getLatestCommit", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	if w := serve(server, http.MethodGet, "/xrpc/com.atproto.sync.getLatestCommit", ""); w.Code != http.StatusTeapot {
+		t.Errorf("Expected the raw handler to run, got %d", w.Code)
+	}
+
+	// Lexicon methods still have their parameters checked
+	server.HandleHTTP(http.MethodGet, "social.coves.repo.listCommits", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	if w := serve(server, http.MethodGet, "/xrpc/social.coves.repo.listCommits", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a missing parameter, got %d", w.Code)
+	}
+}
+
+func TestServer_RegistrationPanics(t *testing.T) {
+	server := newTestServer(t)
+	noop := func(ctx context.Context, req *xrpc.Request) (interface{}, error) { return nil, nil }
+
+	for name, register := range map[string]func(){
+		"no lexicon":   func() { server.Handle("com.atproto.repo.getRecord", noop) },
+		"not a method": func() { server.Handle("social.coves.post.record", noop) },
+		"wrong verb":   func() { server.HandleHTTP(http.MethodPost, "social.coves.actor.getProfile", nil) },
+		"twice": func() {
+			server.Handle("social.coves.actor.getSaved", noop)
+			server.Handle("social.coves.actor.getSaved", noop)
+		},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected a panic", name)
+				}
+			}()
+			register()
+		}()
+	}
+}
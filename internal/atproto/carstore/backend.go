@@ -0,0 +1,141 @@
+package carstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	coreerrors "Coves/internal/core/errors"
+
+	"github.com/bluesky-social/indigo/carstore"
+	"github.com/bluesky-social/indigo/models"
+	"github.com/ipfs/go-cid"
+	car "github.com/ipld/go-car"
+	"gorm.io/gorm"
+)
+
+// Backend stores repository blocks for numeric UIDs. RepoStore maps DIDs to
+// UIDs and delegates everything else to a Backend.
+type Backend interface {
+	// NewDeltaSession opens a write session on top of a user's head. If
+	// since is non-nil the session is only created when it matches the
+	// current revision.
+	NewDeltaSession(ctx context.Context, uid models.Uid, since *string) (*carstore.DeltaSession, error)
+
+	// ReadOnlySession opens a read-only blockstore view of a user's blocks
+	ReadOnlySession(uid models.Uid) (*carstore.DeltaSession, error)
+
+	// ReadUserCar streams a user's blocks to w as a CAR rooted at their
+	// head. If sinceRev is set only blocks written after that revision are
+	// included. A user without blocks is a NotFoundError.
+	ReadUserCar(ctx context.Context, uid models.Uid, sinceRev string, w io.Writer) error
+
+	// GetUserRepoHead and GetUserRepoRev return the CID and revision of a
+	// user's latest commit, or zero values if they have none
+	GetUserRepoHead(ctx context.Context, uid models.Uid) (cid.Cid, error)
+	GetUserRepoRev(ctx context.Context, uid models.Uid) (string, error)
+
+	// ReloadHead drops anything cached about a user's head, so the next
+	// session starts from the head in storage. Backends that don't cache
+	// heads do nothing.
+	ReloadHead(ctx context.Context, uid models.Uid) error
+
+	// CompactUserShards garbage collects a user's blocks. Backends that
+	// don't shard return empty stats.
+	CompactUserShards(ctx context.Context, uid models.Uid, aggressive bool) (*CompactionStats, error)
+
+	// ShardCount returns how many shards hold a user's blocks; backends
+	// that don't shard return 0
+	ShardCount(ctx context.Context, uid models.Uid) (int, error)
+
+	// DiskUsage returns ShardCount and the bytes stored for a user
+	DiskUsage(ctx context.Context, uid models.Uid) (int, int64, error)
+
+	// SizeAfterWrite returns the bytes stored for a user once a session
+	// that started with before bytes stored has been closed with slice
+	SizeAfterWrite(ctx context.Context, uid models.Uid, before int64, slice []byte) (int64, error)
+
+	// WipeUserData removes all of a user's blocks
+	WipeUserData(ctx context.Context, uid models.Uid) error
+
+	// Close releases what the backend opened itself
+	Close() error
+}
+
+// Backend names for Config
+const (
+	BackendFile   = "file"
+	BackendSQLite = "sqlite"
+	BackendMemory = "memory"
+)
+
+// Config selects and configures the backend for OpenRepoStore
+type Config struct {
+	Backend          string // BackendFile, BackendSQLite or BackendMemory; empty means BackendFile
+	Dir              string // Where the file and SQLite backends keep their data
+	MappingCacheSize int    // DIDs the UserMapping caches; 0 means DefaultMappingCacheSize
+}
+
+// OpenRepoStore creates a RepoStore with the backend cfg selects. The file
+// backend keeps shard metadata and the DID mapping in db, so it needs the
+// carstore schema there; the SQLite and memory backends keep everything in
+// their own SQLite database and ignore db.
+func OpenRepoStore(cfg Config, db *gorm.DB) (*RepoStore, error) {
+	switch cfg.Backend {
+	case BackendFile, "":
+		if cfg.Dir == "" {
+			return nil, fmt.Errorf("file carstore needs a directory")
+		}
+		cs, err := NewCarStore(db, []string{cfg.Dir})
+		if err != nil {
+			return nil, fmt.Errorf("creating carstore: %w", err)
+		}
+		mapping, err := NewUserMapping(db, cfg.MappingCacheSize)
+		if err != nil {
+			return nil, fmt.Errorf("creating user mapping: %w", err)
+		}
+		return NewRepoStoreWithBackend(cs, mapping), nil
+	case BackendSQLite:
+		if cfg.Dir == "" {
+			return nil, fmt.Errorf("sqlite carstore needs a directory")
+		}
+		store, err := NewSQLiteStore(cfg.Dir)
+		if err != nil {
+			return nil, err
+		}
+		return newSQLiteRepoStore(store, cfg.MappingCacheSize)
+	case BackendMemory:
+		store, err := NewMemoryStore()
+		if err != nil {
+			return nil, err
+		}
+		return newSQLiteRepoStore(store, cfg.MappingCacheSize)
+	default:
+		return nil, fmt.Errorf("unknown carstore backend %q", cfg.Backend)
+	}
+}
+
+// newSQLiteRepoStore creates a RepoStore that keeps its DID mapping in the
+// same database as store's blocks
+func newSQLiteRepoStore(store *SQLiteStore, cacheSize int) (*RepoStore, error) {
+	metaDB, err := store.metaDB()
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+	mapping, err := NewUserMapping(metaDB, cacheSize)
+	if err != nil {
+		store.Close()
+		return nil, fmt.Errorf("creating user mapping: %w", err)
+	}
+	return NewRepoStoreWithBackend(store, mapping), nil
+}
+
+// writeEmptyCAR writes a CAR with no blocks rooted at head, which is what a
+// read since the latest revision returns
+func writeEmptyCAR(w io.Writer, uid models.Uid, head cid.Cid) error {
+	if !head.Defined() {
+		return coreerrors.NewNotFoundError(coreerrors.ResourceRepo, uid)
+	}
+	return car.WriteHeader(&car.CarHeader{Roots: []cid.Cid{head}, Version: 1}, w)
+}
@@ -2,8 +2,15 @@ package carstore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+
+	coreerrors "Coves/internal/core/errors"
 
 	"github.com/bluesky-social/indigo/carstore"
 	"github.com/bluesky-social/indigo/models"
@@ -11,9 +18,15 @@ import (
 	"gorm.io/gorm"
 )
 
-// CarStore wraps Indigo's carstore for managing ATProto repository CAR files
+// CarStore is the file Backend. It wraps Indigo's carstore, which writes each
+// commit to a CAR shard file and keeps shard and block metadata in a gorm
+// database, usually Postgres.
 type CarStore struct {
-	cs carstore.CarStore
+	db      *gorm.DB
+	carDirs []string
+
+	mu sync.RWMutex
+	cs carstore.CarStore // Replaced by ReloadHead
 }
 
 // NewCarStore creates a new CarStore instance using Indigo's implementation
@@ -25,22 +38,73 @@ func NewCarStore(db *gorm.DB, carDirs []string) (*CarStore, error) {
 	}
 
 	return &CarStore{
-		cs: cs,
+		db:      db,
+		carDirs: carDirs,
+		cs:      cs,
 	}, nil
 }
 
+// store returns the current Indigo carstore
+func (c *CarStore) store() carstore.CarStore {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cs
+}
+
+// ReloadHead makes the next session read a user's head from the shard
+// metadata. Indigo caches each user's latest shard in memory and only
+// updates it for writes made through it, so once another instance sharing
+// the metadata has written to the repository the cached head is stale.
+// Indigo can't drop a single user's entry, so the whole cache is replaced by
+// a fresh carstore over the same metadata and directories; sessions already
+// open on the old one are unaffected.
+func (c *CarStore) ReloadHead(ctx context.Context, uid models.Uid) error {
+	cs, err := carstore.NewCarStore(c.db, c.carDirs)
+	if err != nil {
+		return fmt.Errorf("reloading carstore for UID %d: %w", uid, err)
+	}
+
+	c.mu.Lock()
+	c.cs = cs
+	c.mu.Unlock()
+	return nil
+}
+
 // ImportSlice imports a CAR file slice for a user
 func (c *CarStore) ImportSlice(ctx context.Context, uid models.Uid, since *string, carData []byte) (cid.Cid, error) {
-	rootCid, _, err := c.cs.ImportSlice(ctx, uid, since, carData)
+	rootCid, _, err := c.store().ImportSlice(ctx, uid, since, carData)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("importing CAR slice for UID %d: %w", uid, err)
 	}
 	return rootCid, nil
 }
 
-// ReadUserCar reads a user's repository CAR file
-func (c *CarStore) ReadUserCar(ctx context.Context, uid models.Uid, sinceRev string, incremental bool, w io.Writer) error {
-	if err := c.cs.ReadUserCar(ctx, uid, sinceRev, incremental, w); err != nil {
+// ReadUserCar streams a user's repository CAR file to w. If sinceRev is set
+// only the shards written after that revision are included.
+func (c *CarStore) ReadUserCar(ctx context.Context, uid models.Uid, sinceRev string, w io.Writer) error {
+	incremental := sinceRev != ""
+	if incremental {
+		// Indigo reads from the shard written at sinceRev, which the caller
+		// already has, so start from the next shard instead
+		next, err := c.NextShardRev(ctx, uid, sinceRev)
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			head, err := c.GetUserRepoHead(ctx, uid)
+			if err != nil {
+				return err
+			}
+			return writeEmptyCAR(w, uid, head)
+		}
+		sinceRev = next
+	}
+
+	if err := c.store().ReadUserCar(ctx, uid, sinceRev, incremental, w); err != nil {
+		// Indigo reports a user without shards only in the error text
+		if strings.HasPrefix(err.Error(), "no data found for user") {
+			err = coreerrors.NewNotFoundError(coreerrors.ResourceRepo, uid)
+		}
 		return fmt.Errorf("reading user CAR for UID %d: %w", uid, err)
 	}
 	return nil
@@ -48,25 +112,48 @@ func (c *CarStore) ReadUserCar(ctx context.Context, uid models.Uid, sinceRev str
 
 // GetUserRepoHead gets the latest repository head CID for a user
 func (c *CarStore) GetUserRepoHead(ctx context.Context, uid models.Uid) (cid.Cid, error) {
-	head, err := c.cs.GetUserRepoHead(ctx, uid)
+	head, err := c.store().GetUserRepoHead(ctx, uid)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("getting repo head for UID %d: %w", uid, err)
 	}
 	return head, nil
 }
 
-// CompactUserShards performs garbage collection and compaction for a user's data
-func (c *CarStore) CompactUserShards(ctx context.Context, uid models.Uid, aggressive bool) error {
-	_, err := c.cs.CompactUserShards(ctx, uid, aggressive)
+// GetUserRepoRev gets the revision of the latest repository commit for a user
+func (c *CarStore) GetUserRepoRev(ctx context.Context, uid models.Uid) (string, error) {
+	rev, err := c.store().GetUserRepoRev(ctx, uid)
 	if err != nil {
-		return fmt.Errorf("compacting shards for UID %d: %w", uid, err)
+		return "", fmt.Errorf("getting repo rev for UID %d: %w", uid, err)
 	}
-	return nil
+	return rev, nil
+}
+
+// CompactionStats describes what a compaction did
+type CompactionStats = carstore.CompactionStats
+
+// CompactUserShards performs garbage collection and compaction for a user's
+// data. Normally the large shards at the start of a history are left alone,
+// since they rarely shrink; an aggressive compaction rewrites every shard.
+func (c *CarStore) CompactUserShards(ctx context.Context, uid models.Uid, aggressive bool) (*CompactionStats, error) {
+	stats, err := c.store().CompactUserShards(ctx, uid, !aggressive)
+	if err != nil {
+		return nil, fmt.Errorf("compacting shards for UID %d: %w", uid, err)
+	}
+	return stats, nil
+}
+
+// ShardCount returns how many shards hold a user's data
+func (c *CarStore) ShardCount(ctx context.Context, uid models.Uid) (int, error) {
+	stats, err := c.Stat(ctx, uid)
+	if err != nil {
+		return 0, err
+	}
+	return len(stats), nil
 }
 
 // WipeUserData removes all data for a user
 func (c *CarStore) WipeUserData(ctx context.Context, uid models.Uid) error {
-	if err := c.cs.WipeUserData(ctx, uid); err != nil {
+	if err := c.store().WipeUserData(ctx, uid); err != nil {
 		return fmt.Errorf("wiping data for UID %d: %w", uid, err)
 	}
 	return nil
@@ -74,7 +161,7 @@ func (c *CarStore) WipeUserData(ctx context.Context, uid models.Uid) error {
 
 // NewDeltaSession creates a new session for writing deltas
 func (c *CarStore) NewDeltaSession(ctx context.Context, uid models.Uid, since *string) (*carstore.DeltaSession, error) {
-	session, err := c.cs.NewDeltaSession(ctx, uid, since)
+	session, err := c.store().NewDeltaSession(ctx, uid, since)
 	if err != nil {
 		return nil, fmt.Errorf("creating delta session for UID %d: %w", uid, err)
 	}
@@ -83,18 +170,78 @@ func (c *CarStore) NewDeltaSession(ctx context.Context, uid models.Uid, since *s
 
 // ReadOnlySession creates a read-only session for reading user data
 func (c *CarStore) ReadOnlySession(uid models.Uid) (*carstore.DeltaSession, error) {
-	session, err := c.cs.ReadOnlySession(uid)
+	session, err := c.store().ReadOnlySession(uid)
 	if err != nil {
 		return nil, fmt.Errorf("creating read-only session for UID %d: %w", uid, err)
 	}
 	return session, nil
 }
 
+// NextShardRev returns the revision of the first shard written after rev, or
+// "" if there is none. Indigo's incremental reads include the shard at the
+// given revision, so this turns an exclusive "since" into an inclusive one.
+func (c *CarStore) NextShardRev(ctx context.Context, uid models.Uid, rev string) (string, error) {
+	var revs []string
+	err := c.db.WithContext(ctx).
+		Model(&carstore.CarShard{}).
+		Where("usr = ? AND rev > ?", uid, rev).
+		Order("rev").
+		Limit(1).
+		Pluck("rev", &revs).Error
+	if err != nil {
+		return "", fmt.Errorf("finding shard after rev %s for UID %d: %w", rev, uid, err)
+	}
+	if len(revs) == 0 {
+		return "", nil
+	}
+	return revs[0], nil
+}
+
+// DiskUsage returns how many shards hold a user's data and their total size
+// on disk. Shards removed by a compaction while they are being counted are
+// skipped.
+func (c *CarStore) DiskUsage(ctx context.Context, uid models.Uid) (int, int64, error) {
+	var paths []string
+	err := c.db.WithContext(ctx).
+		Model(&carstore.CarShard{}).
+		Where("usr = ?", uid).
+		Pluck("path", &paths).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("listing shards for UID %d: %w", uid, err)
+	}
+
+	shards := 0
+	var size int64
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("reading shard for UID %d: %w", uid, err)
+		}
+		shards++
+		size += info.Size()
+	}
+	return shards, size, nil
+}
+
+// SizeAfterWrite adds the slice to before, since each session is written
+// to a new shard file holding the slice byte for byte
+func (c *CarStore) SizeAfterWrite(ctx context.Context, uid models.Uid, before int64, slice []byte) (int64, error) {
+	return before + int64(len(slice)), nil
+}
+
 // Stat returns statistics about the carstore
 func (c *CarStore) Stat(ctx context.Context, uid models.Uid) ([]carstore.UserStat, error) {
-	stats, err := c.cs.Stat(ctx, uid)
+	stats, err := c.store().Stat(ctx, uid)
 	if err != nil {
 		return nil, fmt.Errorf("getting stats for UID %d: %w", uid, err)
 	}
 	return stats, nil
 }
+
+// Close does nothing; the metadata database belongs to the caller
+func (c *CarStore) Close() error {
+	return nil
+}
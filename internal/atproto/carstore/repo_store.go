@@ -1,23 +1,26 @@
 package carstore
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
+	coreerrors "Coves/internal/core/errors"
+
+	"github.com/bluesky-social/indigo/carstore"
 	"github.com/bluesky-social/indigo/models"
 	"github.com/ipfs/go-cid"
 	"gorm.io/gorm"
 )
 
-// RepoStore combines CarStore with UserMapping to provide DID-based repository storage
+// RepoStore combines a Backend with UserMapping to provide DID-based repository storage
 type RepoStore struct {
-	cs      *CarStore
+	backend Backend
 	mapping *UserMapping
 }
 
-// NewRepoStore creates a new RepoStore instance
+// NewRepoStore creates a new RepoStore on the file backend
 func NewRepoStore(db *gorm.DB, carDirs []string) (*RepoStore, error) {
 	// Create carstore
 	cs, err := NewCarStore(db, carDirs)
@@ -26,89 +29,194 @@ func NewRepoStore(db *gorm.DB, carDirs []string) (*RepoStore, error) {
 	}
 
 	// Create user mapping
-	mapping, err := NewUserMapping(db)
+	mapping, err := NewUserMapping(db, DefaultMappingCacheSize)
 	if err != nil {
 		return nil, fmt.Errorf("creating user mapping: %w", err)
 	}
 
+	return NewRepoStoreWithBackend(cs, mapping), nil
+}
+
+// NewRepoStoreWithBackend creates a RepoStore that stores blocks in backend
+// and maps DIDs with mapping
+func NewRepoStoreWithBackend(backend Backend, mapping *UserMapping) *RepoStore {
 	return &RepoStore{
-		cs:      cs,
+		backend: backend,
 		mapping: mapping,
-	}, nil
+	}
 }
 
-// ImportRepo imports a repository CAR file for a DID
-func (rs *RepoStore) ImportRepo(ctx context.Context, did string, carData io.Reader) (cid.Cid, error) {
+// NewImportSession opens a write session for importing a repository CAR,
+// creating the DID's UID mapping if needed. Blocks put into the session are
+// only stored once the caller closes it with the imported commit as root.
+func (rs *RepoStore) NewImportSession(ctx context.Context, did string) (*carstore.DeltaSession, error) {
 	uid, err := rs.mapping.GetOrCreateUID(ctx, did)
+	if err != nil {
+		return nil, fmt.Errorf("getting UID for DID %s: %w", did, err)
+	}
+
+	return rs.backend.NewDeltaSession(ctx, uid, nil)
+}
+
+// ReadRepo streams a repository CAR file for a DID to w. If sinceRev is set
+// only the blocks written after that revision are included; the CAR root is
+// still the current head commit. Writing stops as soon as ctx is cancelled,
+// so an abandoned export doesn't keep copying blocks.
+func (rs *RepoStore) ReadRepo(ctx context.Context, did string, sinceRev string, w io.Writer) error {
+	uid, err := rs.mapping.GetUID(ctx, did)
+	if err != nil {
+		return fmt.Errorf("getting UID for DID %s: %w", did, err)
+	}
+
+	if err := rs.backend.ReadUserCar(ctx, uid, sinceRev, &contextWriter{ctx: ctx, w: w}); err != nil {
+		return fmt.Errorf("reading repo for DID %s: %w", did, err)
+	}
+
+	return nil
+}
+
+// contextWriter fails writes once its context is done
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (cw *contextWriter) Write(p []byte) (int, error) {
+	if err := cw.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cw.w.Write(p)
+}
+
+// GetRepoHead gets the latest repository head CID for a DID
+func (rs *RepoStore) GetRepoHead(ctx context.Context, did string) (cid.Cid, error) {
+	uid, err := rs.mapping.GetUID(ctx, did)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("getting UID for DID %s: %w", did, err)
 	}
 
-	// Read all data from the reader
-	data, err := io.ReadAll(carData)
+	return rs.backend.GetUserRepoHead(ctx, uid)
+}
+
+// GetRepoRev gets the revision of the latest repository commit for a DID
+func (rs *RepoStore) GetRepoRev(ctx context.Context, did string) (string, error) {
+	uid, err := rs.mapping.GetUID(ctx, did)
 	if err != nil {
-		return cid.Undef, fmt.Errorf("reading CAR data: %w", err)
+		return "", fmt.Errorf("getting UID for DID %s: %w", did, err)
 	}
 
-	return rs.cs.ImportSlice(ctx, uid, nil, data)
+	return rs.backend.GetUserRepoRev(ctx, uid)
 }
 
-// ReadRepo reads a repository CAR file for a DID
-func (rs *RepoStore) ReadRepo(ctx context.Context, did string, sinceRev string) ([]byte, error) {
-	uid, err := rs.mapping.GetUID(did)
+// NewDeltaSession opens a write session on top of a DID's current repository head.
+// If since is non-nil the session is only created when it matches the current revision.
+func (rs *RepoStore) NewDeltaSession(ctx context.Context, did string, since *string) (*carstore.DeltaSession, error) {
+	uid, err := rs.mapping.GetUID(ctx, did)
 	if err != nil {
 		return nil, fmt.Errorf("getting UID for DID %s: %w", did, err)
 	}
 
-	var buf bytes.Buffer
-	err = rs.cs.ReadUserCar(ctx, uid, sinceRev, false, &buf)
+	return rs.backend.NewDeltaSession(ctx, uid, since)
+}
+
+// ReloadHead drops anything cached about a DID's repository head, so the
+// next session starts from the head in storage. The DID's UID mapping is
+// read again too, in case the repository was deleted and created again by
+// another instance.
+func (rs *RepoStore) ReloadHead(ctx context.Context, did string) error {
+	uid, err := rs.mapping.ReloadUID(ctx, did)
 	if err != nil {
-		return nil, fmt.Errorf("reading repo for DID %s: %w", did, err)
+		return fmt.Errorf("getting UID for DID %s: %w", did, err)
 	}
 
-	return buf.Bytes(), nil
+	return rs.backend.ReloadHead(ctx, uid)
 }
 
-// GetRepoHead gets the latest repository head CID for a DID
-func (rs *RepoStore) GetRepoHead(ctx context.Context, did string) (cid.Cid, error) {
-	uid, err := rs.mapping.GetUID(did)
+// ReadOnlySession opens a read-only blockstore view of a DID's repository
+func (rs *RepoStore) ReadOnlySession(did string) (*carstore.DeltaSession, error) {
+	uid, err := rs.mapping.GetUID(context.Background(), did)
 	if err != nil {
-		return cid.Undef, fmt.Errorf("getting UID for DID %s: %w", did, err)
+		return nil, fmt.Errorf("getting UID for DID %s: %w", did, err)
 	}
 
-	return rs.cs.GetUserRepoHead(ctx, uid)
+	return rs.backend.ReadOnlySession(uid)
 }
 
-// CompactRepo performs garbage collection for a DID's repository
-func (rs *RepoStore) CompactRepo(ctx context.Context, did string) error {
-	uid, err := rs.mapping.GetUID(did)
+// CompactRepo performs garbage collection for a DID's repository. See
+// CarStore.CompactUserShards for what aggressive does; backends that don't
+// shard have nothing to do.
+func (rs *RepoStore) CompactRepo(ctx context.Context, did string, aggressive bool) (*CompactionStats, error) {
+	uid, err := rs.mapping.GetUID(ctx, did)
 	if err != nil {
-		return fmt.Errorf("getting UID for DID %s: %w", did, err)
+		return nil, fmt.Errorf("getting UID for DID %s: %w", did, err)
+	}
+
+	return rs.backend.CompactUserShards(ctx, uid, aggressive)
+}
+
+// ShardCount returns how many CAR shards hold a DID's repository. On the file
+// backend every commit adds one until the repository is compacted.
+func (rs *RepoStore) ShardCount(ctx context.Context, did string) (int, error) {
+	uid, err := rs.mapping.GetUID(ctx, did)
+	if err != nil {
+		return 0, fmt.Errorf("getting UID for DID %s: %w", did, err)
+	}
+
+	return rs.backend.ShardCount(ctx, uid)
+}
+
+// DiskUsage returns how many shards hold a DID's repository and their total
+// size on disk
+func (rs *RepoStore) DiskUsage(ctx context.Context, did string) (int, int64, error) {
+	uid, err := rs.mapping.GetUID(ctx, did)
+	if err != nil {
+		return 0, 0, fmt.Errorf("getting UID for DID %s: %w", did, err)
+	}
+
+	return rs.backend.DiskUsage(ctx, uid)
+}
+
+// SizeAfterWrite returns the bytes stored for a DID's repository once a
+// session that started with before bytes stored has been closed with slice
+func (rs *RepoStore) SizeAfterWrite(ctx context.Context, did string, before int64, slice []byte) (int64, error) {
+	uid, err := rs.mapping.GetUID(ctx, did)
+	if err != nil {
+		return 0, fmt.Errorf("getting UID for DID %s: %w", did, err)
 	}
 
-	return rs.cs.CompactUserShards(ctx, uid, false)
+	return rs.backend.SizeAfterWrite(ctx, uid, before, slice)
 }
 
-// DeleteRepo removes all data for a DID's repository
+// DeleteRepo removes all data for a DID's repository. The DID's UID mapping
+// goes too, so a repository created for it later starts from a fresh UID
+// that no backend has cached anything for.
 func (rs *RepoStore) DeleteRepo(ctx context.Context, did string) error {
-	uid, err := rs.mapping.GetUID(did)
+	uid, err := rs.mapping.GetUID(ctx, did)
 	if err != nil {
 		return fmt.Errorf("getting UID for DID %s: %w", did, err)
 	}
 
-	return rs.cs.WipeUserData(ctx, uid)
+	if err := rs.backend.WipeUserData(ctx, uid); err != nil {
+		return err
+	}
+	return rs.mapping.DeleteUID(ctx, did)
 }
 
-// HasRepo checks if a repository exists for a DID
+// HasRepo checks if a repository exists for a DID. The mapping is read
+// through to the database, so a repository created by another instance is
+// found too.
 func (rs *RepoStore) HasRepo(ctx context.Context, did string) (bool, error) {
-	uid, err := rs.mapping.GetUID(did)
-	if err != nil {
+	uid, err := rs.mapping.GetUID(ctx, did)
+	if errors.Is(err, coreerrors.ErrNotFound) {
 		// If no UID mapping exists, repo doesn't exist
 		return false, nil
 	}
+	if err != nil {
+		return false, fmt.Errorf("getting UID for DID %s: %w", did, err)
+	}
 
 	// Try to get the repo head
-	head, err := rs.cs.GetUserRepoHead(ctx, uid)
+	head, err := rs.backend.GetUserRepoHead(ctx, uid)
 	if err != nil {
 		return false, nil
 	}
@@ -120,3 +228,8 @@ func (rs *RepoStore) HasRepo(ctx context.Context, did string) (bool, error) {
 func (rs *RepoStore) GetOrCreateUID(ctx context.Context, did string) (models.Uid, error) {
 	return rs.mapping.GetOrCreateUID(ctx, did)
 }
+
+// Close closes the backend
+func (rs *RepoStore) Close() error {
+	return rs.backend.Close()
+}
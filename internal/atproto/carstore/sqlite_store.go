@@ -0,0 +1,204 @@
+package carstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/bluesky-social/indigo/carstore"
+	"github.com/bluesky-social/indigo/models"
+	"github.com/ipfs/go-cid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// sqliteParams are added to every SQLite DSN. Indigo's store and ours each
+// keep a connection pool on the database, so a writer waits for the other
+// instead of failing.
+const sqliteParams = "_busy_timeout=10000"
+
+// memoryStores numbers in-memory databases so each store gets its own
+var memoryStores atomic.Int64
+
+// SQLiteStore is a Backend that keeps every repository's blocks in one
+// SQLite database using Indigo's SQLite carstore. Blocks are stored once per
+// repository and overwritten in place, so there are no shards to compact.
+// It needs no Postgres schema, which suits local development and tests.
+type SQLiteStore struct {
+	cs   *carstore.SQLiteStore
+	db   *sql.DB   // Our own pool, for the queries Indigo's store gets wrong or lacks
+	keep *sql.Conn // Holds an in-memory database open; nil for files
+}
+
+// NewSQLiteStore opens or creates a SQLite carstore in dir
+func NewSQLiteStore(dir string) (*SQLiteStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating carstore directory: %w", err)
+	}
+	path := filepath.Join(dir, "carstore.sqlite3")
+	return openSQLiteStore("file:"+path+"?"+sqliteParams+"&_journal_mode=WAL", false)
+}
+
+// NewMemoryStore creates an empty carstore held in memory. Each store is a
+// separate database, discarded when it is closed.
+func NewMemoryStore() (*SQLiteStore, error) {
+	dsn := fmt.Sprintf("file:/coves-carstore-%d?vfs=memdb&%s", memoryStores.Add(1), sqliteParams)
+	return openSQLiteStore(dsn, true)
+}
+
+func openSQLiteStore(dsn string, memory bool) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite carstore: %w", err)
+	}
+	s := &SQLiteStore{db: db}
+
+	if memory {
+		// An in-memory database is dropped when its last connection closes
+		if s.keep, err = db.Conn(context.Background()); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("opening sqlite carstore: %w", err)
+		}
+	}
+
+	cs := &carstore.SQLiteStore{}
+	if err := cs.Open(dsn); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("opening sqlite carstore: %w", err)
+	}
+	s.cs = cs
+	return s, nil
+}
+
+// metaDB opens a gorm handle on the store's database for the DID mapping
+func (s *SQLiteStore) metaDB() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Dialector{Conn: s.db}, &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite carstore metadata: %w", err)
+	}
+	return db, nil
+}
+
+// NewDeltaSession opens a write session on top of a user's head
+func (s *SQLiteStore) NewDeltaSession(ctx context.Context, uid models.Uid, since *string) (*carstore.DeltaSession, error) {
+	session, err := s.cs.NewDeltaSession(ctx, uid, since)
+	if err != nil {
+		return nil, fmt.Errorf("creating delta session for UID %d: %w", uid, err)
+	}
+	return session, nil
+}
+
+// ReadOnlySession creates a read-only session for reading user data
+func (s *SQLiteStore) ReadOnlySession(uid models.Uid) (*carstore.DeltaSession, error) {
+	session, err := s.cs.ReadOnlySession(uid)
+	if err != nil {
+		return nil, fmt.Errorf("creating read-only session for UID %d: %w", uid, err)
+	}
+	return session, nil
+}
+
+// ReadUserCar streams a user's blocks to w, only those written after
+// sinceRev if it is set
+func (s *SQLiteStore) ReadUserCar(ctx context.Context, uid models.Uid, sinceRev string, w io.Writer) error {
+	rev, head, err := s.head(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if !head.Defined() || (sinceRev != "" && rev <= sinceRev) {
+		// Indigo writes nothing at all when there are no blocks to send
+		return writeEmptyCAR(w, uid, head)
+	}
+
+	// Indigo already reads only the blocks after sinceRev
+	if err := s.cs.ReadUserCar(ctx, uid, sinceRev, sinceRev != "", w); err != nil {
+		return fmt.Errorf("reading user CAR for UID %d: %w", uid, err)
+	}
+	return nil
+}
+
+// GetUserRepoHead gets the latest repository head CID for a user
+func (s *SQLiteStore) GetUserRepoHead(ctx context.Context, uid models.Uid) (cid.Cid, error) {
+	_, head, err := s.head(ctx, uid)
+	return head, err
+}
+
+// GetUserRepoRev gets the revision of the latest repository commit for a user
+func (s *SQLiteStore) GetUserRepoRev(ctx context.Context, uid models.Uid) (string, error) {
+	rev, _, err := s.head(ctx, uid)
+	return rev, err
+}
+
+// ReloadHead does nothing, since heads are always read from the database
+func (s *SQLiteStore) ReloadHead(ctx context.Context, uid models.Uid) error {
+	return nil
+}
+
+// head returns the revision and root of a user's latest commit. Indigo's
+// store reports every head as empty, since its shards have no IDs.
+func (s *SQLiteStore) head(ctx context.Context, uid models.Uid) (string, cid.Cid, error) {
+	var rev string
+	var root models.DbCID
+	err := s.db.QueryRowContext(ctx, "SELECT rev, root FROM blocks WHERE uid = ? ORDER BY rev DESC LIMIT 1", uid).Scan(&rev, &root)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", cid.Undef, nil
+	}
+	if err != nil {
+		return "", cid.Undef, fmt.Errorf("getting repo head for UID %d: %w", uid, err)
+	}
+	return rev, root.CID, nil
+}
+
+// CompactUserShards does nothing, since blocks aren't sharded
+func (s *SQLiteStore) CompactUserShards(ctx context.Context, uid models.Uid, aggressive bool) (*CompactionStats, error) {
+	return &CompactionStats{}, nil
+}
+
+// ShardCount returns 0, since blocks aren't sharded
+func (s *SQLiteStore) ShardCount(ctx context.Context, uid models.Uid) (int, error) {
+	return 0, nil
+}
+
+// DiskUsage returns the total size of a user's blocks
+func (s *SQLiteStore) DiskUsage(ctx context.Context, uid models.Uid) (int, int64, error) {
+	var size int64
+	err := s.db.QueryRowContext(ctx, "SELECT COALESCE(SUM(LENGTH(block)), 0) FROM blocks WHERE uid = ?", uid).Scan(&size)
+	if err != nil {
+		return 0, 0, fmt.Errorf("measuring blocks for UID %d: %w", uid, err)
+	}
+	return 0, size, nil
+}
+
+// SizeAfterWrite measures the user's blocks again. Blocks the slice shares
+// with earlier writes are stored once, and the slice's CAR framing isn't
+// stored at all, so its length says little about what it added.
+func (s *SQLiteStore) SizeAfterWrite(ctx context.Context, uid models.Uid, before int64, slice []byte) (int64, error) {
+	_, size, err := s.DiskUsage(ctx, uid)
+	return size, err
+}
+
+// WipeUserData removes all data for a user. Indigo's store keeps caching the
+// user's last head afterwards, so the UID mustn't be reused.
+func (s *SQLiteStore) WipeUserData(ctx context.Context, uid models.Uid) error {
+	if err := s.cs.WipeUserData(ctx, uid); err != nil {
+		return fmt.Errorf("wiping data for UID %d: %w", uid, err)
+	}
+	return nil
+}
+
+// Close closes the database; an in-memory store's data is discarded
+func (s *SQLiteStore) Close() error {
+	var errs []error
+	if s.cs != nil {
+		errs = append(errs, s.cs.Close())
+	}
+	if s.keep != nil {
+		errs = append(errs, s.keep.Close())
+	}
+	errs = append(errs, s.db.Close())
+	return errors.Join(errs...)
+}
@@ -3,19 +3,38 @@ package carstore
 import (
 	"context"
 	"fmt"
-	"sync"
+	"time"
+
+	coreerrors "Coves/internal/core/errors"
 
 	"github.com/bluesky-social/indigo/models"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// UserMapping manages the mapping between DIDs and numeric UIDs required by Indigo's carstore
+// DefaultMappingCacheSize is how many DIDs a UserMapping caches by default
+const DefaultMappingCacheSize = 100000
+
+// mappingTTL is how long a cached DID is trusted before it is checked
+// against the database again. Mappings only change when a repository is
+// deleted, and other instances see that once their entry expires.
+const mappingTTL = 5 * time.Minute
+
+// UserMapping manages the mapping between DIDs and numeric UIDs required by
+// Indigo's carstore. The database is the source of truth, so every instance
+// sees the UIDs the others create; recently used mappings are cached in a
+// bounded LRU.
 type UserMapping struct {
-	db       *gorm.DB
-	mu       sync.RWMutex
-	didToUID map[string]models.Uid
-	uidToDID map[models.Uid]string
-	nextUID  models.Uid
+	db   *gorm.DB
+	dids *lru.Cache[string, cachedUID]
+	uids *lru.Cache[models.Uid, string]
+}
+
+// cachedUID is a cached DID mapping and when it was read
+type cachedUID struct {
+	uid      models.Uid
+	loadedAt time.Time
 }
 
 // UserMap represents the database model for DID to UID mapping
@@ -26,102 +45,163 @@ type UserMap struct {
 	UpdatedAt int64
 }
 
-// NewUserMapping creates a new UserMapping instance
-func NewUserMapping(db *gorm.DB) (*UserMapping, error) {
+// NewUserMapping creates a new UserMapping instance caching up to cacheSize
+// DIDs, or DefaultMappingCacheSize if cacheSize isn't positive
+func NewUserMapping(db *gorm.DB, cacheSize int) (*UserMapping, error) {
 	// Auto-migrate the user mapping table
 	if err := db.AutoMigrate(&UserMap{}); err != nil {
 		return nil, fmt.Errorf("migrating user mapping table: %w", err)
 	}
 
-	um := &UserMapping{
-		db:       db,
-		didToUID: make(map[string]models.Uid),
-		uidToDID: make(map[models.Uid]string),
-		nextUID:  1,
+	if cacheSize <= 0 {
+		cacheSize = DefaultMappingCacheSize
 	}
-
-	// Load existing mappings
-	if err := um.loadMappings(); err != nil {
-		return nil, fmt.Errorf("loading user mappings: %w", err)
+	dids, err := lru.New[string, cachedUID](cacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("creating user mapping cache: %w", err)
+	}
+	uids, err := lru.New[models.Uid, string](cacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("creating user mapping cache: %w", err)
 	}
 
-	return um, nil
+	return &UserMapping{
+		db:   db,
+		dids: dids,
+		uids: uids,
+	}, nil
 }
 
-// loadMappings loads all existing DID to UID mappings from the database
-func (um *UserMapping) loadMappings() error {
-	var mappings []UserMap
-	if err := um.db.Find(&mappings).Error; err != nil {
-		return fmt.Errorf("querying user mappings: %w", err)
+// GetOrCreateUID gets or creates a UID for a given DID. Creation is an
+// upsert on the DID, so instances creating the same DID at once agree on
+// its UID. It is used to start new repositories, so it always reads the
+// database: a cached UID may belong to a repository that another instance
+// has since deleted.
+func (um *UserMapping) GetOrCreateUID(ctx context.Context, did string) (models.Uid, error) {
+	uid, err := um.load(ctx, did)
+	if err != nil || uid != 0 {
+		return uid, err
 	}
 
-	um.mu.Lock()
-	defer um.mu.Unlock()
-
-	for _, m := range mappings {
-		um.didToUID[m.DID] = m.UID
-		um.uidToDID[m.UID] = m.DID
-		if m.UID >= um.nextUID {
-			um.nextUID = m.UID + 1
-		}
+	userMap := &UserMap{
+		DID: did,
+	}
+	err = um.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "did"}}, DoNothing: true}).
+		Create(userMap).Error
+	if err != nil {
+		return 0, fmt.Errorf("creating user mapping for DID %s: %w", did, err)
 	}
 
-	return nil
+	// Read the row back, since another instance may have created it first
+	uid, err = um.load(ctx, did)
+	if err != nil {
+		return 0, err
+	}
+	if uid == 0 {
+		return 0, fmt.Errorf("creating user mapping for DID %s: row missing after insert", did)
+	}
+	return uid, nil
 }
 
-// GetOrCreateUID gets or creates a UID for a given DID
-func (um *UserMapping) GetOrCreateUID(ctx context.Context, did string) (models.Uid, error) {
-	um.mu.RLock()
-	if uid, exists := um.didToUID[did]; exists {
-		um.mu.RUnlock()
+// GetUID returns the UID for a DID, or a NotFoundError if it has none
+func (um *UserMapping) GetUID(ctx context.Context, did string) (models.Uid, error) {
+	if uid, ok := um.cached(did); ok {
 		return uid, nil
 	}
-	um.mu.RUnlock()
 
-	// Need to create a new mapping
-	um.mu.Lock()
-	defer um.mu.Unlock()
+	uid, err := um.load(ctx, did)
+	if err != nil {
+		return 0, err
+	}
+	if uid == 0 {
+		return 0, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	return uid, nil
+}
 
-	// Double-check in case another goroutine created it
-	if uid, exists := um.didToUID[did]; exists {
-		return uid, nil
+// ReloadUID reads the UID for a DID from the database, replacing any cached
+// mapping, or returns a NotFoundError if it has none
+func (um *UserMapping) ReloadUID(ctx context.Context, did string) (models.Uid, error) {
+	uid, err := um.load(ctx, did)
+	if err != nil {
+		return 0, err
+	}
+	if uid == 0 {
+		return 0, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
 	}
+	return uid, nil
+}
 
-	// Create new mapping
-	userMap := &UserMap{
-		DID: did,
+// GetDID returns the DID for a UID, or a NotFoundError if it has none
+func (um *UserMapping) GetDID(ctx context.Context, uid models.Uid) (string, error) {
+	if did, ok := um.uids.Get(uid); ok {
+		return did, nil
 	}
 
-	if err := um.db.Create(userMap).Error; err != nil {
-		return 0, fmt.Errorf("creating user mapping for DID %s: %w", did, err)
+	var userMap UserMap
+	result := um.db.WithContext(ctx).Where("uid = ?", uid).Limit(1).Find(&userMap)
+	if result.Error != nil {
+		return "", fmt.Errorf("getting user mapping for UID %d: %w", uid, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return "", coreerrors.NewNotFoundError(coreerrors.ResourceRepo, uid)
 	}
 
-	um.didToUID[did] = userMap.UID
-	um.uidToDID[userMap.UID] = did
+	um.store(userMap.DID, userMap.UID)
+	return userMap.DID, nil
+}
 
-	return userMap.UID, nil
+// DeleteUID removes the mapping for a DID. The UID isn't handed out again.
+// Other instances keep their cached mapping until it expires, but creating
+// a repository and reloading its head read the database, so nothing is
+// written under the deleted UID.
+func (um *UserMapping) DeleteUID(ctx context.Context, did string) error {
+	if err := um.db.WithContext(ctx).Where("did = ?", did).Delete(&UserMap{}).Error; err != nil {
+		return fmt.Errorf("deleting user mapping for DID %s: %w", did, err)
+	}
+
+	um.forget(did)
+	return nil
 }
 
-// GetUID returns the UID for a DID, or an error if not found
-func (um *UserMapping) GetUID(did string) (models.Uid, error) {
-	um.mu.RLock()
-	defer um.mu.RUnlock()
+// cached returns a DID's UID if it was read recently enough to trust
+func (um *UserMapping) cached(did string) (models.Uid, bool) {
+	entry, ok := um.dids.Get(did)
+	if !ok || time.Since(entry.loadedAt) > mappingTTL {
+		return 0, false
+	}
+	return entry.uid, true
+}
 
-	uid, exists := um.didToUID[did]
-	if !exists {
-		return 0, fmt.Errorf("UID not found for DID: %s", did)
+// load reads a DID's UID from the database and caches it. It returns 0 if
+// the DID has no mapping, which isn't cached so UIDs created by other
+// instances are seen straight away.
+func (um *UserMapping) load(ctx context.Context, did string) (models.Uid, error) {
+	var userMap UserMap
+	result := um.db.WithContext(ctx).Where("did = ?", did).Limit(1).Find(&userMap)
+	if result.Error != nil {
+		return 0, fmt.Errorf("getting user mapping for DID %s: %w", did, result.Error)
 	}
-	return uid, nil
+	if result.RowsAffected == 0 {
+		um.forget(did)
+		return 0, nil
+	}
+
+	um.store(userMap.DID, userMap.UID)
+	return userMap.UID, nil
 }
 
-// GetDID returns the DID for a UID, or an error if not found
-func (um *UserMapping) GetDID(uid models.Uid) (string, error) {
-	um.mu.RLock()
-	defer um.mu.RUnlock()
+// store caches a mapping in both directions
+func (um *UserMapping) store(did string, uid models.Uid) {
+	um.dids.Add(did, cachedUID{uid: uid, loadedAt: time.Now()})
+	um.uids.Add(uid, did)
+}
 
-	did, exists := um.uidToDID[uid]
-	if !exists {
-		return "", fmt.Errorf("DID not found for UID: %d", uid)
+// forget drops a DID's cached mapping in both directions
+func (um *UserMapping) forget(did string) {
+	if entry, ok := um.dids.Peek(did); ok {
+		um.uids.Remove(entry.uid)
 	}
-	return did, nil
+	um.dids.Remove(did)
 }
@@ -0,0 +1,28 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.actor.block
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.actor.block", &ActorBlock{})
+}
+
+// ActorBlock is a "main" in the social.coves.actor.block schema.
+//
+// # A block relationship where one user blocks another
+//
+// RECORDTYPE: ActorBlock
+type ActorBlock struct {
+	LexiconTypeID string `json:"$type,const=social.coves.actor.block" cborgen:"$type,const=social.coves.actor.block"`
+	// createdAt: When the block was created
+	CreatedAt string `json:"createdAt" cborgen:"createdAt"`
+	// reason: Optional reason for blocking
+	Reason *string `json:"reason,omitempty" cborgen:"reason,omitempty"`
+	// subject: DID of the user being blocked
+	Subject string `json:"subject" cborgen:"subject"`
+}
@@ -0,0 +1,30 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.actor.membership
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.actor.membership", &ActorMembership{})
+}
+
+// ActorMembership is a "main" in the social.coves.actor.membership schema.
+//
+// # Membership in a community
+//
+// RECORDTYPE: ActorMembership
+type ActorMembership struct {
+	LexiconTypeID string `json:"$type,const=social.coves.actor.membership" cborgen:"$type,const=social.coves.actor.membership"`
+	// community: DID or handle of the community
+	Community string `json:"community" cborgen:"community"`
+	// createdAt: When the user's membership started
+	CreatedAt string `json:"createdAt" cborgen:"createdAt"`
+	// endedAt: When the membership ended (null if current)
+	EndedAt *string `json:"endedAt,omitempty" cborgen:"endedAt,omitempty"`
+	// reputation: Reputation score within the community
+	Reputation int64 `json:"reputation" cborgen:"reputation"`
+}
@@ -0,0 +1,91 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.actor.preferences
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.actor.preferences", &ActorPreferences{})
+}
+
+// ActorPreferences is a "main" in the social.coves.actor.preferences schema.
+//
+// # User preferences and settings
+//
+// RECORDTYPE: ActorPreferences
+type ActorPreferences struct {
+	LexiconTypeID        string                                 `json:"$type,const=social.coves.actor.preferences" cborgen:"$type,const=social.coves.actor.preferences"`
+	ContentFiltering     *ActorPreferences_ContentFiltering     `json:"contentFiltering,omitempty" cborgen:"contentFiltering,omitempty"`
+	DisplayPreferences   *ActorPreferences_DisplayPreferences   `json:"displayPreferences,omitempty" cborgen:"displayPreferences,omitempty"`
+	FeedPreferences      *ActorPreferences_FeedPreferences      `json:"feedPreferences,omitempty" cborgen:"feedPreferences,omitempty"`
+	NotificationSettings *ActorPreferences_NotificationSettings `json:"notificationSettings,omitempty" cborgen:"notificationSettings,omitempty"`
+	PrivacySettings      *ActorPreferences_PrivacySettings      `json:"privacySettings,omitempty" cborgen:"privacySettings,omitempty"`
+}
+
+// ActorPreferences_ContentFiltering is a "contentFiltering" in the social.coves.actor.preferences schema.
+//
+// Content filtering preferences
+type ActorPreferences_ContentFiltering struct {
+	// blockedCommunities: Communities to filter out from /all feeds
+	BlockedCommunities []string `json:"blockedCommunities,omitempty" cborgen:"blockedCommunities,omitempty"`
+	// blockedTags: Tags to filter out from feeds
+	BlockedTags []string `json:"blockedTags,omitempty" cborgen:"blockedTags,omitempty"`
+	// languageFilter: Only show content in these languages
+	LanguageFilter []string `json:"languageFilter,omitempty" cborgen:"languageFilter,omitempty"`
+	// mutedWords: Words to filter out from content
+	MutedWords []string `json:"mutedWords,omitempty" cborgen:"mutedWords,omitempty"`
+}
+
+// ActorPreferences_DisplayPreferences is a "displayPreferences" in the social.coves.actor.preferences schema.
+//
+// Display and UI preferences
+type ActorPreferences_DisplayPreferences struct {
+	CompactView    *bool   `json:"compactView,omitempty" cborgen:"compactView,omitempty"`
+	PostsPerPage   *int64  `json:"postsPerPage,omitempty" cborgen:"postsPerPage,omitempty"`
+	ShowAvatars    *bool   `json:"showAvatars,omitempty" cborgen:"showAvatars,omitempty"`
+	ShowThumbnails *bool   `json:"showThumbnails,omitempty" cborgen:"showThumbnails,omitempty"`
+	Theme          *string `json:"theme,omitempty" cborgen:"theme,omitempty"`
+}
+
+// ActorPreferences_FeedPreferences is a "feedPreferences" in the social.coves.actor.preferences schema.
+//
+// Feed and content preferences
+type ActorPreferences_FeedPreferences struct {
+	AutoplayVideos *bool `json:"autoplayVideos,omitempty" cborgen:"autoplayVideos,omitempty"`
+	// blurNSFW: Blur NSFW content until clicked
+	BlurNSFW    *bool   `json:"blurNSFW,omitempty" cborgen:"blurNSFW,omitempty"`
+	DefaultFeed *string `json:"defaultFeed,omitempty" cborgen:"defaultFeed,omitempty"`
+	// defaultSort: Default sort order for community feeds
+	DefaultSort    *string `json:"defaultSort,omitempty" cborgen:"defaultSort,omitempty"`
+	InfiniteScroll *bool   `json:"infiniteScroll,omitempty" cborgen:"infiniteScroll,omitempty"`
+	ShowNSFW       *bool   `json:"showNSFW,omitempty" cborgen:"showNSFW,omitempty"`
+}
+
+// ActorPreferences_NotificationSettings is a "notificationSettings" in the social.coves.actor.preferences schema.
+//
+// Notification preferences
+type ActorPreferences_NotificationSettings struct {
+	CommentReplies   *bool `json:"commentReplies,omitempty" cborgen:"commentReplies,omitempty"`
+	CommunityInvites *bool `json:"communityInvites,omitempty" cborgen:"communityInvites,omitempty"`
+	Mentions         *bool `json:"mentions,omitempty" cborgen:"mentions,omitempty"`
+	// moderatorNotifications: Notifications for moderator actions in your communities
+	ModeratorNotifications *bool `json:"moderatorNotifications,omitempty" cborgen:"moderatorNotifications,omitempty"`
+	NewFollowers           *bool `json:"newFollowers,omitempty" cborgen:"newFollowers,omitempty"`
+	PostReplies            *bool `json:"postReplies,omitempty" cborgen:"postReplies,omitempty"`
+	Upvotes                *bool `json:"upvotes,omitempty" cborgen:"upvotes,omitempty"`
+}
+
+// ActorPreferences_PrivacySettings is a "privacySettings" in the social.coves.actor.preferences schema.
+//
+// Privacy preferences
+type ActorPreferences_PrivacySettings struct {
+	AllowDMs          *string `json:"allowDMs,omitempty" cborgen:"allowDMs,omitempty"`
+	ProfileVisibility *string `json:"profileVisibility,omitempty" cborgen:"profileVisibility,omitempty"`
+	ShowSavedPosts    *bool   `json:"showSavedPosts,omitempty" cborgen:"showSavedPosts,omitempty"`
+	ShowSubscriptions *bool   `json:"showSubscriptions,omitempty" cborgen:"showSubscriptions,omitempty"`
+	ShowVoteHistory   *bool   `json:"showVoteHistory,omitempty" cborgen:"showVoteHistory,omitempty"`
+}
@@ -0,0 +1,106 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.actor.profile
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.actor.profile", &ActorProfile{})
+}
+
+// ActorProfile is a "main" in the social.coves.actor.profile schema.
+//
+// # A user's profile information
+//
+// RECORDTYPE: ActorProfile
+type ActorProfile struct {
+	LexiconTypeID string        `json:"$type,const=social.coves.actor.profile" cborgen:"$type,const=social.coves.actor.profile"`
+	Avatar        *util.LexBlob `json:"avatar,omitempty" cborgen:"avatar,omitempty"`
+	Banner        *util.LexBlob `json:"banner,omitempty" cborgen:"banner,omitempty"`
+	// bio: User bio with rich text support
+	Bio *string `json:"bio,omitempty" cborgen:"bio,omitempty"`
+	// bioFacets: Rich text annotations for bio
+	BioFacets []*RichtextFacet `json:"bioFacets,omitempty" cborgen:"bioFacets,omitempty"`
+	CreatedAt string           `json:"createdAt" cborgen:"createdAt"`
+	// displayName: Optional display name
+	DisplayName *string `json:"displayName,omitempty" cborgen:"displayName,omitempty"`
+	// federatedFrom: Platform user federated from
+	FederatedFrom *string `json:"federatedFrom,omitempty" cborgen:"federatedFrom,omitempty"`
+	// federatedIdentity: Identity information from federated platform
+	FederatedIdentity *ActorProfile_FederatedIdentity `json:"federatedIdentity,omitempty" cborgen:"federatedIdentity,omitempty"`
+	// handle: User's handle
+	Handle   string                    `json:"handle" cborgen:"handle"`
+	Location *ActorProfile_GeoLocation `json:"location,omitempty" cborgen:"location,omitempty"`
+	// moderatedCommunities: Communities the user currently moderates
+	ModeratedCommunities []string `json:"moderatedCommunities,omitempty" cborgen:"moderatedCommunities,omitempty"`
+	// moderationHistory: Historical record of all moderation roles
+	ModerationHistory []*ActorProfile_ModerationRole `json:"moderationHistory,omitempty" cborgen:"moderationHistory,omitempty"`
+	// verificationExpiresAt: When verification expires
+	VerificationExpiresAt *string `json:"verificationExpiresAt,omitempty" cborgen:"verificationExpiresAt,omitempty"`
+	// verified: Whether the user has completed phone verification
+	Verified *bool `json:"verified,omitempty" cborgen:"verified,omitempty"`
+	// verifiedAt: When the user was verified
+	VerifiedAt *string `json:"verifiedAt,omitempty" cborgen:"verifiedAt,omitempty"`
+	// violations: Record of rule violations across communities
+	Violations []*ActorProfile_Violation `json:"violations,omitempty" cborgen:"violations,omitempty"`
+}
+
+// ActorProfile_FederatedIdentity is a "federatedIdentity" in the social.coves.actor.profile schema.
+//
+// Verified identity from a federated platform
+type ActorProfile_FederatedIdentity struct {
+	// did: Original DID from the federated platform
+	Did string `json:"did" cborgen:"did"`
+	// handle: Original handle from the federated platform
+	Handle string `json:"handle" cborgen:"handle"`
+	// homePDS: Home PDS server URL for the federated account
+	HomePDS *string `json:"homePDS,omitempty" cborgen:"homePDS,omitempty"`
+	// lastSyncedAt: Last time profile data was synced from the federated platform
+	LastSyncedAt *string `json:"lastSyncedAt,omitempty" cborgen:"lastSyncedAt,omitempty"`
+	// verifiedAt: When the federated identity was verified via OAuth
+	VerifiedAt string `json:"verifiedAt" cborgen:"verifiedAt"`
+}
+
+// ActorProfile_GeoLocation is a "geoLocation" in the social.coves.actor.profile schema.
+//
+// Geographic location information
+type ActorProfile_GeoLocation struct {
+	// country: ISO 3166-1 alpha-2 country code
+	Country *string `json:"country,omitempty" cborgen:"country,omitempty"`
+	// displayName: Human-readable location name
+	DisplayName *string `json:"displayName,omitempty" cborgen:"displayName,omitempty"`
+	// region: State/province/region name
+	Region *string `json:"region,omitempty" cborgen:"region,omitempty"`
+}
+
+// ActorProfile_ModerationRole is a "moderationRole" in the social.coves.actor.profile schema.
+type ActorProfile_ModerationRole struct {
+	// communityDid: Community where moderation role was held
+	CommunityDid string `json:"communityDid" cborgen:"communityDid"`
+	// endedAt: When the role ended (null if current)
+	EndedAt *string `json:"endedAt,omitempty" cborgen:"endedAt,omitempty"`
+	// role: Type of moderation role
+	Role string `json:"role" cborgen:"role"`
+	// startedAt: When the role began
+	StartedAt string `json:"startedAt" cborgen:"startedAt"`
+}
+
+// ActorProfile_Violation is a "violation" in the social.coves.actor.profile schema.
+type ActorProfile_Violation struct {
+	// communityDid: Community where violation occurred
+	CommunityDid string `json:"communityDid" cborgen:"communityDid"`
+	// postUri: Optional reference to the violating content
+	PostUri *string `json:"postUri,omitempty" cborgen:"postUri,omitempty"`
+	// resolution: How the violation was resolved
+	Resolution *string `json:"resolution,omitempty" cborgen:"resolution,omitempty"`
+	// ruleViolated: Description of the rule that was violated
+	RuleViolated string `json:"ruleViolated" cborgen:"ruleViolated"`
+	// severity: Severity level of the violation
+	Severity string `json:"severity" cborgen:"severity"`
+	// timestamp: When the violation occurred
+	Timestamp string `json:"timestamp" cborgen:"timestamp"`
+}
@@ -0,0 +1,30 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.actor.saved
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.actor.saved", &ActorSaved{})
+}
+
+// ActorSaved is a "main" in the social.coves.actor.saved schema.
+//
+// # A saved post or comment
+//
+// RECORDTYPE: ActorSaved
+type ActorSaved struct {
+	LexiconTypeID string `json:"$type,const=social.coves.actor.saved" cborgen:"$type,const=social.coves.actor.saved"`
+	// createdAt: When the item was saved
+	CreatedAt string `json:"createdAt" cborgen:"createdAt"`
+	// note: Optional note about why this was saved
+	Note *string `json:"note,omitempty" cborgen:"note,omitempty"`
+	// subject: AT-URI of the post or comment being saved
+	Subject string `json:"subject" cborgen:"subject"`
+	// type: Type of content being saved
+	Type string `json:"type" cborgen:"type"`
+}
@@ -0,0 +1,30 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.actor.subscription
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.actor.subscription", &ActorSubscription{})
+}
+
+// ActorSubscription is a "main" in the social.coves.actor.subscription schema.
+//
+// # A subscription to a community
+//
+// RECORDTYPE: ActorSubscription
+type ActorSubscription struct {
+	LexiconTypeID string `json:"$type,const=social.coves.actor.subscription" cborgen:"$type,const=social.coves.actor.subscription"`
+	// community: DID or handle of the community
+	Community string `json:"community" cborgen:"community"`
+	// contentVisibility: Content visibility level (1=only best content, 5=all content)
+	ContentVisibility *int64 `json:"contentVisibility,omitempty" cborgen:"contentVisibility,omitempty"`
+	// createdAt: When the subscription started
+	CreatedAt string `json:"createdAt" cborgen:"createdAt"`
+	// endedAt: When the subscription ended (null if current)
+	EndedAt *string `json:"endedAt,omitempty" cborgen:"endedAt,omitempty"`
+}
@@ -0,0 +1,38 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.community.moderator
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.community.moderator", &CommunityModerator{})
+}
+
+// CommunityModerator is a "main" in the social.coves.community.moderator schema.
+//
+// # A moderator assignment for a community
+//
+// RECORDTYPE: CommunityModerator
+type CommunityModerator struct {
+	LexiconTypeID string `json:"$type,const=social.coves.community.moderator" cborgen:"$type,const=social.coves.community.moderator"`
+	// community: DID of the community
+	Community string `json:"community" cborgen:"community"`
+	// createdAt: When the moderator was assigned
+	CreatedAt string `json:"createdAt" cborgen:"createdAt"`
+	// createdBy: DID of the user who assigned this moderator
+	CreatedBy string `json:"createdBy" cborgen:"createdBy"`
+	// expiresAt: When the moderator assignment expires (optional)
+	ExpiresAt *string `json:"expiresAt,omitempty" cborgen:"expiresAt,omitempty"`
+	// note: Note about why this person was made a moderator
+	Note *string `json:"note,omitempty" cborgen:"note,omitempty"`
+	// permissions: Specific permissions granted
+	Permissions []string `json:"permissions,omitempty" cborgen:"permissions,omitempty"`
+	// role: Level of moderation privileges
+	Role string `json:"role" cborgen:"role"`
+	// user: DID of the user being assigned as moderator
+	User string `json:"user" cborgen:"user"`
+}
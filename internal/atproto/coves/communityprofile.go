@@ -0,0 +1,47 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.community.profile
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.community.profile", &CommunityProfile{})
+}
+
+// CommunityProfile is a "main" in the social.coves.community.profile schema.
+//
+// # A community's profile information
+//
+// RECORDTYPE: CommunityProfile
+type CommunityProfile struct {
+	LexiconTypeID string        `json:"$type,const=social.coves.community.profile" cborgen:"$type,const=social.coves.community.profile"`
+	Avatar        *util.LexBlob `json:"avatar,omitempty" cborgen:"avatar,omitempty"`
+	Banner        *util.LexBlob `json:"banner,omitempty" cborgen:"banner,omitempty"`
+	// contentWarnings: Required content warnings for this community
+	ContentWarnings []string `json:"contentWarnings,omitempty" cborgen:"contentWarnings,omitempty"`
+	CreatedAt       string   `json:"createdAt" cborgen:"createdAt"`
+	// creator: DID of the community creator
+	Creator string `json:"creator" cborgen:"creator"`
+	// description: Community description with rich text support
+	Description *string `json:"description,omitempty" cborgen:"description,omitempty"`
+	// descriptionFacets: Rich text annotations for description
+	DescriptionFacets []*RichtextFacet `json:"descriptionFacets,omitempty" cborgen:"descriptionFacets,omitempty"`
+	// displayName: Display name for the community
+	DisplayName *string `json:"displayName,omitempty" cborgen:"displayName,omitempty"`
+	// federatedFrom: Platform community originated from
+	FederatedFrom string `json:"federatedFrom" cborgen:"federatedFrom"`
+	// federatedId: Original ID on federated platform
+	FederatedId *string `json:"federatedId,omitempty" cborgen:"federatedId,omitempty"`
+	// memberCount: Cached count of community members
+	MemberCount *int64 `json:"memberCount,omitempty" cborgen:"memberCount,omitempty"`
+	// moderationType: Type of moderation system
+	ModerationType string `json:"moderationType" cborgen:"moderationType"`
+	// name: Unique community name
+	Name string `json:"name" cborgen:"name"`
+	// subscriberCount: Cached count of community subscribers
+	SubscriberCount *int64 `json:"subscriberCount,omitempty" cborgen:"subscriberCount,omitempty"`
+}
@@ -0,0 +1,189 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.community.rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/bluesky-social/indigo/lex/util"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+func init() {
+	util.RegisterType("social.coves.community.rules", &CommunityRules{})
+}
+
+// CommunityRules is a "main" in the social.coves.community.rules schema.
+//
+// # Community rules and configuration
+//
+// RECORDTYPE: CommunityRules
+type CommunityRules struct {
+	LexiconTypeID       string                              `json:"$type,const=social.coves.community.rules" cborgen:"$type,const=social.coves.community.rules"`
+	ContentRestrictions *CommunityRules_ContentRestrictions `json:"contentRestrictions,omitempty" cborgen:"contentRestrictions,omitempty"`
+	// customTags: Additional tags beyond predefined ones
+	CustomTags      []string                        `json:"customTags,omitempty" cborgen:"customTags,omitempty"`
+	GeoRestrictions *CommunityRules_GeoRestrictions `json:"geoRestrictions,omitempty" cborgen:"geoRestrictions,omitempty"`
+	// moderationConfig: Configuration for community moderation
+	ModerationConfig *CommunityRules_ModerationConfig `json:"moderationConfig,omitempty" cborgen:"moderationConfig,omitempty"`
+	// moderatorList: DIDs of community moderators (if moderator-based)
+	ModeratorList []string                       `json:"moderatorList,omitempty" cborgen:"moderatorList,omitempty"`
+	PostTypes     *CommunityRules_PostTypeConfig `json:"postTypes,omitempty" cborgen:"postTypes,omitempty"`
+	// textRules: Community-specific rules
+	TextRules []*CommunityRules_Rule `json:"textRules,omitempty" cborgen:"textRules,omitempty"`
+}
+
+// CommunityRules_ContentRestrictions is a "contentRestrictions" in the social.coves.community.rules schema.
+//
+// Content filtering rules
+type CommunityRules_ContentRestrictions struct {
+	// allowedDomains: If set, only these domains can be linked
+	AllowedDomains []string `json:"allowedDomains,omitempty" cborgen:"allowedDomains,omitempty"`
+	// blockedDomains: Domains that cannot be linked
+	BlockedDomains []string `json:"blockedDomains,omitempty" cborgen:"blockedDomains,omitempty"`
+}
+
+// CommunityRules_GeoRestrictions is a "geoRestrictions" in the social.coves.community.rules schema.
+//
+// Geographic posting restrictions
+type CommunityRules_GeoRestrictions struct {
+	// allowedCountries: ISO 3166-1 alpha-2 country codes
+	AllowedCountries []string `json:"allowedCountries,omitempty" cborgen:"allowedCountries,omitempty"`
+	// allowedRegions: Specific regions/states allowed
+	AllowedRegions []string `json:"allowedRegions,omitempty" cborgen:"allowedRegions,omitempty"`
+	Enabled        *bool    `json:"enabled,omitempty" cborgen:"enabled,omitempty"`
+}
+
+type CommunityRules_ModerationConfig struct {
+	CommunityRules_ModeratorModeration *CommunityRules_ModeratorModeration
+	CommunityRules_SortitionModeration *CommunityRules_SortitionModeration
+}
+
+func (t *CommunityRules_ModerationConfig) MarshalJSON() ([]byte, error) {
+	if t.CommunityRules_ModeratorModeration != nil {
+		t.CommunityRules_ModeratorModeration.LexiconTypeID = "social.coves.community.rules#moderatorModeration"
+		return json.Marshal(t.CommunityRules_ModeratorModeration)
+	}
+	if t.CommunityRules_SortitionModeration != nil {
+		t.CommunityRules_SortitionModeration.LexiconTypeID = "social.coves.community.rules#sortitionModeration"
+		return json.Marshal(t.CommunityRules_SortitionModeration)
+	}
+	return nil, fmt.Errorf("cannot marshal empty enum")
+}
+
+func (t *CommunityRules_ModerationConfig) UnmarshalJSON(b []byte) error {
+	typ, err := util.TypeExtract(b)
+	if err != nil {
+		return err
+	}
+
+	switch typ {
+	case "social.coves.community.rules#moderatorModeration":
+		t.CommunityRules_ModeratorModeration = new(CommunityRules_ModeratorModeration)
+		return json.Unmarshal(b, t.CommunityRules_ModeratorModeration)
+	case "social.coves.community.rules#sortitionModeration":
+		t.CommunityRules_SortitionModeration = new(CommunityRules_SortitionModeration)
+		return json.Unmarshal(b, t.CommunityRules_SortitionModeration)
+	default:
+		return nil
+	}
+}
+
+func (t *CommunityRules_ModerationConfig) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if t.CommunityRules_ModeratorModeration != nil {
+		return t.CommunityRules_ModeratorModeration.MarshalCBOR(w)
+	}
+	if t.CommunityRules_SortitionModeration != nil {
+		return t.CommunityRules_SortitionModeration.MarshalCBOR(w)
+	}
+	return fmt.Errorf("cannot cbor marshal empty enum")
+}
+
+func (t *CommunityRules_ModerationConfig) UnmarshalCBOR(r io.Reader) error {
+	typ, b, err := util.CborTypeExtractReader(r)
+	if err != nil {
+		return err
+	}
+
+	switch typ {
+	case "social.coves.community.rules#moderatorModeration":
+		t.CommunityRules_ModeratorModeration = new(CommunityRules_ModeratorModeration)
+		return t.CommunityRules_ModeratorModeration.UnmarshalCBOR(bytes.NewReader(b))
+	case "social.coves.community.rules#sortitionModeration":
+		t.CommunityRules_SortitionModeration = new(CommunityRules_SortitionModeration)
+		return t.CommunityRules_SortitionModeration.UnmarshalCBOR(bytes.NewReader(b))
+	default:
+		return nil
+	}
+}
+
+// CommunityRules_ModeratorModeration is a "moderatorModeration" in the social.coves.community.rules schema.
+//
+// Moderation configuration for moderator-based communities
+type CommunityRules_ModeratorModeration struct {
+	LexiconTypeID string `json:"$type,const=social.coves.community.rules#moderatorModeration" cborgen:"$type,const=social.coves.community.rules#moderatorModeration"`
+	// customNegativeTags: Community-specific tags that count as negative
+	CustomNegativeTags []string `json:"customNegativeTags,omitempty" cborgen:"customNegativeTags,omitempty"`
+	// hideThreshold: Number of negative tags needed to hide content
+	HideThreshold *int64 `json:"hideThreshold,omitempty" cborgen:"hideThreshold,omitempty"`
+	// negativeTags: Default tags that count as negative
+	NegativeTags []string `json:"negativeTags,omitempty" cborgen:"negativeTags,omitempty"`
+}
+
+// CommunityRules_PostTypeConfig is a "postTypeConfig" in the social.coves.community.rules schema.
+//
+// Allowed post types in the community
+type CommunityRules_PostTypeConfig struct {
+	// allowArticle: Allow Article posts
+	AllowArticle *bool `json:"allowArticle,omitempty" cborgen:"allowArticle,omitempty"`
+	// allowImage: Allow image posts
+	AllowImage *bool `json:"allowImage,omitempty" cborgen:"allowImage,omitempty"`
+	// allowMicroblog: Allow microblog posts (federated short-form content)
+	AllowMicroblog *bool `json:"allowMicroblog,omitempty" cborgen:"allowMicroblog,omitempty"`
+	// allowText: Allow text posts
+	AllowText *bool `json:"allowText,omitempty" cborgen:"allowText,omitempty"`
+	// allowVideo: Allow video posts
+	AllowVideo *bool `json:"allowVideo,omitempty" cborgen:"allowVideo,omitempty"`
+}
+
+// CommunityRules_Rule is a "rule" in the social.coves.community.rules schema.
+//
+// A text-based community rule for display purposes
+type CommunityRules_Rule struct {
+	// createdAt: When the rule was created
+	CreatedAt string `json:"createdAt" cborgen:"createdAt"`
+	// description: Detailed explanation of the rule
+	Description string `json:"description" cborgen:"description"`
+	// disabledAt: When the rule was disabled (optional)
+	DisabledAt *string `json:"disabledAt,omitempty" cborgen:"disabledAt,omitempty"`
+	// isActive: Whether the rule is currently active
+	IsActive bool `json:"isActive" cborgen:"isActive"`
+	// title: Short rule title (e.g., 'No Editorialized Titles')
+	Title string `json:"title" cborgen:"title"`
+}
+
+// CommunityRules_SortitionModeration is a "sortitionModeration" in the social.coves.community.rules schema.
+//
+// Moderation configuration for sortition-based communities
+type CommunityRules_SortitionModeration struct {
+	LexiconTypeID string `json:"$type,const=social.coves.community.rules#sortitionModeration" cborgen:"$type,const=social.coves.community.rules#sortitionModeration"`
+	// customNegativeTags: Community-specific tags that count as negative
+	CustomNegativeTags []string `json:"customNegativeTags,omitempty" cborgen:"customNegativeTags,omitempty"`
+	// hideThreshold: Number of negative tags needed to hide content
+	HideThreshold *int64 `json:"hideThreshold,omitempty" cborgen:"hideThreshold,omitempty"`
+	// jurySize: Number of jurors for tribunal
+	JurySize *int64 `json:"jurySize,omitempty" cborgen:"jurySize,omitempty"`
+	// negativeTags: Default tags that count as negative
+	NegativeTags []string `json:"negativeTags,omitempty" cborgen:"negativeTags,omitempty"`
+	// tribunalThreshold: Number of negative tags to trigger tribunal review
+	TribunalThreshold *int64 `json:"tribunalThreshold,omitempty" cborgen:"tribunalThreshold,omitempty"`
+}
@@ -0,0 +1,42 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.community.wiki
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.community.wiki", &CommunityWiki{})
+}
+
+// CommunityWiki is a "main" in the social.coves.community.wiki schema.
+//
+// # Community wiki page
+//
+// RECORDTYPE: CommunityWiki
+type CommunityWiki struct {
+	LexiconTypeID string `json:"$type,const=social.coves.community.wiki" cborgen:"$type,const=social.coves.community.wiki"`
+	// content: Markdown-formatted wiki content
+	Content   string `json:"content" cborgen:"content"`
+	CreatedAt string `json:"createdAt" cborgen:"createdAt"`
+	// editHistory: History of edits (stored separately for efficiency)
+	EditHistory []*CommunityWiki_WikiEdit `json:"editHistory,omitempty" cborgen:"editHistory,omitempty"`
+	// lastEditedBy: DID of the last editor
+	LastEditedBy *string `json:"lastEditedBy,omitempty" cborgen:"lastEditedBy,omitempty"`
+	// slug: URL-friendly page identifier
+	Slug *string `json:"slug,omitempty" cborgen:"slug,omitempty"`
+	// title: Wiki page title
+	Title     string  `json:"title" cborgen:"title"`
+	UpdatedAt *string `json:"updatedAt,omitempty" cborgen:"updatedAt,omitempty"`
+}
+
+// CommunityWiki_WikiEdit is a "wikiEdit" in the social.coves.community.wiki schema.
+type CommunityWiki_WikiEdit struct {
+	Editor string `json:"editor" cborgen:"editor"`
+	// summary: Edit summary
+	Summary   *string `json:"summary,omitempty" cborgen:"summary,omitempty"`
+	Timestamp string  `json:"timestamp" cborgen:"timestamp"`
+}
@@ -0,0 +1,104 @@
+package coves_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"Coves/internal/atproto/coves"
+	atrepo "Coves/internal/atproto/repo"
+)
+
+const postJSON = `{
+	"$type": "social.coves.post.record",
+	"community": "did:plc:community123",
+	"postType": "text",
+	"title": "Hello",
+	"content": "Hello bold world",
+	"createdAt": "2025-01-09T14:30:00Z",
+	"facets": [{
+		"index": {"byteStart": 6, "byteEnd": 10},
+		"features": [{"$type": "social.coves.richtext.facet#bold"}]
+	}],
+	"embed": {
+		"$type": "social.coves.embed.external",
+		"uri": "https://example.com",
+		"title": "Example"
+	}
+}`
+
+func TestPostRecord_RoundTrip(t *testing.T) {
+	var post coves.PostRecord
+	if err := json.Unmarshal([]byte(postJSON), &post); err != nil {
+		t.Fatalf("Failed to decode post: %v", err)
+	}
+	if post.Embed == nil || post.Embed.EmbedExternal == nil {
+		t.Fatalf("Expected an external embed, got %+v", post.Embed)
+	}
+	if len(post.Facets) != 1 || post.Facets[0].Features[0].RichtextFacet_Bold == nil {
+		t.Fatalf("Expected a bold facet, got %+v", post.Facets)
+	}
+
+	// The generated marshaler writes the same bytes as the generic record
+	var buf bytes.Buffer
+	if err := post.MarshalCBOR(&buf); err != nil {
+		t.Fatalf("Failed to encode post: %v", err)
+	}
+	want, err := atrepo.RecordJSONToCBOR([]byte(postJSON))
+	if err != nil {
+		t.Fatalf("Failed to encode generic record: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Error("Expected generated CBOR to match the generic record encoding")
+	}
+
+	var decoded coves.PostRecord
+	if err := decoded.UnmarshalCBOR(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("Failed to decode CBOR: %v", err)
+	}
+	out, err := json.Marshal(&decoded)
+	if err != nil {
+		t.Fatalf("Failed to encode JSON: %v", err)
+	}
+	again, err := atrepo.RecordJSONToCBOR(out)
+	if err != nil {
+		t.Fatalf("Failed to encode round-tripped JSON: %v", err)
+	}
+	if !bytes.Equal(again, want) {
+		t.Errorf("Round trip changed the post:\n%s", out)
+	}
+}
+
+func TestModerationRuleProposal_UnknownField(t *testing.T) {
+	in := []byte(`{
+		"$type": "social.coves.moderation.ruleProposal",
+		"community": "did:plc:community123",
+		"proposalType": "addTag",
+		"description": "Add a tag",
+		"proposalData": {"tag": "news", "weight": 3},
+		"createdAt": "2025-01-09T14:30:00Z"
+	}`)
+
+	var rec coves.ModerationRuleProposal
+	if err := json.Unmarshal(in, &rec); err != nil {
+		t.Fatalf("Failed to decode proposal: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := rec.MarshalCBOR(&buf); err != nil {
+		t.Fatalf("Failed to encode proposal: %v", err)
+	}
+
+	var decoded coves.ModerationRuleProposal
+	if err := decoded.UnmarshalCBOR(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("Failed to decode CBOR: %v", err)
+	}
+	if decoded.ProposalData == nil {
+		t.Fatal("Expected proposal data to survive the round trip")
+	}
+	if w, ok := decoded.ProposalData.Value()["weight"].(int64); !ok || w != 3 {
+		t.Errorf("Expected weight 3, got %#v", decoded.ProposalData.Value()["weight"])
+	}
+	if decoded.ProposalType != "addTag" {
+		t.Errorf("Expected fields after the unknown one to decode, got proposalType %q", decoded.ProposalType)
+	}
+}
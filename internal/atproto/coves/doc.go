@@ -0,0 +1,6 @@
+// Package coves holds Go types for the social.coves.* lexicon records. The
+// types and their CBOR marshalers are generated by cmd/generate-lexicon;
+// regenerate them after changing a lexicon with:
+//
+//	go run ./cmd/generate-lexicon
+package coves
@@ -0,0 +1,34 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.embed.external
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+// EmbedExternal is a "main" in the social.coves.embed.external schema.
+//
+// External link embed with preview metadata and provider support
+type EmbedExternal struct {
+	LexiconTypeID string `json:"$type,const=social.coves.embed.external" cborgen:"$type,const=social.coves.embed.external"`
+	// description: Description or excerpt of the linked content
+	Description *string `json:"description,omitempty" cborgen:"description,omitempty"`
+	// domain: Domain of the linked content
+	Domain *string `json:"domain,omitempty" cborgen:"domain,omitempty"`
+	// embedType: Type hint for special handling of known providers
+	EmbedType *string `json:"embedType,omitempty" cborgen:"embedType,omitempty"`
+	// images: Preview images for image gallery providers
+	Images []*EmbedImages_Image `json:"images,omitempty" cborgen:"images,omitempty"`
+	// provider: Service provider name (e.g., imgur, streamable)
+	Provider *string `json:"provider,omitempty" cborgen:"provider,omitempty"`
+	// thumb: Thumbnail image for the link
+	Thumb *util.LexBlob `json:"thumb,omitempty" cborgen:"thumb,omitempty"`
+	// title: Title of the linked content
+	Title *string `json:"title,omitempty" cborgen:"title,omitempty"`
+	// totalCount: Total number of items if more than displayed (for galleries)
+	TotalCount *int64 `json:"totalCount,omitempty" cborgen:"totalCount,omitempty"`
+	// uri: URI of the external content
+	Uri string `json:"uri" cborgen:"uri"`
+}
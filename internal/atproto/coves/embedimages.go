@@ -0,0 +1,40 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.embed.images
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+// EmbedImages is a "main" in the social.coves.embed.images schema.
+//
+// Image set embed supporting multiple images (max 8)
+type EmbedImages struct {
+	LexiconTypeID string `json:"$type,const=social.coves.embed.images" cborgen:"$type,const=social.coves.embed.images"`
+	// images: Set of images in the post
+	Images []*EmbedImages_Image `json:"images" cborgen:"images"`
+}
+
+// EmbedImages_AspectRatio is a "aspectRatio" in the social.coves.embed.images schema.
+//
+// Image aspect ratio for client display
+type EmbedImages_AspectRatio struct {
+	// height: Height component of aspect ratio
+	Height int64 `json:"height" cborgen:"height"`
+	// width: Width component of aspect ratio
+	Width int64 `json:"width" cborgen:"width"`
+}
+
+// EmbedImages_Image is a "image" in the social.coves.embed.images schema.
+//
+// Individual image with metadata
+type EmbedImages_Image struct {
+	// alt: Alt text for accessibility
+	Alt *string `json:"alt,omitempty" cborgen:"alt,omitempty"`
+	// aspectRatio: Aspect ratio for client-side rendering optimization
+	AspectRatio *EmbedImages_AspectRatio `json:"aspectRatio,omitempty" cborgen:"aspectRatio,omitempty"`
+	// image: Image blob reference
+	Image *util.LexBlob `json:"image" cborgen:"image"`
+}
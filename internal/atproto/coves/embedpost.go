@@ -0,0 +1,16 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.embed.post
+
+import ()
+
+// EmbedPost is a "main" in the social.coves.embed.post schema.
+//
+// Embedded reference to another post
+type EmbedPost struct {
+	LexiconTypeID string `json:"$type,const=social.coves.embed.post" cborgen:"$type,const=social.coves.embed.post"`
+	// uri: AT-URI of the post being embedded
+	Uri string `json:"uri" cborgen:"uri"`
+}
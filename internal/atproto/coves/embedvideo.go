@@ -0,0 +1,25 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.embed.video
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+// EmbedVideo is a "main" in the social.coves.embed.video schema.
+//
+// Video embed with metadata
+type EmbedVideo struct {
+	LexiconTypeID string `json:"$type,const=social.coves.embed.video" cborgen:"$type,const=social.coves.embed.video"`
+	// alt: Alt text describing video content
+	Alt         *string                  `json:"alt,omitempty" cborgen:"alt,omitempty"`
+	AspectRatio *EmbedImages_AspectRatio `json:"aspectRatio,omitempty" cborgen:"aspectRatio,omitempty"`
+	// duration: Duration in seconds
+	Duration *int64 `json:"duration,omitempty" cborgen:"duration,omitempty"`
+	// thumbnail: Video thumbnail image
+	Thumbnail *util.LexBlob `json:"thumbnail,omitempty" cborgen:"thumbnail,omitempty"`
+	// video: Video blob reference
+	Video *util.LexBlob `json:"video" cborgen:"video"`
+}
@@ -0,0 +1,21 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.federation.post
+
+import ()
+
+// FederationPost is a "main" in the social.coves.federation.post schema.
+//
+// Reference to original federated post
+type FederationPost struct {
+	// id: Platform-specific post ID
+	Id *string `json:"id,omitempty" cborgen:"id,omitempty"`
+	// originalCreatedAt: Timestamp when originally posted on source platform
+	OriginalCreatedAt *string `json:"originalCreatedAt,omitempty" cborgen:"originalCreatedAt,omitempty"`
+	// platform: Platform the post originated from
+	Platform string `json:"platform" cborgen:"platform"`
+	// uri: Original URI of the post (at:// URI for atproto platforms)
+	Uri string `json:"uri" cborgen:"uri"`
+}
@@ -0,0 +1,141 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.interaction.comment
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/bluesky-social/indigo/lex/util"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+func init() {
+	util.RegisterType("social.coves.interaction.comment", &InteractionComment{})
+}
+
+// InteractionComment is a "main" in the social.coves.interaction.comment schema.
+//
+// # A comment on a post or another comment
+//
+// RECORDTYPE: InteractionComment
+type InteractionComment struct {
+	LexiconTypeID string                      `json:"$type,const=social.coves.interaction.comment" cborgen:"$type,const=social.coves.interaction.comment"`
+	Content       *InteractionComment_Content `json:"content" cborgen:"content"`
+	CreatedAt     string                      `json:"createdAt" cborgen:"createdAt"`
+	Location      *ActorProfile_GeoLocation   `json:"location,omitempty" cborgen:"location,omitempty"`
+	// subject: AT-URI of post or comment being replied to
+	Subject string `json:"subject" cborgen:"subject"`
+	// translatedFrom: Language code if auto-translated (ISO 639-1)
+	TranslatedFrom *string `json:"translatedFrom,omitempty" cborgen:"translatedFrom,omitempty"`
+}
+
+type InteractionComment_Content struct {
+	InteractionComment_TextContent    *InteractionComment_TextContent
+	InteractionComment_ImageContent   *InteractionComment_ImageContent
+	InteractionComment_StickerContent *InteractionComment_StickerContent
+}
+
+func (t *InteractionComment_Content) MarshalJSON() ([]byte, error) {
+	if t.InteractionComment_TextContent != nil {
+		t.InteractionComment_TextContent.LexiconTypeID = "social.coves.interaction.comment#textContent"
+		return json.Marshal(t.InteractionComment_TextContent)
+	}
+	if t.InteractionComment_ImageContent != nil {
+		t.InteractionComment_ImageContent.LexiconTypeID = "social.coves.interaction.comment#imageContent"
+		return json.Marshal(t.InteractionComment_ImageContent)
+	}
+	if t.InteractionComment_StickerContent != nil {
+		t.InteractionComment_StickerContent.LexiconTypeID = "social.coves.interaction.comment#stickerContent"
+		return json.Marshal(t.InteractionComment_StickerContent)
+	}
+	return nil, fmt.Errorf("cannot marshal empty enum")
+}
+
+func (t *InteractionComment_Content) UnmarshalJSON(b []byte) error {
+	typ, err := util.TypeExtract(b)
+	if err != nil {
+		return err
+	}
+
+	switch typ {
+	case "social.coves.interaction.comment#textContent":
+		t.InteractionComment_TextContent = new(InteractionComment_TextContent)
+		return json.Unmarshal(b, t.InteractionComment_TextContent)
+	case "social.coves.interaction.comment#imageContent":
+		t.InteractionComment_ImageContent = new(InteractionComment_ImageContent)
+		return json.Unmarshal(b, t.InteractionComment_ImageContent)
+	case "social.coves.interaction.comment#stickerContent":
+		t.InteractionComment_StickerContent = new(InteractionComment_StickerContent)
+		return json.Unmarshal(b, t.InteractionComment_StickerContent)
+	default:
+		return nil
+	}
+}
+
+func (t *InteractionComment_Content) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if t.InteractionComment_TextContent != nil {
+		return t.InteractionComment_TextContent.MarshalCBOR(w)
+	}
+	if t.InteractionComment_ImageContent != nil {
+		return t.InteractionComment_ImageContent.MarshalCBOR(w)
+	}
+	if t.InteractionComment_StickerContent != nil {
+		return t.InteractionComment_StickerContent.MarshalCBOR(w)
+	}
+	return fmt.Errorf("cannot cbor marshal empty enum")
+}
+
+func (t *InteractionComment_Content) UnmarshalCBOR(r io.Reader) error {
+	typ, b, err := util.CborTypeExtractReader(r)
+	if err != nil {
+		return err
+	}
+
+	switch typ {
+	case "social.coves.interaction.comment#textContent":
+		t.InteractionComment_TextContent = new(InteractionComment_TextContent)
+		return t.InteractionComment_TextContent.UnmarshalCBOR(bytes.NewReader(b))
+	case "social.coves.interaction.comment#imageContent":
+		t.InteractionComment_ImageContent = new(InteractionComment_ImageContent)
+		return t.InteractionComment_ImageContent.UnmarshalCBOR(bytes.NewReader(b))
+	case "social.coves.interaction.comment#stickerContent":
+		t.InteractionComment_StickerContent = new(InteractionComment_StickerContent)
+		return t.InteractionComment_StickerContent.UnmarshalCBOR(bytes.NewReader(b))
+	default:
+		return nil
+	}
+}
+
+// InteractionComment_ImageContent is a "imageContent" in the social.coves.interaction.comment schema.
+type InteractionComment_ImageContent struct {
+	LexiconTypeID string             `json:"$type,const=social.coves.interaction.comment#imageContent" cborgen:"$type,const=social.coves.interaction.comment#imageContent"`
+	Caption       *string            `json:"caption,omitempty" cborgen:"caption,omitempty"`
+	Image         *EmbedImages_Image `json:"image" cborgen:"image"`
+}
+
+// InteractionComment_StickerContent is a "stickerContent" in the social.coves.interaction.comment schema.
+type InteractionComment_StickerContent struct {
+	LexiconTypeID string `json:"$type,const=social.coves.interaction.comment#stickerContent" cborgen:"$type,const=social.coves.interaction.comment#stickerContent"`
+	// stickerId: Reference to a sticker in a sticker pack
+	StickerId string `json:"stickerId" cborgen:"stickerId"`
+	// stickerPackId: Reference to the sticker pack
+	StickerPackId *string `json:"stickerPackId,omitempty" cborgen:"stickerPackId,omitempty"`
+}
+
+// InteractionComment_TextContent is a "textContent" in the social.coves.interaction.comment schema.
+type InteractionComment_TextContent struct {
+	LexiconTypeID string `json:"$type,const=social.coves.interaction.comment#textContent" cborgen:"$type,const=social.coves.interaction.comment#textContent"`
+	// facets: Rich text annotations
+	Facets []*RichtextFacet `json:"facets,omitempty" cborgen:"facets,omitempty"`
+	// text: Comment text
+	Text string `json:"text" cborgen:"text"`
+}
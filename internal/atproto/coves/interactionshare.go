@@ -0,0 +1,27 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.interaction.share
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.interaction.share", &InteractionShare{})
+}
+
+// InteractionShare is a "main" in the social.coves.interaction.share schema.
+//
+// # Sharing a post to another community or platform
+//
+// RECORDTYPE: InteractionShare
+type InteractionShare struct {
+	LexiconTypeID string `json:"$type,const=social.coves.interaction.share" cborgen:"$type,const=social.coves.interaction.share"`
+	CreatedAt     string `json:"createdAt" cborgen:"createdAt"`
+	// subject: AT-URI of the post being shared
+	Subject string `json:"subject" cborgen:"subject"`
+	// toCommunity: Community being shared to (if applicable)
+	ToCommunity *string `json:"toCommunity,omitempty" cborgen:"toCommunity,omitempty"`
+}
@@ -0,0 +1,27 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.interaction.tag
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.interaction.tag", &InteractionTag{})
+}
+
+// InteractionTag is a "main" in the social.coves.interaction.tag schema.
+//
+// # A tag applied to a post or comment
+//
+// RECORDTYPE: InteractionTag
+type InteractionTag struct {
+	LexiconTypeID string `json:"$type,const=social.coves.interaction.tag" cborgen:"$type,const=social.coves.interaction.tag"`
+	CreatedAt     string `json:"createdAt" cborgen:"createdAt"`
+	// subject: AT-URI of the post or comment being tagged
+	Subject string `json:"subject" cborgen:"subject"`
+	// tag: Predefined tag or custom community tag
+	Tag string `json:"tag" cborgen:"tag"`
+}
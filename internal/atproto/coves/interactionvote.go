@@ -0,0 +1,25 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.interaction.vote
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.interaction.vote", &InteractionVote{})
+}
+
+// InteractionVote is a "main" in the social.coves.interaction.vote schema.
+//
+// # An upvote on a post or comment
+//
+// RECORDTYPE: InteractionVote
+type InteractionVote struct {
+	LexiconTypeID string `json:"$type,const=social.coves.interaction.vote" cborgen:"$type,const=social.coves.interaction.vote"`
+	CreatedAt     string `json:"createdAt" cborgen:"createdAt"`
+	// subject: AT-URI of the post or comment being voted on
+	Subject string `json:"subject" cborgen:"subject"`
+}
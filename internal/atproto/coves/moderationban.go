@@ -0,0 +1,44 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.moderation.ban
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.moderation.ban", &ModerationBan{})
+}
+
+// ModerationBan is a "main" in the social.coves.moderation.ban schema.
+//
+// # A ban record for a user in a community
+//
+// RECORDTYPE: ModerationBan
+type ModerationBan struct {
+	LexiconTypeID string `json:"$type,const=social.coves.moderation.ban" cborgen:"$type,const=social.coves.moderation.ban"`
+	// banType: How the ban was imposed
+	BanType string `json:"banType" cborgen:"banType"`
+	// bannedBy: DID of moderator who issued ban (null for tribunal bans)
+	BannedBy *string `json:"bannedBy,omitempty" cborgen:"bannedBy,omitempty"`
+	// community: DID or handle of the community
+	Community string `json:"community" cborgen:"community"`
+	CreatedAt string `json:"createdAt" cborgen:"createdAt"`
+	// duration: Ban duration in hours (null for permanent)
+	Duration *int64 `json:"duration,omitempty" cborgen:"duration,omitempty"`
+	// expiresAt: When the ban expires (null for permanent)
+	ExpiresAt *string `json:"expiresAt,omitempty" cborgen:"expiresAt,omitempty"`
+	// reason: Reason for the ban
+	Reason string `json:"reason" cborgen:"reason"`
+	// revokedAt: When the ban was revoked
+	RevokedAt *string `json:"revokedAt,omitempty" cborgen:"revokedAt,omitempty"`
+	// revokedBy: DID of moderator who revoked the ban
+	RevokedBy *string `json:"revokedBy,omitempty" cborgen:"revokedBy,omitempty"`
+	Status    *string `json:"status,omitempty" cborgen:"status,omitempty"`
+	// subject: DID of the banned user
+	Subject string `json:"subject" cborgen:"subject"`
+	// tribunalCase: AT-URI of tribunal case that resulted in ban
+	TribunalCase *string `json:"tribunalCase,omitempty" cborgen:"tribunalCase,omitempty"`
+}
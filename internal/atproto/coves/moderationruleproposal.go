@@ -0,0 +1,45 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.moderation.ruleProposal
+
+import (
+	atrepo "Coves/internal/atproto/repo"
+
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.moderation.ruleProposal", &ModerationRuleProposal{})
+}
+
+// ModerationRuleProposal is a "main" in the social.coves.moderation.ruleProposal schema.
+//
+// # A proposal to change community rules
+//
+// RECORDTYPE: ModerationRuleProposal
+type ModerationRuleProposal struct {
+	LexiconTypeID string `json:"$type,const=social.coves.moderation.ruleProposal" cborgen:"$type,const=social.coves.moderation.ruleProposal"`
+	// community: DID or handle of the community
+	Community string `json:"community" cborgen:"community"`
+	CreatedAt string `json:"createdAt" cborgen:"createdAt"`
+	// description: Detailed description of the proposed change
+	Description string `json:"description" cborgen:"description"`
+	// implementedAt: When the change was implemented
+	ImplementedAt *string `json:"implementedAt,omitempty" cborgen:"implementedAt,omitempty"`
+	// proposalData: Type-specific proposal data
+	ProposalData *atrepo.GenericRecord `json:"proposalData,omitempty" cborgen:"proposalData,omitempty"`
+	ProposalType string                `json:"proposalType" cborgen:"proposalType"`
+	// requiredApprovalPercent: Percentage of approval votes needed
+	RequiredApprovalPercent *int64 `json:"requiredApprovalPercent,omitempty" cborgen:"requiredApprovalPercent,omitempty"`
+	// requiredVotes: Number of votes needed to pass
+	RequiredVotes *int64  `json:"requiredVotes,omitempty" cborgen:"requiredVotes,omitempty"`
+	Status        *string `json:"status,omitempty" cborgen:"status,omitempty"`
+	// title: Short title for the proposal
+	Title *string `json:"title,omitempty" cborgen:"title,omitempty"`
+	// votingEndsAt: When voting ends
+	VotingEndsAt *string `json:"votingEndsAt,omitempty" cborgen:"votingEndsAt,omitempty"`
+	// votingStartsAt: When voting begins
+	VotingStartsAt *string `json:"votingStartsAt,omitempty" cborgen:"votingStartsAt,omitempty"`
+}
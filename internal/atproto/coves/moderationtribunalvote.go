@@ -0,0 +1,35 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.moderation.tribunalVote
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.moderation.tribunalVote", &ModerationTribunalVote{})
+}
+
+// ModerationTribunalVote is a "main" in the social.coves.moderation.tribunalVote schema.
+//
+// # A vote in a tribunal decision
+//
+// RECORDTYPE: ModerationTribunalVote
+type ModerationTribunalVote struct {
+	LexiconTypeID string `json:"$type,const=social.coves.moderation.tribunalVote" cborgen:"$type,const=social.coves.moderation.tribunalVote"`
+	CreatedAt     string `json:"createdAt" cborgen:"createdAt"`
+	// decision: Tribunal decision
+	Decision string `json:"decision" cborgen:"decision"`
+	// duration: Ban duration in days (for timeout)
+	Duration *int64 `json:"duration,omitempty" cborgen:"duration,omitempty"`
+	// precedents: References to similar past cases
+	Precedents []string `json:"precedents,omitempty" cborgen:"precedents,omitempty"`
+	// reason: Detailed reasoning for the decision
+	Reason *string `json:"reason,omitempty" cborgen:"reason,omitempty"`
+	// subject: Post/comment/user being judged
+	Subject string `json:"subject" cborgen:"subject"`
+	// tribunal: AT-URI of the tribunal case
+	Tribunal string `json:"tribunal" cborgen:"tribunal"`
+}
@@ -0,0 +1,28 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.moderation.vote
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.moderation.vote", &ModerationVote{})
+}
+
+// ModerationVote is a "main" in the social.coves.moderation.vote schema.
+//
+// # A vote on a moderation action or rule change
+//
+// RECORDTYPE: ModerationVote
+type ModerationVote struct {
+	LexiconTypeID string `json:"$type,const=social.coves.moderation.vote" cborgen:"$type,const=social.coves.moderation.vote"`
+	CreatedAt     string `json:"createdAt" cborgen:"createdAt"`
+	// reason: Optional reason for the vote
+	Reason *string `json:"reason,omitempty" cborgen:"reason,omitempty"`
+	// subject: AT-URI of the proposal being voted on
+	Subject string `json:"subject" cborgen:"subject"`
+	Vote    string `json:"vote" cborgen:"vote"`
+}
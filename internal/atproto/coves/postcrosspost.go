@@ -0,0 +1,29 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.post.crosspost
+
+import (
+	"github.com/bluesky-social/indigo/lex/util"
+)
+
+func init() {
+	util.RegisterType("social.coves.post.crosspost", &PostCrosspost{})
+}
+
+// PostCrosspost is a "main" in the social.coves.post.crosspost schema.
+//
+// # A record tracking crosspost relationships between posts
+//
+// RECORDTYPE: PostCrosspost
+type PostCrosspost struct {
+	LexiconTypeID string `json:"$type,const=social.coves.post.crosspost" cborgen:"$type,const=social.coves.post.crosspost"`
+	// allCrossposts: Array of AT-URIs of all posts in the crosspost chain
+	AllCrossposts []string `json:"allCrossposts,omitempty" cborgen:"allCrossposts,omitempty"`
+	CreatedAt     string   `json:"createdAt" cborgen:"createdAt"`
+	// crosspostOf: AT-URI of the immediate parent this is a crosspost of
+	CrosspostOf string `json:"crosspostOf" cborgen:"crosspostOf"`
+	// originalPost: AT-URI of the original post in the crosspost chain
+	OriginalPost string `json:"originalPost" cborgen:"originalPost"`
+}
@@ -0,0 +1,162 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.post.record
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/bluesky-social/indigo/lex/util"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+func init() {
+	util.RegisterType("social.coves.post.record", &PostRecord{})
+}
+
+// PostRecord is a "main" in the social.coves.post.record schema.
+//
+// # A unified post record supporting multiple content types
+//
+// RECORDTYPE: PostRecord
+type PostRecord struct {
+	LexiconTypeID string `json:"$type,const=social.coves.post.record" cborgen:"$type,const=social.coves.post.record"`
+	// community: DID or handle of the community this was posted to
+	Community string `json:"community" cborgen:"community"`
+	// content: Post content - main text for text posts, description for media, etc.
+	Content *string `json:"content,omitempty" cborgen:"content,omitempty"`
+	// contentLabels: Self-applied content labels
+	ContentLabels []string `json:"contentLabels,omitempty" cborgen:"contentLabels,omitempty"`
+	CreatedAt     string   `json:"createdAt" cborgen:"createdAt"`
+	// crosspostChain: Array of AT-URIs of all posts in the crosspost chain (including this one)
+	CrosspostChain []string `json:"crosspostChain,omitempty" cborgen:"crosspostChain,omitempty"`
+	// crosspostOf: If this is a crosspost, AT-URI of the post this is a crosspost of
+	CrosspostOf *string `json:"crosspostOf,omitempty" cborgen:"crosspostOf,omitempty"`
+	// embed: Embedded content - images, videos, external links, or quoted posts
+	Embed *PostRecord_Embed `json:"embed,omitempty" cborgen:"embed,omitempty"`
+	// facets: Rich text annotations for content
+	Facets []*RichtextFacet `json:"facets,omitempty" cborgen:"facets,omitempty"`
+	// federatedFrom: Reference to original federated post (if applicable)
+	FederatedFrom *FederationPost `json:"federatedFrom,omitempty" cborgen:"federatedFrom,omitempty"`
+	// location: Geographic location where post was created
+	Location *ActorProfile_GeoLocation `json:"location,omitempty" cborgen:"location,omitempty"`
+	// originalAuthor: For microblog posts - information about the original author from federated platform
+	OriginalAuthor *PostRecord_OriginalAuthor `json:"originalAuthor,omitempty" cborgen:"originalAuthor,omitempty"`
+	// postType: Discriminator for post type to enable filtering and specialized rendering
+	PostType string `json:"postType" cborgen:"postType"`
+	// title: Post title (optional for microblog, image, and video posts)
+	Title *string `json:"title,omitempty" cborgen:"title,omitempty"`
+}
+
+type PostRecord_Embed struct {
+	EmbedImages   *EmbedImages
+	EmbedVideo    *EmbedVideo
+	EmbedExternal *EmbedExternal
+	EmbedPost     *EmbedPost
+}
+
+func (t *PostRecord_Embed) MarshalJSON() ([]byte, error) {
+	if t.EmbedImages != nil {
+		t.EmbedImages.LexiconTypeID = "social.coves.embed.images"
+		return json.Marshal(t.EmbedImages)
+	}
+	if t.EmbedVideo != nil {
+		t.EmbedVideo.LexiconTypeID = "social.coves.embed.video"
+		return json.Marshal(t.EmbedVideo)
+	}
+	if t.EmbedExternal != nil {
+		t.EmbedExternal.LexiconTypeID = "social.coves.embed.external"
+		return json.Marshal(t.EmbedExternal)
+	}
+	if t.EmbedPost != nil {
+		t.EmbedPost.LexiconTypeID = "social.coves.embed.post"
+		return json.Marshal(t.EmbedPost)
+	}
+	return nil, fmt.Errorf("cannot marshal empty enum")
+}
+
+func (t *PostRecord_Embed) UnmarshalJSON(b []byte) error {
+	typ, err := util.TypeExtract(b)
+	if err != nil {
+		return err
+	}
+
+	switch typ {
+	case "social.coves.embed.images":
+		t.EmbedImages = new(EmbedImages)
+		return json.Unmarshal(b, t.EmbedImages)
+	case "social.coves.embed.video":
+		t.EmbedVideo = new(EmbedVideo)
+		return json.Unmarshal(b, t.EmbedVideo)
+	case "social.coves.embed.external":
+		t.EmbedExternal = new(EmbedExternal)
+		return json.Unmarshal(b, t.EmbedExternal)
+	case "social.coves.embed.post":
+		t.EmbedPost = new(EmbedPost)
+		return json.Unmarshal(b, t.EmbedPost)
+	default:
+		return nil
+	}
+}
+
+func (t *PostRecord_Embed) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if t.EmbedImages != nil {
+		return t.EmbedImages.MarshalCBOR(w)
+	}
+	if t.EmbedVideo != nil {
+		return t.EmbedVideo.MarshalCBOR(w)
+	}
+	if t.EmbedExternal != nil {
+		return t.EmbedExternal.MarshalCBOR(w)
+	}
+	if t.EmbedPost != nil {
+		return t.EmbedPost.MarshalCBOR(w)
+	}
+	return fmt.Errorf("cannot cbor marshal empty enum")
+}
+
+func (t *PostRecord_Embed) UnmarshalCBOR(r io.Reader) error {
+	typ, b, err := util.CborTypeExtractReader(r)
+	if err != nil {
+		return err
+	}
+
+	switch typ {
+	case "social.coves.embed.images":
+		t.EmbedImages = new(EmbedImages)
+		return t.EmbedImages.UnmarshalCBOR(bytes.NewReader(b))
+	case "social.coves.embed.video":
+		t.EmbedVideo = new(EmbedVideo)
+		return t.EmbedVideo.UnmarshalCBOR(bytes.NewReader(b))
+	case "social.coves.embed.external":
+		t.EmbedExternal = new(EmbedExternal)
+		return t.EmbedExternal.UnmarshalCBOR(bytes.NewReader(b))
+	case "social.coves.embed.post":
+		t.EmbedPost = new(EmbedPost)
+		return t.EmbedPost.UnmarshalCBOR(bytes.NewReader(b))
+	default:
+		return nil
+	}
+}
+
+// PostRecord_OriginalAuthor is a "originalAuthor" in the social.coves.post.record schema.
+//
+// Information about the original author from a federated platform
+type PostRecord_OriginalAuthor struct {
+	// avatar: URL to original author's avatar
+	Avatar *string `json:"avatar,omitempty" cborgen:"avatar,omitempty"`
+	// did: Original author's DID (if available)
+	Did *string `json:"did,omitempty" cborgen:"did,omitempty"`
+	// displayName: Original author's display name
+	DisplayName *string `json:"displayName,omitempty" cborgen:"displayName,omitempty"`
+	// handle: Original author's handle
+	Handle string `json:"handle" cborgen:"handle"`
+}
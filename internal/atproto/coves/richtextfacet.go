@@ -0,0 +1,207 @@
+// Code generated by cmd/generate-lexicon; DO NOT EDIT.
+
+package coves
+
+// schema: social.coves.richtext.facet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/bluesky-social/indigo/lex/util"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// RichtextFacet is a "main" in the social.coves.richtext.facet schema.
+//
+// Annotation of a sub-string within rich text
+type RichtextFacet struct {
+	// features: Features applied to this text range
+	Features []*RichtextFacet_Features_Elem `json:"features" cborgen:"features"`
+	Index    *RichtextFacet_ByteSlice       `json:"index" cborgen:"index"`
+}
+
+// RichtextFacet_Bold is a "bold" in the social.coves.richtext.facet schema.
+//
+// Bold text formatting
+type RichtextFacet_Bold struct {
+	LexiconTypeID string `json:"$type,const=social.coves.richtext.facet#bold" cborgen:"$type,const=social.coves.richtext.facet#bold"`
+}
+
+// RichtextFacet_ByteSlice is a "byteSlice" in the social.coves.richtext.facet schema.
+//
+// Specifies the sub-string range via byte indices
+type RichtextFacet_ByteSlice struct {
+	// byteEnd: Exclusive end position in UTF-8 bytes
+	ByteEnd int64 `json:"byteEnd" cborgen:"byteEnd"`
+	// byteStart: Inclusive start position in UTF-8 bytes
+	ByteStart int64 `json:"byteStart" cborgen:"byteStart"`
+}
+
+type RichtextFacet_Features_Elem struct {
+	RichtextFacet_Mention       *RichtextFacet_Mention
+	RichtextFacet_Link          *RichtextFacet_Link
+	RichtextFacet_Bold          *RichtextFacet_Bold
+	RichtextFacet_Italic        *RichtextFacet_Italic
+	RichtextFacet_Strikethrough *RichtextFacet_Strikethrough
+	RichtextFacet_Spoiler       *RichtextFacet_Spoiler
+}
+
+func (t *RichtextFacet_Features_Elem) MarshalJSON() ([]byte, error) {
+	if t.RichtextFacet_Mention != nil {
+		t.RichtextFacet_Mention.LexiconTypeID = "social.coves.richtext.facet#mention"
+		return json.Marshal(t.RichtextFacet_Mention)
+	}
+	if t.RichtextFacet_Link != nil {
+		t.RichtextFacet_Link.LexiconTypeID = "social.coves.richtext.facet#link"
+		return json.Marshal(t.RichtextFacet_Link)
+	}
+	if t.RichtextFacet_Bold != nil {
+		t.RichtextFacet_Bold.LexiconTypeID = "social.coves.richtext.facet#bold"
+		return json.Marshal(t.RichtextFacet_Bold)
+	}
+	if t.RichtextFacet_Italic != nil {
+		t.RichtextFacet_Italic.LexiconTypeID = "social.coves.richtext.facet#italic"
+		return json.Marshal(t.RichtextFacet_Italic)
+	}
+	if t.RichtextFacet_Strikethrough != nil {
+		t.RichtextFacet_Strikethrough.LexiconTypeID = "social.coves.richtext.facet#strikethrough"
+		return json.Marshal(t.RichtextFacet_Strikethrough)
+	}
+	if t.RichtextFacet_Spoiler != nil {
+		t.RichtextFacet_Spoiler.LexiconTypeID = "social.coves.richtext.facet#spoiler"
+		return json.Marshal(t.RichtextFacet_Spoiler)
+	}
+	return nil, fmt.Errorf("cannot marshal empty enum")
+}
+
+func (t *RichtextFacet_Features_Elem) UnmarshalJSON(b []byte) error {
+	typ, err := util.TypeExtract(b)
+	if err != nil {
+		return err
+	}
+
+	switch typ {
+	case "social.coves.richtext.facet#mention":
+		t.RichtextFacet_Mention = new(RichtextFacet_Mention)
+		return json.Unmarshal(b, t.RichtextFacet_Mention)
+	case "social.coves.richtext.facet#link":
+		t.RichtextFacet_Link = new(RichtextFacet_Link)
+		return json.Unmarshal(b, t.RichtextFacet_Link)
+	case "social.coves.richtext.facet#bold":
+		t.RichtextFacet_Bold = new(RichtextFacet_Bold)
+		return json.Unmarshal(b, t.RichtextFacet_Bold)
+	case "social.coves.richtext.facet#italic":
+		t.RichtextFacet_Italic = new(RichtextFacet_Italic)
+		return json.Unmarshal(b, t.RichtextFacet_Italic)
+	case "social.coves.richtext.facet#strikethrough":
+		t.RichtextFacet_Strikethrough = new(RichtextFacet_Strikethrough)
+		return json.Unmarshal(b, t.RichtextFacet_Strikethrough)
+	case "social.coves.richtext.facet#spoiler":
+		t.RichtextFacet_Spoiler = new(RichtextFacet_Spoiler)
+		return json.Unmarshal(b, t.RichtextFacet_Spoiler)
+	default:
+		return nil
+	}
+}
+
+func (t *RichtextFacet_Features_Elem) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if t.RichtextFacet_Mention != nil {
+		return t.RichtextFacet_Mention.MarshalCBOR(w)
+	}
+	if t.RichtextFacet_Link != nil {
+		return t.RichtextFacet_Link.MarshalCBOR(w)
+	}
+	if t.RichtextFacet_Bold != nil {
+		return t.RichtextFacet_Bold.MarshalCBOR(w)
+	}
+	if t.RichtextFacet_Italic != nil {
+		return t.RichtextFacet_Italic.MarshalCBOR(w)
+	}
+	if t.RichtextFacet_Strikethrough != nil {
+		return t.RichtextFacet_Strikethrough.MarshalCBOR(w)
+	}
+	if t.RichtextFacet_Spoiler != nil {
+		return t.RichtextFacet_Spoiler.MarshalCBOR(w)
+	}
+	return fmt.Errorf("cannot cbor marshal empty enum")
+}
+
+func (t *RichtextFacet_Features_Elem) UnmarshalCBOR(r io.Reader) error {
+	typ, b, err := util.CborTypeExtractReader(r)
+	if err != nil {
+		return err
+	}
+
+	switch typ {
+	case "social.coves.richtext.facet#mention":
+		t.RichtextFacet_Mention = new(RichtextFacet_Mention)
+		return t.RichtextFacet_Mention.UnmarshalCBOR(bytes.NewReader(b))
+	case "social.coves.richtext.facet#link":
+		t.RichtextFacet_Link = new(RichtextFacet_Link)
+		return t.RichtextFacet_Link.UnmarshalCBOR(bytes.NewReader(b))
+	case "social.coves.richtext.facet#bold":
+		t.RichtextFacet_Bold = new(RichtextFacet_Bold)
+		return t.RichtextFacet_Bold.UnmarshalCBOR(bytes.NewReader(b))
+	case "social.coves.richtext.facet#italic":
+		t.RichtextFacet_Italic = new(RichtextFacet_Italic)
+		return t.RichtextFacet_Italic.UnmarshalCBOR(bytes.NewReader(b))
+	case "social.coves.richtext.facet#strikethrough":
+		t.RichtextFacet_Strikethrough = new(RichtextFacet_Strikethrough)
+		return t.RichtextFacet_Strikethrough.UnmarshalCBOR(bytes.NewReader(b))
+	case "social.coves.richtext.facet#spoiler":
+		t.RichtextFacet_Spoiler = new(RichtextFacet_Spoiler)
+		return t.RichtextFacet_Spoiler.UnmarshalCBOR(bytes.NewReader(b))
+	default:
+		return nil
+	}
+}
+
+// RichtextFacet_Italic is a "italic" in the social.coves.richtext.facet schema.
+//
+// Italic text formatting
+type RichtextFacet_Italic struct {
+	LexiconTypeID string `json:"$type,const=social.coves.richtext.facet#italic" cborgen:"$type,const=social.coves.richtext.facet#italic"`
+}
+
+// RichtextFacet_Link is a "link" in the social.coves.richtext.facet schema.
+//
+// Facet feature for hyperlinks
+type RichtextFacet_Link struct {
+	LexiconTypeID string `json:"$type,const=social.coves.richtext.facet#link" cborgen:"$type,const=social.coves.richtext.facet#link"`
+	// uri: Target URI of the link
+	Uri string `json:"uri" cborgen:"uri"`
+}
+
+// RichtextFacet_Mention is a "mention" in the social.coves.richtext.facet schema.
+//
+// Facet feature for user or community mentions
+type RichtextFacet_Mention struct {
+	LexiconTypeID string `json:"$type,const=social.coves.richtext.facet#mention" cborgen:"$type,const=social.coves.richtext.facet#mention"`
+	// did: DID of the mentioned user (@) or community (!)
+	Did string `json:"did" cborgen:"did"`
+	// handle: Handle at time of mention (may change)
+	Handle *string `json:"handle,omitempty" cborgen:"handle,omitempty"`
+}
+
+// RichtextFacet_Spoiler is a "spoiler" in the social.coves.richtext.facet schema.
+//
+// Hidden/spoiler text that requires user interaction to reveal
+type RichtextFacet_Spoiler struct {
+	LexiconTypeID string `json:"$type,const=social.coves.richtext.facet#spoiler" cborgen:"$type,const=social.coves.richtext.facet#spoiler"`
+	// reason: Optional explanation of what's hidden
+	Reason *string `json:"reason,omitempty" cborgen:"reason,omitempty"`
+}
+
+// RichtextFacet_Strikethrough is a "strikethrough" in the social.coves.richtext.facet schema.
+//
+// Strikethrough text formatting
+type RichtextFacet_Strikethrough struct {
+	LexiconTypeID string `json:"$type,const=social.coves.richtext.facet#strikethrough" cborgen:"$type,const=social.coves.richtext.facet#strikethrough"`
+}
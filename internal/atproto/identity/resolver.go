@@ -0,0 +1,44 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bluesky-social/indigo/atproto/crypto"
+	atid "github.com/bluesky-social/indigo/atproto/identity"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+)
+
+// DirectoryResolver resolves a DID's repository signing key from its DID
+// document, using Indigo's identity directory (PLC and did:web)
+type DirectoryResolver struct {
+	dir atid.Directory
+}
+
+// NewDirectoryResolver creates a resolver backed by the given directory.
+// Use atid.DefaultDirectory() for a cached directory that talks to plc.directory.
+func NewDirectoryResolver(dir atid.Directory) *DirectoryResolver {
+	return &DirectoryResolver{
+		dir: dir,
+	}
+}
+
+// ResolveSigningKey returns the atproto signing key published in the DID document
+func (r *DirectoryResolver) ResolveSigningKey(ctx context.Context, did string) (crypto.PublicKey, error) {
+	parsed, err := syntax.ParseDID(did)
+	if err != nil {
+		return nil, fmt.Errorf("parsing DID %s: %w", did, err)
+	}
+
+	ident, err := r.dir.LookupDID(ctx, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("resolving DID %s: %w", did, err)
+	}
+
+	key, err := ident.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("getting signing key for DID %s: %w", did, err)
+	}
+
+	return key, nil
+}
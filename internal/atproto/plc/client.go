@@ -0,0 +1,89 @@
+package plc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultDirectoryURL is the public PLC directory
+const DefaultDirectoryURL = "https://plc.directory"
+
+// Client talks to a PLC directory
+type Client struct {
+	url  string
+	http *http.Client
+}
+
+// NewClient creates a client for the directory at directoryURL
+func NewClient(directoryURL string) *Client {
+	return &Client{
+		url:  strings.TrimSuffix(directoryURL, "/"),
+		http: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// LastOperation returns the latest operation in a DID's log
+func (c *Client) LastOperation(ctx context.Context, did string) (*Operation, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/"+url.PathEscape(did)+"/log/last", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching plc log for %s: %w", did, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching plc log for %s: %w", did, responseError(resp))
+	}
+
+	var op Operation
+	if err := json.NewDecoder(resp.Body).Decode(&op); err != nil {
+		return nil, fmt.Errorf("decoding plc operation for %s: %w", did, err)
+	}
+	return &op, nil
+}
+
+// Submit sends a signed operation to the directory, which checks it
+// against the DID's log before applying it
+func (c *Client) Submit(ctx context.Context, did string, op *Operation) error {
+	body, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("encoding plc operation: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"/"+url.PathEscape(did), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("submitting plc operation for %s: %w", did, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("submitting plc operation for %s: %w", did, responseError(resp))
+	}
+	return nil
+}
+
+// responseError describes a failed directory response
+func responseError(resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	var body struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(msg, &body) == nil && body.Message != "" {
+		return fmt.Errorf("directory returned %d: %s", resp.StatusCode, body.Message)
+	}
+	return fmt.Errorf("directory returned %d", resp.StatusCode)
+}
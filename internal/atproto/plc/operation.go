@@ -0,0 +1,140 @@
+// Package plc builds, signs and submits did:plc operations. An operation
+// replaces a DID's rotation keys, signing key, handles and services; it is
+// signed by one of the rotation keys of the operation before it and sent to
+// the PLC directory, which keeps each DID's log.
+package plc
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/bluesky-social/indigo/atproto/crypto"
+	atdata "github.com/bluesky-social/indigo/atproto/data"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+)
+
+// Operation types in a DID's log
+const (
+	TypeOperation = "plc_operation"
+	TypeTombstone = "plc_tombstone"
+	TypeLegacy    = "create" // The original genesis format
+)
+
+// Names of the signing key and PDS service entries atproto uses
+const (
+	VerificationMethodAtproto = "atproto"
+	ServiceAtprotoPDS         = "atproto_pds"
+	ServiceTypeAtprotoPDS     = "AtprotoPersonalDataServer"
+)
+
+// ErrInvalidSignature is returned when no rotation key verifies an operation
+var ErrInvalidSignature = errors.New("invalid plc operation signature")
+
+// Service is a service endpoint in a DID document
+type Service struct {
+	Type     string `json:"type"`
+	Endpoint string `json:"endpoint"`
+}
+
+// Operation is a did:plc operation, as the directory serves and accepts it
+type Operation struct {
+	Type                string             `json:"type"`
+	RotationKeys        []string           `json:"rotationKeys"`
+	VerificationMethods map[string]string  `json:"verificationMethods"`
+	AlsoKnownAs         []string           `json:"alsoKnownAs"`
+	Services            map[string]Service `json:"services"`
+	Prev                *string            `json:"prev"` // CID of the previous operation; nil for a genesis operation
+	Sig                 string             `json:"sig,omitempty"`
+}
+
+// fields returns the operation as generic data, with the signature if it
+// has one and signed is set
+func (op *Operation) fields(signed bool) map[string]any {
+	rotationKeys := make([]any, len(op.RotationKeys))
+	for i, k := range op.RotationKeys {
+		rotationKeys[i] = k
+	}
+	alsoKnownAs := make([]any, len(op.AlsoKnownAs))
+	for i, aka := range op.AlsoKnownAs {
+		alsoKnownAs[i] = aka
+	}
+	verificationMethods := make(map[string]any, len(op.VerificationMethods))
+	for name, key := range op.VerificationMethods {
+		verificationMethods[name] = key
+	}
+	services := make(map[string]any, len(op.Services))
+	for name, svc := range op.Services {
+		services[name] = map[string]any{"type": svc.Type, "endpoint": svc.Endpoint}
+	}
+
+	fields := map[string]any{
+		"type":                op.Type,
+		"rotationKeys":        rotationKeys,
+		"verificationMethods": verificationMethods,
+		"alsoKnownAs":         alsoKnownAs,
+		"services":            services,
+		"prev":                nil,
+	}
+	if op.Prev != nil {
+		fields["prev"] = *op.Prev
+	}
+	if signed && op.Sig != "" {
+		fields["sig"] = op.Sig
+	}
+	return fields
+}
+
+// UnsignedCBOR returns the DAG-CBOR bytes a signature covers: the operation
+// without its sig field
+func (op *Operation) UnsignedCBOR() ([]byte, error) {
+	return atdata.MarshalCBOR(op.fields(false))
+}
+
+// Sign signs the operation with key, replacing any signature it had
+func (op *Operation) Sign(key crypto.PrivateKey) error {
+	data, err := op.UnsignedCBOR()
+	if err != nil {
+		return fmt.Errorf("encoding plc operation: %w", err)
+	}
+	sig, err := key.HashAndSign(data)
+	if err != nil {
+		return fmt.Errorf("signing plc operation: %w", err)
+	}
+	op.Sig = base64.RawURLEncoding.EncodeToString(sig)
+	return nil
+}
+
+// Verify checks the operation is signed by one of rotationKeys, the did:key
+// rotation keys of the operation before it
+func (op *Operation) Verify(rotationKeys []string) error {
+	sig, err := base64.RawURLEncoding.DecodeString(op.Sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	data, err := op.UnsignedCBOR()
+	if err != nil {
+		return fmt.Errorf("encoding plc operation: %w", err)
+	}
+	for _, k := range rotationKeys {
+		pub, err := crypto.ParsePublicDIDKey(k)
+		if err != nil {
+			continue
+		}
+		if pub.HashAndVerify(data, sig) == nil {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// CID returns the CID of the signed operation, which the next operation
+// names as its prev
+func (op *Operation) CID() (cid.Cid, error) {
+	data, err := atdata.MarshalCBOR(op.fields(true))
+	if err != nil {
+		return cid.Undef, fmt.Errorf("encoding plc operation: %w", err)
+	}
+	return cid.V1Builder{Codec: cid.DagCBOR, MhType: multihash.SHA2_256}.Sum(data)
+}
@@ -0,0 +1,157 @@
+package plc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"Coves/internal/atproto/plc"
+
+	"github.com/bluesky-social/indigo/atproto/crypto"
+)
+
+func newKey(t *testing.T) crypto.PrivateKeyExportable {
+	t.Helper()
+	key, err := crypto.GeneratePrivateKeyK256()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	return key
+}
+
+func didKey(t *testing.T, key crypto.PrivateKey) string {
+	t.Helper()
+	pub, err := key.PublicKey()
+	if err != nil {
+		t.Fatalf("Failed to get public key: %v", err)
+	}
+	return pub.DIDKey()
+}
+
+func newOperation(t *testing.T, rotation crypto.PrivateKey, prev *string) *plc.Operation {
+	t.Helper()
+	return &plc.Operation{
+		Type:                plc.TypeOperation,
+		RotationKeys:        []string{didKey(t, rotation)},
+		VerificationMethods: map[string]string{plc.VerificationMethodAtproto: didKey(t, newKey(t))},
+		AlsoKnownAs:         []string{"at://alice.example.com"},
+		Services: map[string]plc.Service{
+			plc.ServiceAtprotoPDS: {Type: plc.ServiceTypeAtprotoPDS, Endpoint: "https://pds.example.com"},
+		},
+		Prev: prev,
+	}
+}
+
+func TestOperation_SignAndVerify(t *testing.T) {
+	rotation := newKey(t)
+	op := newOperation(t, rotation, nil)
+	if err := op.Sign(rotation); err != nil {
+		t.Fatalf("Failed to sign operation: %v", err)
+	}
+	if strings.ContainsAny(op.Sig, "=+/") {
+		t.Errorf("Expected an unpadded base64url signature, got %s", op.Sig)
+	}
+
+	if err := op.Verify(op.RotationKeys); err != nil {
+		t.Errorf("Expected the signature to verify: %v", err)
+	}
+	if err := op.Verify([]string{didKey(t, newKey(t))}); !errors.Is(err, plc.ErrInvalidSignature) {
+		t.Errorf("Expected another key to be refused, got %v", err)
+	}
+
+	// The signature covers every field
+	op.AlsoKnownAs = []string{"at://mallory.example.com"}
+	if err := op.Verify(op.RotationKeys); !errors.Is(err, plc.ErrInvalidSignature) {
+		t.Errorf("Expected a changed operation to be refused, got %v", err)
+	}
+}
+
+func TestOperation_CID(t *testing.T) {
+	rotation := newKey(t)
+	op := newOperation(t, rotation, nil)
+	if err := op.Sign(rotation); err != nil {
+		t.Fatalf("Failed to sign operation: %v", err)
+	}
+
+	genesis, err := op.CID()
+	if err != nil {
+		t.Fatalf("Failed to compute CID: %v", err)
+	}
+	if !strings.HasPrefix(genesis.String(), "bafyrei") {
+		t.Errorf("Expected a dag-cbor CID, got %s", genesis)
+	}
+
+	// The CID survives the JSON the directory serves
+	data, err := json.Marshal(op)
+	if err != nil {
+		t.Fatalf("Failed to encode operation: %v", err)
+	}
+	var decoded plc.Operation
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode operation: %v", err)
+	}
+	if again, err := decoded.CID(); err != nil || again != genesis {
+		t.Errorf("Expected CID %s after a round trip, got %s %v", genesis, again, err)
+	}
+	if !strings.Contains(string(data), `"prev":null`) {
+		t.Errorf("Expected a genesis operation to send a null prev: %s", data)
+	}
+
+	prev := genesis.String()
+	next := newOperation(t, rotation, &prev)
+	if err := next.Sign(rotation); err != nil {
+		t.Fatalf("Failed to sign operation: %v", err)
+	}
+	if c, _ := next.CID(); c == genesis {
+		t.Error("Expected operations to have different CIDs")
+	}
+}
+
+func TestClient(t *testing.T) {
+	rotation := newKey(t)
+	last := newOperation(t, rotation, nil)
+	if err := last.Sign(rotation); err != nil {
+		t.Fatalf("Failed to sign operation: %v", err)
+	}
+
+	var submitted *plc.Operation
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/did:plc:alice/log/last":
+			json.NewEncoder(w).Encode(last)
+		case r.Method == http.MethodPost && r.URL.Path == "/did:plc:alice":
+			submitted = &plc.Operation{}
+			json.NewDecoder(r.Body).Decode(submitted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"DID not registered"}`))
+		}
+	}))
+	defer srv.Close()
+
+	client := plc.NewClient(srv.URL + "/")
+	ctx := context.Background()
+
+	got, err := client.LastOperation(ctx, "did:plc:alice")
+	if err != nil {
+		t.Fatalf("Failed to fetch last operation: %v", err)
+	}
+	if err := got.Verify(last.RotationKeys); err != nil {
+		t.Errorf("Expected the fetched operation to verify: %v", err)
+	}
+
+	if err := client.Submit(ctx, "did:plc:alice", got); err != nil {
+		t.Fatalf("Failed to submit operation: %v", err)
+	}
+	if submitted == nil || submitted.Sig != last.Sig {
+		t.Errorf("Expected the operation to be submitted, got %+v", submitted)
+	}
+
+	if _, err := client.LastOperation(ctx, "did:plc:bob"); err == nil || !strings.Contains(err.Error(), "DID not registered") {
+		t.Errorf("Expected the directory's error, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	coreerrors "Coves/internal/core/errors"
+
+	"github.com/bluesky-social/indigo/mst"
+	"github.com/bluesky-social/indigo/repo"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	car "github.com/ipld/go-car"
+	carutil "github.com/ipld/go-car/util"
+)
+
+// RecordProof returns the blocks that prove a record's inclusion at the
+// wrapper's head: the signed commit, every MST node on the path to the
+// record, and the record itself.
+func (w *Wrapper) RecordProof(collection string, recordKey string) ([]blocks.Block, error) {
+	if !w.head.Defined() {
+		return nil, fmt.Errorf("repository has no commits")
+	}
+
+	// Re-open the repo on a recording blockstore so that the MST is read from
+	// scratch and every block on the lookup path is captured
+	rec := &recordingBlockstore{Blockstore: w.blockstore}
+	r, err := repo.OpenRepo(context.Background(), rec, w.head)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repo at %s: %w", w.head, err)
+	}
+
+	path := fmt.Sprintf("%s/%s", collection, recordKey)
+	if _, _, err := r.GetRecordBytes(context.Background(), path); err != nil {
+		if errors.Is(err, mst.ErrNotFound) {
+			return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRecord, path)
+		}
+		return nil, fmt.Errorf("failed to get record: %w", err)
+	}
+
+	return rec.blocks, nil
+}
+
+// WriteCAR writes blks to out as a CAR file with the given root
+func WriteCAR(out io.Writer, root cid.Cid, blks []blocks.Block) error {
+	if err := car.WriteHeader(&car.CarHeader{Roots: []cid.Cid{root}, Version: 1}, out); err != nil {
+		return fmt.Errorf("failed to write CAR header: %w", err)
+	}
+	for _, blk := range blks {
+		if err := carutil.LdWrite(out, blk.Cid().Bytes(), blk.RawData()); err != nil {
+			return fmt.Errorf("failed to write block %s: %w", blk.Cid(), err)
+		}
+	}
+	return nil
+}
+
+// recordingBlockstore remembers each distinct block read through it, in order
+type recordingBlockstore struct {
+	blockstore.Blockstore
+
+	mu     sync.Mutex
+	seen   map[cid.Cid]bool
+	blocks []blocks.Block
+}
+
+func (r *recordingBlockstore) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	blk, err := r.Blockstore.Get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.seen == nil {
+		r.seen = make(map[cid.Cid]bool)
+	}
+	if !r.seen[c] {
+		r.seen[c] = true
+		r.blocks = append(r.blocks, blk)
+	}
+	return blk, nil
+}
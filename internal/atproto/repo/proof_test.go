@@ -0,0 +1,72 @@
+package repo_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	atrepo "Coves/internal/atproto/repo"
+
+	"github.com/bluesky-social/indigo/atproto/crypto"
+	"github.com/bluesky-social/indigo/repo"
+	car "github.com/ipld/go-car"
+)
+
+func TestRecordProof(t *testing.T) {
+	ctx := context.Background()
+	priv, err := crypto.GeneratePrivateKeyK256()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	w, err := atrepo.NewWrapper("did:plc:prooftest", newBlockstore())
+	if err != nil {
+		t.Fatalf("Failed to create repo: %v", err)
+	}
+	// Enough records for a multi-level MST
+	var rkey string
+	for i := 0; i < 50; i++ {
+		if _, rkey, err = w.CreateRecord("social.coves.test.record", "", &textRecord{Text: fmt.Sprint(i)}); err != nil {
+			t.Fatalf("Failed to create record: %v", err)
+		}
+	}
+	if _, err := w.Commit(func(ctx context.Context, did string, data []byte) ([]byte, error) {
+		return priv.HashAndSign(data)
+	}); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	head, err := w.GetHeadCID()
+	if err != nil {
+		t.Fatalf("Failed to get head: %v", err)
+	}
+
+	blks, err := w.RecordProof("social.coves.test.record", rkey)
+	if err != nil {
+		t.Fatalf("Failed to build proof: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := atrepo.WriteCAR(&buf, head, blks); err != nil {
+		t.Fatalf("Failed to write CAR: %v", err)
+	}
+
+	// The proof alone must be enough to look the record up from the commit
+	bs := newBlockstore()
+	header, err := car.LoadCar(ctx, bs, &buf)
+	if err != nil {
+		t.Fatalf("Failed to load proof CAR: %v", err)
+	}
+	proven, err := repo.OpenRepo(ctx, bs, header.Roots[0])
+	if err != nil {
+		t.Fatalf("Failed to open repo from proof: %v", err)
+	}
+	if _, _, err := proven.GetRecordBytes(ctx, "social.coves.test.record/"+rkey); err != nil {
+		t.Errorf("Failed to get record from proof: %v", err)
+	}
+
+	if _, err := w.RecordProof("social.coves.test.record", "missing"); !errors.Is(err, atrepo.ErrRecordNotFound) {
+		t.Errorf("Expected ErrRecordNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package repo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	atdata "github.com/bluesky-social/indigo/atproto/data"
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// GenericRecord is a record held in the atproto data model rather than as a
+// generated Go type. It converts losslessly between the JSON and DAG-CBOR
+// forms of a record: integers stay integers, CID links map to {"$link": ...},
+// bytes to {"$bytes": ...}, and blob references keep their shape. Floats are
+// not part of the data model and are rejected.
+type GenericRecord struct {
+	value map[string]any
+}
+
+// ParseRecordJSON parses a record from its JSON form
+func ParseRecordJSON(b []byte) (*GenericRecord, error) {
+	value, err := atdata.UnmarshalJSON(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid record: %w", err)
+	}
+	return &GenericRecord{value: value}, nil
+}
+
+// ParseRecordCBOR parses a record from its DAG-CBOR form
+func ParseRecordCBOR(b []byte) (*GenericRecord, error) {
+	value, err := atdata.UnmarshalCBOR(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid record: %w", err)
+	}
+	return &GenericRecord{value: value}, nil
+}
+
+// RecordJSONToCBOR converts a record's JSON form to DAG-CBOR
+func RecordJSONToCBOR(b []byte) ([]byte, error) {
+	rec, err := ParseRecordJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if err := rec.MarshalCBOR(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RecordCBORToJSON converts a stored DAG-CBOR record to its JSON form
+func RecordCBORToJSON(b []byte) (json.RawMessage, error) {
+	rec, err := ParseRecordCBOR(b)
+	if err != nil {
+		return nil, err
+	}
+	return rec.MarshalJSON()
+}
+
+// Value returns the record's fields in the atproto data model, with
+// atdata.CIDLink, atdata.Bytes and atdata.Blob for links, bytes and blobs
+func (r *GenericRecord) Value() map[string]any {
+	return r.value
+}
+
+// MarshalCBOR writes the record as canonical DAG-CBOR: map keys are sorted
+// length-first and integers use their shortest encoding, so equal records
+// always encode to the same bytes and CID
+func (r *GenericRecord) MarshalCBOR(w io.Writer) error {
+	b, err := cbornode.DumpObject(cborValue(r.value))
+	if err != nil {
+		return fmt.Errorf("encoding record: %w", err)
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// UnmarshalCBOR reads a record from DAG-CBOR. It consumes exactly one item,
+// so it can be embedded in cbor-gen types.
+func (r *GenericRecord) UnmarshalCBOR(rd io.Reader) error {
+	var raw cbg.Deferred
+	if err := raw.UnmarshalCBOR(rd); err != nil {
+		return fmt.Errorf("invalid record: %w", err)
+	}
+	rec, err := ParseRecordCBOR(raw.Raw)
+	if err != nil {
+		return err
+	}
+	r.value = rec.value
+	return nil
+}
+
+// MarshalJSON writes the record as JSON
+func (r *GenericRecord) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.value)
+}
+
+// UnmarshalJSON reads a record from JSON
+func (r *GenericRecord) UnmarshalJSON(b []byte) error {
+	rec, err := ParseRecordJSON(b)
+	if err != nil {
+		return err
+	}
+	r.value = rec.value
+	return nil
+}
+
+// cborValue converts a data model value into the shapes go-ipld-cbor
+// encodes as DAG-CBOR links, bytes and blobs
+func cborValue(v any) any {
+	switch v := v.(type) {
+	case atdata.CIDLink:
+		return cid.Cid(v)
+	case atdata.Bytes:
+		return []byte(v)
+	case atdata.Blob:
+		if v.Size < 0 {
+			// Legacy blobs have no size and a string CID
+			return map[string]any{
+				"cid":      v.Ref.String(),
+				"mimeType": v.MimeType,
+			}
+		}
+		return map[string]any{
+			"$type":    "blob",
+			"ref":      cid.Cid(v.Ref),
+			"mimeType": v.MimeType,
+			"size":     v.Size,
+		}
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			out[k] = cborValue(val)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = cborValue(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,124 @@
+package repo_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	atrepo "Coves/internal/atproto/repo"
+
+	atdata "github.com/bluesky-social/indigo/atproto/data"
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+)
+
+const recordCID = "bafyreie5737gdxlw5i64vzichcalba3z2v5n6icifvx5xytvske7mr3hpm"
+
+func TestGenericRecord_RoundTrip(t *testing.T) {
+	in := `{
+		"$type": "social.coves.post.record",
+		"count": 9007199254740991,
+		"negative": -42,
+		"subject": {"$link": "` + recordCID + `"},
+		"data": {"$bytes": "aGVsbG8"},
+		"image": {"$type": "blob", "ref": {"$link": "` + recordCID + `"}, "mimeType": "image/png", "size": 1234},
+		"legacy": {"cid": "` + recordCID + `", "mimeType": "image/jpeg"},
+		"nested": [{"index": {"byteStart": 0, "byteEnd": 5}}, null, true]
+	}`
+
+	cborBytes, err := atrepo.RecordJSONToCBOR([]byte(in))
+	if err != nil {
+		t.Fatalf("Failed to encode record: %v", err)
+	}
+
+	// The CBOR holds real integers, links and bytes, not their JSON forms
+	var decoded map[string]interface{}
+	if err := cbornode.DecodeInto(cborBytes, &decoded); err != nil {
+		t.Fatalf("Failed to decode CBOR: %v", err)
+	}
+	if _, ok := decoded["count"].(float64); ok {
+		t.Error("Expected count to be encoded as an integer")
+	}
+	if c, ok := decoded["subject"].(cid.Cid); !ok || c.String() != recordCID {
+		t.Errorf("Expected subject to be a CID link, got %#v", decoded["subject"])
+	}
+	if b, ok := decoded["data"].([]byte); !ok || string(b) != "hello" {
+		t.Errorf("Expected data to be bytes, got %#v", decoded["data"])
+	}
+
+	out, err := atrepo.RecordCBORToJSON(cborBytes)
+	if err != nil {
+		t.Fatalf("Failed to convert record to JSON: %v", err)
+	}
+	var want, got interface{}
+	if err := json.Unmarshal([]byte(in), &want); err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("Converted record is not valid JSON: %v\n%s", err, out)
+	}
+	wantJSON, _ := json.Marshal(want)
+	gotJSON, _ := json.Marshal(got)
+	if !bytes.Equal(wantJSON, gotJSON) {
+		t.Errorf("Round trip changed the record:\nwant %s\ngot  %s", wantJSON, gotJSON)
+	}
+
+	// Converting back yields the same bytes, so the CID is stable
+	again, err := atrepo.RecordJSONToCBOR(out)
+	if err != nil {
+		t.Fatalf("Failed to re-encode record: %v", err)
+	}
+	if !bytes.Equal(again, cborBytes) {
+		t.Error("Expected re-encoding the JSON to reproduce the CBOR")
+	}
+}
+
+func TestGenericRecord_Canonical(t *testing.T) {
+	a, err := atrepo.RecordJSONToCBOR([]byte(`{"text": "hi", "$type": "a.b.c", "n": 1, "bb": {"z": 1, "a": 2}}`))
+	if err != nil {
+		t.Fatalf("Failed to encode record: %v", err)
+	}
+	b, err := atrepo.RecordJSONToCBOR([]byte(`{"bb": {"a": 2, "z": 1}, "n": 1, "$type": "a.b.c", "text": "hi"}`))
+	if err != nil {
+		t.Fatalf("Failed to encode record: %v", err)
+	}
+	if !bytes.Equal(a, b) {
+		t.Error("Expected key order not to affect the encoding")
+	}
+
+	// The encoding matches go-ipld-cbor's, so the CID is what the block store computes
+	node, err := cbornode.WrapObject(map[string]interface{}{
+		"$type": "a.b.c",
+		"text":  "hi",
+		"n":     1,
+		"bb":    map[string]interface{}{"a": 2, "z": 1},
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatalf("Failed to wrap object: %v", err)
+	}
+	if !bytes.Equal(node.RawData(), a) {
+		t.Error("Expected canonical DAG-CBOR encoding")
+	}
+}
+
+func TestGenericRecord_Invalid(t *testing.T) {
+	for name, in := range map[string]string{
+		"float":     `{"$type": "a.b.c", "n": 1.5}`,
+		"bad link":  `{"$type": "a.b.c", "l": {"$link": "nope"}}`,
+		"bad bytes": `{"$type": "a.b.c", "b": {"$bytes": 5}}`,
+		"not json":  `{"$type": `,
+	} {
+		if _, err := atrepo.ParseRecordJSON([]byte(in)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	rec, err := atrepo.ParseRecordJSON([]byte(`{"$type": "a.b.c", "l": {"$link": "` + recordCID + `"}}`))
+	if err != nil {
+		t.Fatalf("Failed to parse record: %v", err)
+	}
+	if _, ok := rec.Value()["l"].(atdata.CIDLink); !ok {
+		t.Errorf("Expected a CIDLink value, got %T", rec.Value()["l"])
+	}
+}
@@ -0,0 +1,174 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bluesky-social/indigo/atproto/crypto"
+	"github.com/bluesky-social/indigo/repo"
+	"github.com/ipfs/go-cid"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	car "github.com/ipld/go-car"
+)
+
+var (
+	// ErrInvalidCAR is returned when imported CAR data is malformed, has a
+	// block that doesn't match its CID, or has a commit that fails verification
+	ErrInvalidCAR = errors.New("invalid CAR")
+
+	// ErrImportTooLarge is returned when imported CAR data exceeds ImportLimits
+	ErrImportTooLarge = errors.New("import exceeds limits")
+)
+
+// ImportLimits bounds the size of a CAR import
+type ImportLimits struct {
+	MaxBytes  int64 // Maximum size of the CAR stream
+	MaxBlocks int   // Maximum number of blocks in the CAR
+}
+
+// DefaultImportLimits are used when no other limits are configured. Blocks
+// are held in memory until the whole CAR verifies, so they are kept modest;
+// servers that take in large repositories raise them.
+var DefaultImportLimits = ImportLimits{
+	MaxBytes:  64 << 20,
+	MaxBlocks: 100_000,
+}
+
+// VerifiedCAR describes a repository that passed VerifyCAR
+type VerifiedCAR struct {
+	Root        cid.Cid           // CID of the signed commit
+	Commit      repo.SignedCommit // The verified commit
+	Blocks      int               // Number of blocks read
+	Bytes       int64             // Total size of block data
+	Collections map[string]int    // Record count per collection
+}
+
+// RecordCount returns the total number of records across all collections
+func (v *VerifiedCAR) RecordCount() int {
+	n := 0
+	for _, count := range v.Collections {
+		n += count
+	}
+	return n
+}
+
+// VerifyCAR streams a full repository CAR from r into bs. Every block's CID
+// is recomputed from its data, the commit must be for did and signed by key,
+// and the MST is walked to count records. Blocks are written to bs as they
+// are read, so callers should only persist bs once VerifyCAR succeeds.
+func VerifyCAR(ctx context.Context, r io.Reader, bs blockstore.Blockstore, did string, key crypto.PublicKey, limits ImportLimits) (*VerifiedCAR, error) {
+	lr := &limitedReader{r: r, limit: limits.MaxBytes, remaining: limits.MaxBytes}
+	cr, err := car.NewCarReader(lr)
+	if err != nil {
+		return nil, lr.wrapError(err)
+	}
+	if len(cr.Header.Roots) != 1 {
+		return nil, fmt.Errorf("%w: expected 1 root, got %d", ErrInvalidCAR, len(cr.Header.Roots))
+	}
+
+	v := &VerifiedCAR{
+		Root:        cr.Header.Roots[0],
+		Collections: make(map[string]int),
+	}
+
+	for {
+		blk, err := cr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, lr.wrapError(err)
+		}
+
+		v.Blocks++
+		if v.Blocks > limits.MaxBlocks {
+			return nil, fmt.Errorf("%w: more than %d blocks", ErrImportTooLarge, limits.MaxBlocks)
+		}
+
+		computed, err := blk.Cid().Prefix().Sum(blk.RawData())
+		if err != nil {
+			return nil, fmt.Errorf("%w: hashing block %s: %v", ErrInvalidCAR, blk.Cid(), err)
+		}
+		if !computed.Equals(blk.Cid()) {
+			return nil, fmt.Errorf("%w: block %s hashes to %s", ErrInvalidCAR, blk.Cid(), computed)
+		}
+
+		if err := bs.Put(ctx, blk); err != nil {
+			return nil, fmt.Errorf("storing block %s: %w", blk.Cid(), err)
+		}
+		v.Bytes += int64(len(blk.RawData()))
+	}
+
+	r2, err := repo.OpenRepo(ctx, bs, v.Root)
+	if err != nil {
+		return nil, fmt.Errorf("%w: loading commit %s: %v", ErrInvalidCAR, v.Root, err)
+	}
+	v.Commit = r2.SignedCommit()
+
+	if v.Commit.Did != did {
+		return nil, fmt.Errorf("%w: commit is for %s, not %s", ErrInvalidCAR, v.Commit.Did, did)
+	}
+	if v.Commit.Rev == "" {
+		return nil, fmt.Errorf("%w: commit has no revision", ErrInvalidCAR)
+	}
+
+	unsigned, err := v.Commit.Unsigned().BytesForSigning()
+	if err != nil {
+		return nil, fmt.Errorf("encoding commit for verification: %w", err)
+	}
+	if err := key.HashAndVerify(unsigned, v.Commit.Sig); err != nil {
+		return nil, fmt.Errorf("%w: commit signature: %v", ErrInvalidCAR, err)
+	}
+
+	// Walking the MST also proves every tree node is present
+	err = r2.ForEach(ctx, "", func(k string, _ cid.Cid) error {
+		collection, _, ok := strings.Cut(k, "/")
+		if !ok {
+			return fmt.Errorf("malformed record path %q", k)
+		}
+		v.Collections[collection]++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: walking records: %v", ErrInvalidCAR, err)
+	}
+
+	return v, nil
+}
+
+// limitedReader fails with ErrImportTooLarge once more than limit bytes have
+// been read
+type limitedReader struct {
+	r         io.Reader
+	limit     int64
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, ErrImportTooLarge
+	}
+	// Read one byte past the limit so hitting it exactly isn't an error
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, ErrImportTooLarge
+	}
+	return n, err
+}
+
+// wrapError reports a CAR read error as ErrImportTooLarge if the limit was
+// hit, since the CAR reader doesn't always wrap the underlying error, and as
+// a malformed CAR otherwise
+func (l *limitedReader) wrapError(err error) error {
+	if l.remaining < 0 {
+		return fmt.Errorf("%w: more than %d bytes", ErrImportTooLarge, l.limit)
+	}
+	return fmt.Errorf("%w: %v", ErrInvalidCAR, err)
+}
@@ -0,0 +1,151 @@
+package repo_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	atrepo "Coves/internal/atproto/repo"
+
+	"github.com/bluesky-social/indigo/atproto/crypto"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	car "github.com/ipld/go-car"
+	carutil "github.com/ipld/go-car/util"
+)
+
+type textRecord struct {
+	Text string
+}
+
+func (r *textRecord) MarshalCBOR(w io.Writer) error {
+	data, err := cbornode.DumpObject(map[string]interface{}{"$type": "social.coves.test.record", "text": r.Text})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func newBlockstore() blockstore.Blockstore {
+	return blockstore.NewBlockstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+}
+
+// buildCAR creates a signed repository with records in two collections and
+// returns it as a CAR along with the signing key
+func buildCAR(t *testing.T, did string) ([]byte, crypto.PublicKey) {
+	t.Helper()
+	ctx := context.Background()
+
+	priv, err := crypto.GeneratePrivateKeyK256()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	pub, err := priv.PublicKey()
+	if err != nil {
+		t.Fatalf("Failed to get public key: %v", err)
+	}
+
+	bs := newBlockstore()
+	w, err := atrepo.NewWrapper(did, bs)
+	if err != nil {
+		t.Fatalf("Failed to create repo: %v", err)
+	}
+	for _, collection := range []string{"social.coves.a", "social.coves.a", "social.coves.b"} {
+		if _, _, err := w.CreateRecord(collection, "", &textRecord{Text: collection}); err != nil {
+			t.Fatalf("Failed to create record: %v", err)
+		}
+	}
+	sc, err := w.Commit(func(ctx context.Context, did string, data []byte) ([]byte, error) {
+		return priv.HashAndSign(data)
+	})
+	if err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	if sc == nil {
+		t.Fatal("Expected a signed commit")
+	}
+	root, err := w.GetHeadCID()
+	if err != nil {
+		t.Fatalf("Failed to get head: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := car.WriteHeader(&car.CarHeader{Roots: []cid.Cid{root}, Version: 1}, &buf); err != nil {
+		t.Fatalf("Failed to write CAR header: %v", err)
+	}
+	keys, err := bs.AllKeysChan(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list blocks: %v", err)
+	}
+	for c := range keys {
+		blk, err := bs.Get(ctx, c)
+		if err != nil {
+			t.Fatalf("Failed to get block: %v", err)
+		}
+		if err := carutil.LdWrite(&buf, c.Bytes(), blk.RawData()); err != nil {
+			t.Fatalf("Failed to write block: %v", err)
+		}
+	}
+
+	return buf.Bytes(), pub
+}
+
+func TestVerifyCAR(t *testing.T) {
+	did := "did:plc:verifytest"
+	carData, pub := buildCAR(t, did)
+
+	v, err := atrepo.VerifyCAR(context.Background(), bytes.NewReader(carData), newBlockstore(), did, pub, atrepo.DefaultImportLimits)
+	if err != nil {
+		t.Fatalf("Failed to verify CAR: %v", err)
+	}
+
+	if v.Commit.Did != did || v.Commit.Rev == "" {
+		t.Errorf("Unexpected commit %+v", v.Commit)
+	}
+	if v.RecordCount() != 3 || v.Collections["social.coves.a"] != 2 || v.Collections["social.coves.b"] != 1 {
+		t.Errorf("Unexpected record counts %v", v.Collections)
+	}
+	if v.Blocks == 0 || v.Bytes == 0 || v.Bytes >= int64(len(carData)) {
+		t.Errorf("Unexpected size: %d blocks, %d bytes", v.Blocks, v.Bytes)
+	}
+}
+
+func TestVerifyCAR_Rejects(t *testing.T) {
+	did := "did:plc:verifytest"
+	carData, pub := buildCAR(t, did)
+	_, otherPub := buildCAR(t, did)
+
+	tampered := append([]byte{}, carData...)
+	tampered[len(tampered)-3] ^= 0xff
+
+	tests := []struct {
+		name    string
+		data    []byte
+		did     string
+		key     crypto.PublicKey
+		limits  atrepo.ImportLimits
+		wantErr error
+	}{
+		{"tampered block", tampered, did, pub, atrepo.DefaultImportLimits, atrepo.ErrInvalidCAR},
+		{"wrong DID", carData, "did:plc:someoneelse", pub, atrepo.DefaultImportLimits, atrepo.ErrInvalidCAR},
+		{"wrong key", carData, did, otherPub, atrepo.DefaultImportLimits, atrepo.ErrInvalidCAR},
+		{"truncated", carData[:len(carData)/2], did, pub, atrepo.DefaultImportLimits, atrepo.ErrInvalidCAR},
+		{"too many bytes", carData, did, pub, atrepo.ImportLimits{MaxBytes: 64, MaxBlocks: 1000}, atrepo.ErrImportTooLarge},
+		{"too many blocks", carData, did, pub, atrepo.ImportLimits{MaxBytes: 1 << 20, MaxBlocks: 1}, atrepo.ErrImportTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := atrepo.VerifyCAR(context.Background(), bytes.NewReader(tt.data), newBlockstore(), tt.did, tt.key, tt.limits)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -3,167 +3,363 @@ package repo
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
+	"Coves/internal/atproto/tid"
+	coreerrors "Coves/internal/core/errors"
+
+	atdata "github.com/bluesky-social/indigo/atproto/data"
 	"github.com/bluesky-social/indigo/mst"
 	"github.com/bluesky-social/indigo/repo"
+	"github.com/bluesky-social/indigo/util"
 	"github.com/ipfs/go-cid"
 	blockstore "github.com/ipfs/go-ipfs-blockstore"
 	cbornode "github.com/ipfs/go-ipld-cbor"
 	cbg "github.com/whyrusleeping/cbor-gen"
 )
 
-// Wrapper provides a thin wrapper around Indigo's repo package
+// Wrapper provides a thin wrapper around Indigo's MST and commit types. It
+// owns record keys and commit revisions, which come from the TID generator.
 type Wrapper struct {
-	repo       *repo.Repo
+	did        string
 	blockstore blockstore.Blockstore
+	cst        cbornode.IpldStore
+	mst        *mst.MerkleSearchTree
+	commit     repo.SignedCommit // Head commit; zero until the first Commit
+	head       cid.Cid
 }
 
+// ErrRecordNotFound is returned when a record path does not exist in the MST
+var ErrRecordNotFound error = coreerrors.NotFoundError{Resource: coreerrors.ResourceRecord}
+
 // NewWrapper creates a new wrapper for a repository with the provided blockstore
-func NewWrapper(did string, signingKey interface{}, bs blockstore.Blockstore) (*Wrapper, error) {
-	// Create new repository with the provided blockstore
-	r := repo.NewRepo(context.Background(), did, bs)
-	
+func NewWrapper(did string, bs blockstore.Blockstore) (*Wrapper, error) {
+	cst := util.CborStore(bs)
+
 	return &Wrapper{
-		repo:       r,
+		did:        did,
 		blockstore: bs,
+		cst:        cst,
+		mst:        mst.NewEmptyMST(cst),
 	}, nil
 }
 
-// OpenWrapper opens an existing repository from CAR data with the provided blockstore
-func OpenWrapper(carData []byte, signingKey interface{}, bs blockstore.Blockstore) (*Wrapper, error) {
-	r, err := repo.ReadRepoFromCar(context.Background(), bytes.NewReader(carData))
+// OpenWrapper loads a repository from CAR data into the provided blockstore
+func OpenWrapper(carData []byte, bs blockstore.Blockstore) (*Wrapper, error) {
+	root, err := repo.IngestRepo(context.Background(), bs, bytes.NewReader(carData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read repo from CAR: %w", err)
 	}
-	
+
+	return LoadWrapper(root, bs)
+}
+
+// LoadWrapper opens an existing repository at the given commit CID from the provided blockstore
+func LoadWrapper(root cid.Cid, bs blockstore.Blockstore) (*Wrapper, error) {
+	cst := util.CborStore(bs)
+
+	var sc repo.SignedCommit
+	if err := cst.Get(context.Background(), root, &sc); err != nil {
+		return nil, fmt.Errorf("failed to open repo at %s: %w", root, err)
+	}
+	if sc.Version != repo.ATP_REPO_VERSION && sc.Version != repo.ATP_REPO_VERSION_2 {
+		return nil, fmt.Errorf("failed to open repo at %s: unsupported repo version %d", root, sc.Version)
+	}
+
 	return &Wrapper{
-		repo:       r,
+		did:        sc.Did,
 		blockstore: bs,
+		cst:        cst,
+		mst:        mst.LoadMST(cst, sc.Data),
+		commit:     sc,
+		head:       root,
 	}, nil
 }
 
-// CreateRecord adds a new record to the repository
+// CreateRecord adds a new record to the repository. If recordKey is empty a
+// TID is generated for it.
 func (w *Wrapper) CreateRecord(collection string, recordKey string, record cbg.CBORMarshaler) (cid.Cid, string, error) {
-	// The repo.CreateRecord generates its own key, so we'll use that
-	recordCID, rkey, err := w.repo.CreateRecord(context.Background(), collection, record)
+	if recordKey == "" {
+		recordKey = tid.Next().String()
+	}
+	path := fmt.Sprintf("%s/%s", collection, recordKey)
+
+	recordCID, err := w.cst.Put(context.Background(), record)
 	if err != nil {
-		return cid.Undef, "", fmt.Errorf("failed to create record: %w", err)
+		return cid.Undef, "", fmt.Errorf("failed to store record: %w", err)
 	}
-	
-	// If a specific key was requested, we'd need to use PutRecord instead
-	if recordKey != "" {
-		// Use PutRecord for specific keys
-		path := fmt.Sprintf("%s/%s", collection, recordKey)
-		recordCID, err = w.repo.PutRecord(context.Background(), path, record)
-		if err != nil {
-			return cid.Undef, "", fmt.Errorf("failed to put record with key: %w", err)
-		}
-		return recordCID, recordKey, nil
+
+	t, err := w.mst.Add(context.Background(), path, recordCID, -1)
+	if err != nil {
+		return cid.Undef, "", fmt.Errorf("failed to create record: %w", err)
 	}
-	
-	return recordCID, rkey, nil
+	w.mst = t
+
+	return recordCID, recordKey, nil
 }
 
 // GetRecord retrieves a record from the repository
 func (w *Wrapper) GetRecord(collection string, recordKey string) (cid.Cid, []byte, error) {
 	path := fmt.Sprintf("%s/%s", collection, recordKey)
-	
-	recordCID, rec, err := w.repo.GetRecord(context.Background(), path)
+
+	recordCID, err := w.mst.Get(context.Background(), path)
 	if err != nil {
+		if errors.Is(err, mst.ErrNotFound) {
+			return cid.Undef, nil, coreerrors.NewNotFoundError(coreerrors.ResourceRecord, path)
+		}
 		return cid.Undef, nil, fmt.Errorf("failed to get record: %w", err)
 	}
-	
-	// Encode record to CBOR
-	buf := new(bytes.Buffer)
-	if err := rec.(cbg.CBORMarshaler).MarshalCBOR(buf); err != nil {
-		return cid.Undef, nil, fmt.Errorf("failed to encode record: %w", err)
+
+	blk, err := w.blockstore.Get(context.Background(), recordCID)
+	if err != nil {
+		return cid.Undef, nil, fmt.Errorf("failed to get record: %w", err)
 	}
-	
-	return recordCID, buf.Bytes(), nil
+
+	return recordCID, blk.RawData(), nil
 }
 
 // UpdateRecord updates an existing record in the repository
 func (w *Wrapper) UpdateRecord(collection string, recordKey string, record cbg.CBORMarshaler) (cid.Cid, error) {
 	path := fmt.Sprintf("%s/%s", collection, recordKey)
-	
+
 	// Check if record exists
-	_, _, err := w.repo.GetRecord(context.Background(), path)
+	if _, _, err := w.GetRecord(collection, recordKey); err != nil {
+		return cid.Undef, err
+	}
+
+	recordCID, err := w.cst.Put(context.Background(), record)
 	if err != nil {
-		return cid.Undef, fmt.Errorf("record not found: %w", err)
+		return cid.Undef, fmt.Errorf("failed to store record: %w", err)
 	}
-	
-	// Update the record
-	recordCID, err := w.repo.UpdateRecord(context.Background(), path, record)
+
+	t, err := w.mst.Update(context.Background(), path, recordCID)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("failed to update record: %w", err)
 	}
-	
+	w.mst = t
+
 	return recordCID, nil
 }
 
 // DeleteRecord removes a record from the repository
 func (w *Wrapper) DeleteRecord(collection string, recordKey string) error {
 	path := fmt.Sprintf("%s/%s", collection, recordKey)
-	
-	if err := w.repo.DeleteRecord(context.Background(), path); err != nil {
+
+	// Check if record exists
+	if _, _, err := w.GetRecord(collection, recordKey); err != nil {
+		return err
+	}
+
+	t, err := w.mst.Delete(context.Background(), path)
+	if err != nil {
 		return fmt.Errorf("failed to delete record: %w", err)
 	}
-	
+	w.mst = t
+
 	return nil
 }
 
-// ListRecords returns all records in a collection
-func (w *Wrapper) ListRecords(collection string) ([]RecordInfo, error) {
+// ListRecords returns up to limit records in a collection, ordered by record key,
+// starting after the given cursor record key. A limit of zero returns all records.
+func (w *Wrapper) ListRecords(collection string, cursor string, limit int) ([]RecordInfo, error) {
 	var records []RecordInfo
-	
-	err := w.repo.ForEach(context.Background(), collection, func(k string, v cid.Cid) error {
-		// Skip if not in the requested collection
-		if len(k) <= len(collection)+1 || k[:len(collection)] != collection || k[len(collection)] != '/' {
+	prefix := collection + "/"
+
+	from := prefix
+	if cursor != "" {
+		from = prefix + cursor
+	}
+
+	err := w.mst.WalkLeavesFrom(context.Background(), from, func(k string, v cid.Cid) error {
+		// Keys are sorted, so the first key outside the collection ends the walk
+		if len(k) <= len(prefix) || k[:len(prefix)] != prefix {
+			return repo.ErrDoneIterating
+		}
+
+		recordKey := k[len(prefix):]
+		if recordKey == cursor {
 			return nil
 		}
-		
-		recordKey := k[len(collection)+1:]
+
 		records = append(records, RecordInfo{
 			Collection: collection,
 			RecordKey:  recordKey,
 			CID:        v,
 		})
-		
+
+		if limit > 0 && len(records) >= limit {
+			return repo.ErrDoneIterating
+		}
 		return nil
 	})
-	
-	if err != nil {
+
+	if err != nil && !errors.Is(err, repo.ErrDoneIterating) {
 		return nil, fmt.Errorf("failed to list records: %w", err)
 	}
-	
+
 	return records, nil
 }
 
-// Commit creates a new signed commit
-func (w *Wrapper) Commit(did string, signingKey interface{}) (*repo.SignedCommit, error) {
-	// The commit function expects a signing function with context
-	signingFunc := func(ctx context.Context, did string, data []byte) ([]byte, error) {
-		// TODO: Implement proper signing based on signingKey type
-		return []byte("mock-signature"), nil
+// CountRecords walks the MST and returns the number of records in each
+// collection
+func (w *Wrapper) CountRecords() (map[string]int, error) {
+	counts := make(map[string]int)
+	err := w.mst.WalkLeavesFrom(context.Background(), "", func(k string, _ cid.Cid) error {
+		collection, _, ok := strings.Cut(k, "/")
+		if !ok {
+			return fmt.Errorf("malformed record path %q", k)
+		}
+		counts[collection]++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count records: %w", err)
 	}
-	
-	_, _, err := w.repo.Commit(context.Background(), signingFunc)
+	return counts, nil
+}
+
+// BlobRef is a blob a record links to
+type BlobRef struct {
+	CID        cid.Cid
+	Collection string
+	RecordKey  string
+}
+
+// BlobRefs walks every record and returns the blobs they link to, in
+// record path order
+func (w *Wrapper) BlobRefs() ([]BlobRef, error) {
+	ctx := context.Background()
+	var refs []BlobRef
+	err := w.mst.WalkLeavesFrom(ctx, "", func(k string, v cid.Cid) error {
+		collection, rkey, ok := strings.Cut(k, "/")
+		if !ok {
+			return fmt.Errorf("malformed record path %q", k)
+		}
+		blk, err := w.blockstore.Get(ctx, v)
+		if err != nil {
+			return fmt.Errorf("getting record %s: %w", k, err)
+		}
+		record, err := atdata.UnmarshalCBOR(blk.RawData())
+		if err != nil {
+			return fmt.Errorf("decoding record %s: %w", k, err)
+		}
+		for _, blob := range atdata.ExtractBlobs(record) {
+			refs = append(refs, BlobRef{CID: blob.Ref.CID(), Collection: collection, RecordKey: rkey})
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to commit: %w", err)
+		return nil, fmt.Errorf("failed to list blobs: %w", err)
 	}
-	
-	// Return the signed commit from the repo
-	sc := w.repo.SignedCommit()
-	
+	return refs, nil
+}
+
+// RecordDiff is a record that differs between two versions of a repository
+type RecordDiff struct {
+	Collection string
+	RecordKey  string
+	OldCID     cid.Cid // Undefined for records that were created
+	NewCID     cid.Cid // Undefined for records that were deleted
+}
+
+// Diff returns the records that differ between from's head commit and w's,
+// in record path order. A nil from diffs against an empty repository. The
+// MST nodes of both commits are read through w's blockstore, so both
+// versions must be stored there.
+func (w *Wrapper) Diff(from *Wrapper) ([]RecordDiff, error) {
+	fromData := cid.Undef
+	if from != nil {
+		fromData = from.commit.Data
+	}
+
+	ops, err := mst.DiffTrees(context.Background(), w.blockstore, fromData, w.commit.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff trees: %w", err)
+	}
+
+	diffs := make([]RecordDiff, 0, len(ops))
+	for _, op := range ops {
+		collection, rkey, ok := strings.Cut(op.Rpath, "/")
+		if !ok {
+			return nil, fmt.Errorf("malformed record path %q", op.Rpath)
+		}
+		diffs = append(diffs, RecordDiff{
+			Collection: collection,
+			RecordKey:  rkey,
+			OldCID:     op.OldCid,
+			NewCID:     op.NewCid,
+		})
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Collection != diffs[j].Collection {
+			return diffs[i].Collection < diffs[j].Collection
+		}
+		return diffs[i].RecordKey < diffs[j].RecordKey
+	})
+	return diffs, nil
+}
+
+// SignFunc signs the serialized bytes of an unsigned commit on behalf of a DID
+type SignFunc func(ctx context.Context, did string, data []byte) ([]byte, error)
+
+// Commit creates a new commit signed by the given signing function. Its
+// revision is a TID that sorts after the previous commit's revision.
+func (w *Wrapper) Commit(sign SignFunc) (*repo.SignedCommit, error) {
+	ctx := context.Background()
+
+	data, err := w.mst.GetPointer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit: writing MST: %w", err)
+	}
+
+	unsigned := repo.UnsignedCommit{
+		Did:     w.did,
+		Version: repo.ATP_REPO_VERSION,
+		Data:    data,
+		Rev:     tid.NextAfter(w.commit.Rev).String(),
+	}
+	sb, err := unsigned.BytesForSigning()
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit: serializing commit: %w", err)
+	}
+	sig, err := sign(ctx, w.did, sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit: signing: %w", err)
+	}
+
+	sc := repo.SignedCommit{
+		Did:     unsigned.Did,
+		Version: unsigned.Version,
+		Data:    unsigned.Data,
+		Rev:     unsigned.Rev,
+		Sig:     sig,
+	}
+	commitCID, err := w.cst.Put(ctx, &sc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit: storing commit: %w", err)
+	}
+
+	w.commit = sc
+	w.head = commitCID
+
 	return &sc, nil
 }
 
 // GetHeadCID returns the CID of the current repository head
 func (w *Wrapper) GetHeadCID() (cid.Cid, error) {
-	// TODO: Implement this properly
-	// The repo package doesn't expose a direct way to get the head CID
-	return cid.Undef, fmt.Errorf("not implemented")
+	if !w.head.Defined() {
+		return cid.Undef, fmt.Errorf("repository has no commits")
+	}
+	return w.head, nil
+}
+
+// DataCID returns the MST root referenced by the current head commit. It
+// does not reflect uncommitted changes.
+func (w *Wrapper) DataCID() cid.Cid {
+	return w.commit.Data
 }
 
 // Export exports the repository as a CAR file
@@ -173,10 +369,9 @@ func (w *Wrapper) Export() ([]byte, error) {
 	return nil, fmt.Errorf("CAR export not yet implemented")
 }
 
-// GetMST returns the underlying Merkle Search Tree
+// GetMST returns the underlying Merkle Search Tree, including uncommitted changes
 func (w *Wrapper) GetMST() (*mst.MerkleSearchTree, error) {
-	// TODO: Implement MST access
-	return nil, fmt.Errorf("not implemented")
+	return w.mst, nil
 }
 
 // RecordInfo contains information about a record
@@ -198,4 +393,4 @@ func EncodeRecord(v cbg.CBORMarshaler) ([]byte, error) {
 		return nil, err
 	}
 	return buf.Bytes(), nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,253 @@
+package repo_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	atrepo "Coves/internal/atproto/repo"
+
+	atdata "github.com/bluesky-social/indigo/atproto/data"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/ipfs/go-cid"
+)
+
+func noopSign(ctx context.Context, did string, data []byte) ([]byte, error) {
+	return []byte("sig"), nil
+}
+
+func TestWrapper_RecordKeysAndRevisions(t *testing.T) {
+	bs := newBlockstore()
+	w, err := atrepo.NewWrapper("did:plc:tidtest", bs)
+	if err != nil {
+		t.Fatalf("Failed to create repo: %v", err)
+	}
+
+	// Generated keys are TIDs, so records list in creation order
+	var keys []string
+	for i := 0; i < 20; i++ {
+		_, rkey, err := w.CreateRecord("social.coves.test.record", "", &textRecord{Text: fmt.Sprint(i)})
+		if err != nil {
+			t.Fatalf("Failed to create record: %v", err)
+		}
+		if _, err := syntax.ParseTID(rkey); err != nil {
+			t.Errorf("Expected a TID record key, got %q", rkey)
+		}
+		keys = append(keys, rkey)
+	}
+	if _, _, err := w.CreateRecord("social.coves.test.record", "self", &textRecord{Text: "self"}); err != nil {
+		t.Fatalf("Failed to create record with key: %v", err)
+	}
+
+	infos, err := w.ListRecords("social.coves.test.record", "", 0)
+	if err != nil {
+		t.Fatalf("Failed to list records: %v", err)
+	}
+	if len(infos) != len(keys)+1 {
+		t.Fatalf("Expected %d records, got %d", len(keys)+1, len(infos))
+	}
+	for i, key := range keys {
+		if infos[i].RecordKey != key {
+			t.Errorf("Expected record %d to be %s, got %s", i, key, infos[i].RecordKey)
+		}
+	}
+
+	// A caller-supplied key can't overwrite an existing record
+	if _, _, err := w.CreateRecord("social.coves.test.record", "self", &textRecord{Text: "again"}); err == nil {
+		t.Error("Expected creating a duplicate key to fail")
+	}
+
+	first, err := w.Commit(noopSign)
+	if err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	if _, err := w.UpdateRecord("social.coves.test.record", "self", &textRecord{Text: "updated"}); err != nil {
+		t.Fatalf("Failed to update record: %v", err)
+	}
+	second, err := w.Commit(noopSign)
+	if err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	if _, err := syntax.ParseTID(first.Rev); err != nil {
+		t.Errorf("Expected a TID revision, got %q", first.Rev)
+	}
+	if second.Rev <= first.Rev {
+		t.Errorf("Expected revision %s to sort after %s", second.Rev, first.Rev)
+	}
+
+	// The commit reloads with the same records and revision
+	head, err := w.GetHeadCID()
+	if err != nil {
+		t.Fatalf("Failed to get head: %v", err)
+	}
+	loaded, err := atrepo.LoadWrapper(head, bs)
+	if err != nil {
+		t.Fatalf("Failed to load repo: %v", err)
+	}
+	if loaded.DataCID() != second.Data {
+		t.Errorf("Expected data %s, got %s", second.Data, loaded.DataCID())
+	}
+	if _, value, err := loaded.GetRecord("social.coves.test.record", "self"); err != nil || len(value) == 0 {
+		t.Errorf("Failed to read record after reload: %v", err)
+	}
+}
+
+func TestWrapper_CountRecords(t *testing.T) {
+	w, err := atrepo.NewWrapper("did:plc:counttest", newBlockstore())
+	if err != nil {
+		t.Fatalf("Failed to create repo: %v", err)
+	}
+
+	if counts, err := w.CountRecords(); err != nil || len(counts) != 0 {
+		t.Fatalf("Expected no records, got %v %v", counts, err)
+	}
+
+	for i, collection := range []string{"social.coves.test.a", "social.coves.test.b", "social.coves.test.a"} {
+		if _, _, err := w.CreateRecord(collection, "", &textRecord{Text: fmt.Sprint(i)}); err != nil {
+			t.Fatalf("Failed to create record: %v", err)
+		}
+	}
+	if _, _, err := w.CreateRecord("social.coves.test.c", "gone", &textRecord{Text: "gone"}); err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+	if err := w.DeleteRecord("social.coves.test.c", "gone"); err != nil {
+		t.Fatalf("Failed to delete record: %v", err)
+	}
+
+	counts, err := w.CountRecords()
+	if err != nil {
+		t.Fatalf("Failed to count records: %v", err)
+	}
+	if len(counts) != 2 || counts["social.coves.test.a"] != 2 || counts["social.coves.test.b"] != 1 {
+		t.Errorf("Unexpected counts %v", counts)
+	}
+}
+
+// mapRecord is a record built from generic atproto data
+type mapRecord map[string]any
+
+func (r mapRecord) MarshalCBOR(w io.Writer) error {
+	data, err := atdata.MarshalCBOR(r)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func TestWrapper_BlobRefs(t *testing.T) {
+	w, err := atrepo.NewWrapper("did:plc:blobtest", newBlockstore())
+	if err != nil {
+		t.Fatalf("Failed to create repo: %v", err)
+	}
+
+	avatar, err := cid.Decode("bafkreibme22gw2h7y2h7tg2fhqotaqjucnbc24deqo72b6mkl2egezxhvy")
+	if err != nil {
+		t.Fatalf("Failed to decode CID: %v", err)
+	}
+	banner, err := cid.Decode("bafkreiawbvjptn2tpydsyxiyxbq5zwfdaxdzgaxl3dzgb7t2mmtqjgujuy")
+	if err != nil {
+		t.Fatalf("Failed to decode CID: %v", err)
+	}
+
+	profile := mapRecord{
+		"$type":  "social.coves.test.profile",
+		"avatar": atdata.Blob{Ref: atdata.CIDLink(avatar), MimeType: "image/png", Size: 10},
+		"images": []any{map[string]any{"image": atdata.Blob{Ref: atdata.CIDLink(banner), MimeType: "image/jpeg", Size: 20}}},
+	}
+	if _, _, err := w.CreateRecord("social.coves.test.profile", "self", profile); err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+	if _, _, err := w.CreateRecord("social.coves.test.record", "", &textRecord{Text: "no blobs"}); err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+
+	refs, err := w.BlobRefs()
+	if err != nil {
+		t.Fatalf("Failed to list blobs: %v", err)
+	}
+	if len(refs) != 2 {
+		t.Fatalf("Expected 2 blobs, got %v", refs)
+	}
+	found := map[cid.Cid]bool{}
+	for _, ref := range refs {
+		if ref.Collection != "social.coves.test.profile" || ref.RecordKey != "self" {
+			t.Errorf("Unexpected record for blob %v", ref)
+		}
+		found[ref.CID] = true
+	}
+	if !found[avatar] || !found[banner] {
+		t.Errorf("Expected both blobs, got %v", refs)
+	}
+}
+
+func TestWrapper_Diff(t *testing.T) {
+	bs := newBlockstore()
+	w, err := atrepo.NewWrapper("did:plc:difftest", bs)
+	if err != nil {
+		t.Fatalf("Failed to create repo: %v", err)
+	}
+
+	// Enough records for the MST to have several levels
+	var keys []string
+	for i := 0; i < 50; i++ {
+		_, rkey, err := w.CreateRecord("social.coves.test.record", "", &textRecord{Text: fmt.Sprint(i)})
+		if err != nil {
+			t.Fatalf("Failed to create record: %v", err)
+		}
+		keys = append(keys, rkey)
+	}
+	if _, err := w.Commit(noopSign); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	head, _ := w.GetHeadCID()
+	before, err := atrepo.LoadWrapper(head, bs)
+	if err != nil {
+		t.Fatalf("Failed to load repo: %v", err)
+	}
+
+	all, err := before.Diff(nil)
+	if err != nil {
+		t.Fatalf("Failed to diff: %v", err)
+	}
+	if len(all) != 50 || all[0].RecordKey != keys[0] || all[0].OldCID.Defined() || !all[0].NewCID.Defined() {
+		t.Errorf("Expected every record to be created, got %d changes starting %+v", len(all), all[0])
+	}
+
+	updatedCID, err := w.UpdateRecord("social.coves.test.record", keys[10], &textRecord{Text: "updated"})
+	if err != nil {
+		t.Fatalf("Failed to update record: %v", err)
+	}
+	if err := w.DeleteRecord("social.coves.test.record", keys[20]); err != nil {
+		t.Fatalf("Failed to delete record: %v", err)
+	}
+	createdCID, _, err := w.CreateRecord("social.coves.test.other", "self", &textRecord{Text: "new"})
+	if err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+	if _, err := w.Commit(noopSign); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	diffs, err := w.Diff(before)
+	if err != nil {
+		t.Fatalf("Failed to diff: %v", err)
+	}
+	if len(diffs) != 3 {
+		t.Fatalf("Expected 3 changes, got %+v", diffs)
+	}
+	if d := diffs[0]; d.Collection != "social.coves.test.other" || d.RecordKey != "self" || d.OldCID.Defined() || d.NewCID != createdCID {
+		t.Errorf("Expected the create first, got %+v", d)
+	}
+	if d := diffs[1]; d.RecordKey != keys[10] || !d.OldCID.Defined() || d.NewCID != updatedCID {
+		t.Errorf("Expected the update, got %+v", d)
+	}
+	if d := diffs[2]; d.RecordKey != keys[20] || !d.OldCID.Defined() || d.NewCID.Defined() {
+		t.Errorf("Expected the delete, got %+v", d)
+	}
+
+	if same, err := w.Diff(w); err != nil || len(same) != 0 {
+		t.Errorf("Expected no changes against itself, got %+v %v", same, err)
+	}
+}
@@ -0,0 +1,92 @@
+package tid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/syntax"
+)
+
+// maxClockID is the largest clock ID that fits in a TID's low 10 bits
+const maxClockID = 1<<10 - 1
+
+// Generator produces TIDs (13-character base32-sortable timestamp IDs) that
+// strictly increase, even under concurrent use or if the wall clock steps
+// backwards. The clock ID distinguishes TIDs minted in the same microsecond
+// by different processes.
+type Generator struct {
+	clockID uint
+
+	mu        sync.Mutex
+	lastMicro int64 // Timestamp of the last TID issued
+}
+
+// NewGenerator creates a generator that stamps TIDs with clockID, which is
+// truncated to 10 bits
+func NewGenerator(clockID uint) *Generator {
+	return &Generator{clockID: clockID & maxClockID}
+}
+
+// Next returns a TID greater than any this generator has returned before
+func (g *Generator) Next() syntax.TID {
+	return g.next(0)
+}
+
+// NextAfter returns a TID greater than both prev and any this generator has
+// returned before. It is used for commit revisions, which must increase even
+// when the previous one came from another process or a faster clock. An
+// empty or malformed prev is ignored.
+func (g *Generator) NextAfter(prev string) syntax.TID {
+	var floor int64
+	if t, err := syntax.ParseTID(prev); err == nil {
+		floor = t.Time().UnixMicro()
+	}
+	return g.next(floor)
+}
+
+// next issues a TID whose timestamp is later than floor and the last TID
+func (g *Generator) next(floor int64) syntax.TID {
+	now := time.Now().UTC().UnixMicro()
+
+	g.mu.Lock()
+	if floor < g.lastMicro {
+		floor = g.lastMicro
+	}
+	if now <= floor {
+		now = floor + 1
+	}
+	g.lastMicro = now
+	g.mu.Unlock()
+
+	return syntax.NewTID(now, g.clockID)
+}
+
+// ClockID returns the clock ID stamped on this generator's TIDs
+func (g *Generator) ClockID() uint {
+	return g.clockID
+}
+
+var defaultGenerator = NewGenerator(randomClockID())
+
+// Next returns a new TID from the process-wide generator
+func Next() syntax.TID {
+	return defaultGenerator.Next()
+}
+
+// NextAfter returns a new TID from the process-wide generator that sorts
+// after prev
+func NextAfter(prev string) syntax.TID {
+	return defaultGenerator.NextAfter(prev)
+}
+
+// randomClockID picks the process's clock ID so that concurrent processes
+// are unlikely to share one
+func randomClockID() uint {
+	var b [2]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0
+	}
+	return uint(binary.BigEndian.Uint16(b[:])) & maxClockID
+}
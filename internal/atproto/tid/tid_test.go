@@ -0,0 +1,84 @@
+package tid_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"Coves/internal/atproto/tid"
+
+	"github.com/bluesky-social/indigo/atproto/syntax"
+)
+
+func TestGenerator_Format(t *testing.T) {
+	g := tid.NewGenerator(1234)
+	if g.ClockID() != 1234&1023 {
+		t.Errorf("Expected clock ID truncated to 10 bits, got %d", g.ClockID())
+	}
+
+	s := g.Next().String()
+	if len(s) != 13 {
+		t.Errorf("Expected 13 characters, got %q", s)
+	}
+	parsed, err := syntax.ParseTID(s)
+	if err != nil {
+		t.Fatalf("Generated TID does not parse: %v", err)
+	}
+	if parsed.ClockID() != g.ClockID() {
+		t.Errorf("Expected clock ID %d, got %d", g.ClockID(), parsed.ClockID())
+	}
+	if d := time.Since(parsed.Time()); d < 0 || d > time.Minute {
+		t.Errorf("Expected a current timestamp, got %s", parsed.Time())
+	}
+}
+
+func TestGenerator_Concurrent(t *testing.T) {
+	g := tid.NewGenerator(0)
+
+	const workers, perWorker = 8, 1000
+	results := make([][]string, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				results[i] = append(results[i], g.Next().String())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for _, ids := range results {
+		for j, id := range ids {
+			if seen[id] {
+				t.Fatalf("Duplicate TID %s", id)
+			}
+			seen[id] = true
+			// Each worker must see its own TIDs in increasing order
+			if j > 0 && id <= ids[j-1] {
+				t.Fatalf("TID %s does not sort after %s", id, ids[j-1])
+			}
+		}
+	}
+}
+
+func TestGenerator_NextAfter(t *testing.T) {
+	g := tid.NewGenerator(0)
+
+	// A revision minted by a clock an hour ahead must still be superseded
+	future := syntax.NewTIDFromTime(time.Now().Add(time.Hour), 1023).String()
+	next := g.NextAfter(future).String()
+	if next <= future {
+		t.Errorf("Expected %s to sort after %s", next, future)
+	}
+	if after := g.Next().String(); after <= next {
+		t.Errorf("Expected %s to sort after %s", after, next)
+	}
+
+	// Malformed revisions are ignored
+	if s := g.NextAfter("rev-0").String(); len(s) != 13 {
+		t.Errorf("Expected a TID, got %q", s)
+	}
+}
@@ -0,0 +1,31 @@
+// Package auth issues and checks account access tokens. A token is handed
+// out when an account's repository is created and proves, on later
+// requests, that the caller owns that account. Only token hashes are stored.
+package auth
+
+import (
+	"time"
+)
+
+// AccessToken is a stored account access token
+type AccessToken struct {
+	Hash      []byte // SHA-256 of the token
+	DID       string // DID of the account the token was issued for
+	CreatedAt time.Time
+}
+
+// TokenService defines the business logic for account access tokens
+type TokenService interface {
+	// IssueToken creates a new access token for a DID. The token itself is
+	// only returned here; it can't be recovered later.
+	IssueToken(did string) (string, error)
+
+	// Authenticate returns the DID a token was issued for
+	Authenticate(token string) (string, error)
+}
+
+// TokenRepository defines the data access interface for access tokens
+type TokenRepository interface {
+	Create(token *AccessToken) error
+	GetByHash(hash []byte) (*AccessToken, error)
+}
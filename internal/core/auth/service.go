@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	coreerrors "Coves/internal/core/errors"
+)
+
+// tokenBytes is the number of random bytes in an access token
+const tokenBytes = 32
+
+// ErrInvalidToken is returned for tokens that weren't issued by this server
+var ErrInvalidToken = fmt.Errorf("invalid access token: %w", coreerrors.ErrUnauthorized)
+
+// Service implements TokenService
+type Service struct {
+	repo TokenRepository
+}
+
+// NewService creates a new access token service
+func NewService(repo TokenRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// IssueToken creates a new access token for a DID
+func (s *Service) IssueToken(did string) (string, error) {
+	if did == "" {
+		return "", coreerrors.NewValidationError("did", "DID is required")
+	}
+
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generating access token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	if err := s.repo.Create(&AccessToken{
+		Hash:      hashToken(token),
+		DID:       did,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return "", fmt.Errorf("saving access token: %w", err)
+	}
+
+	return token, nil
+}
+
+// Authenticate returns the DID a token was issued for
+func (s *Service) Authenticate(token string) (string, error) {
+	if token == "" {
+		return "", ErrInvalidToken
+	}
+
+	stored, err := s.repo.GetByHash(hashToken(token))
+	if err != nil {
+		return "", fmt.Errorf("looking up access token: %w", err)
+	}
+	if stored == nil {
+		return "", ErrInvalidToken
+	}
+
+	return stored.DID, nil
+}
+
+// hashToken returns the hash a token is stored and looked up by. Tokens are
+// random, so an unsalted hash is enough to keep them out of the database.
+func hashToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
@@ -0,0 +1,70 @@
+package auth_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"Coves/internal/core/auth"
+	coreerrors "Coves/internal/core/errors"
+)
+
+type mockTokenRepository struct {
+	tokens []*auth.AccessToken
+}
+
+func (m *mockTokenRepository) Create(token *auth.AccessToken) error {
+	m.tokens = append(m.tokens, token)
+	return nil
+}
+
+func (m *mockTokenRepository) GetByHash(hash []byte) (*auth.AccessToken, error) {
+	for _, t := range m.tokens {
+		if bytes.Equal(t.Hash, hash) {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestTokenService(t *testing.T) {
+	repo := &mockTokenRepository{}
+	service := auth.NewService(repo)
+
+	token, err := service.IssueToken("did:plc:owner")
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+	other, err := service.IssueToken("did:plc:other")
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+	if token == other {
+		t.Fatal("Expected every token to be different")
+	}
+
+	// Only the hash is stored
+	for _, stored := range repo.tokens {
+		if bytes.Contains(stored.Hash, []byte(token)) || string(stored.Hash) == token {
+			t.Fatal("Expected the token not to be stored")
+		}
+	}
+
+	did, err := service.Authenticate(token)
+	if err != nil || did != "did:plc:owner" {
+		t.Errorf("Expected the token to authenticate did:plc:owner, got %q %v", did, err)
+	}
+	if did, err := service.Authenticate(other); err != nil || did != "did:plc:other" {
+		t.Errorf("Expected the token to authenticate did:plc:other, got %q %v", did, err)
+	}
+
+	for _, bad := range []string{"", "not-a-token", token + "x"} {
+		if _, err := service.Authenticate(bad); !errors.Is(err, auth.ErrInvalidToken) || !errors.Is(err, coreerrors.ErrUnauthorized) {
+			t.Errorf("Expected %q to be rejected as unauthorized, got %v", bad, err)
+		}
+	}
+
+	if _, err := service.IssueToken(""); !errors.Is(err, coreerrors.ErrInvalidInput) {
+		t.Errorf("Expected a validation error without a DID, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+// Package blobs stores the images and other files that records link to.
+// Blobs belong to an account and are addressed by the CID of their bytes;
+// the bytes live in a Store and their metadata in a BlobRepository. An
+// account migrating to this server uploads its blobs while its repository
+// is deactivated, and ListMissing reports which ones it still has to send.
+package blobs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"Coves/internal/core/repository"
+
+	"github.com/ipfs/go-cid"
+)
+
+// DefaultMaxSize is the largest blob accepted unless SetMaxSize changes it
+const DefaultMaxSize = 5 << 20
+
+// DefaultMimeType is recorded for blobs uploaded without a content type
+const DefaultMimeType = "application/octet-stream"
+
+// ErrBlobTooLarge is returned for uploads over the size limit
+var ErrBlobTooLarge = errors.New("blob too large")
+
+// Blob is a stored blob's metadata
+type Blob struct {
+	DID       string
+	CID       cid.Cid // Raw codec, sha-256
+	MimeType  string
+	Size      int64
+	CreatedAt time.Time
+}
+
+// MissingBlob is a blob a record links to that hasn't been uploaded
+type MissingBlob struct {
+	CID       cid.Cid
+	RecordURI string // The first record found linking to it
+}
+
+// BlobRepository defines the data access interface for blob metadata
+type BlobRepository interface {
+	Create(blob *Blob) error // Does nothing if the DID already has the blob
+	Get(did string, c cid.Cid) (*Blob, error)
+	List(did string, limit int, afterCID string) ([]*Blob, error) // Ordered by CID string, starting after afterCID
+	Has(did string, cids []cid.Cid) (map[cid.Cid]bool, error)     // Which of cids the DID has
+}
+
+// Store holds blob bytes
+type Store interface {
+	Put(ctx context.Context, did string, c cid.Cid, data []byte) error
+	Open(ctx context.Context, did string, c cid.Cid) (io.ReadCloser, error) // A NotFoundError if it isn't stored
+	Delete(ctx context.Context, did string, c cid.Cid) error
+}
+
+// Repositories is what the blob service needs from the repository service
+type Repositories interface {
+	GetRepository(did string) (*repository.Repository, error)
+	ListBlobRefs(did string) ([]repository.BlobRef, error)
+}
@@ -0,0 +1,82 @@
+package blobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	coreerrors "Coves/internal/core/errors"
+
+	"github.com/ipfs/go-cid"
+)
+
+// DiskStore is a Store keeping each blob in a file under a directory per DID
+type DiskStore struct {
+	dir string
+}
+
+// NewDiskStore creates a store in dir, creating it if needed
+func NewDiskStore(dir string) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating blob directory: %w", err)
+	}
+	return &DiskStore{dir: dir}, nil
+}
+
+// path returns where a blob is kept. DIDs contain colons, so they are
+// escaped to make a directory name.
+func (s *DiskStore) path(did string, c cid.Cid) string {
+	return filepath.Join(s.dir, url.PathEscape(did), c.String())
+}
+
+// Put writes a blob. It is written to a temporary file first, so a blob is
+// either stored whole or not at all.
+func (s *DiskStore) Put(ctx context.Context, did string, c cid.Cid, data []byte) error {
+	path := s.path(did, c)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("creating blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing blob: %w", err)
+	}
+	return nil
+}
+
+// Open opens a blob for reading
+func (s *DiskStore) Open(ctx context.Context, did string, c cid.Cid) (io.ReadCloser, error) {
+	f, err := os.Open(s.path(did, c))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceBlob, c)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening blob: %w", err)
+	}
+	return f, nil
+}
+
+// Delete removes a blob; removing one that isn't stored isn't an error
+func (s *DiskStore) Delete(ctx context.Context, did string, c cid.Cid) error {
+	if err := os.Remove(s.path(did, c)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("deleting blob: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package blobs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	coreerrors "Coves/internal/core/errors"
+	"Coves/internal/core/repository"
+
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+)
+
+// cidBuilder computes blob CIDs the way atproto clients do
+var cidBuilder = cid.V1Builder{Codec: cid.Raw, MhType: multihash.SHA2_256}
+
+// Service uploads and serves blobs
+type Service struct {
+	blobs   BlobRepository
+	store   Store
+	repos   Repositories
+	maxSize int64
+}
+
+// NewService creates a blob service keeping metadata in blobs and bytes in
+// store. Blobs are only accepted for repositories repos knows.
+func NewService(blobs BlobRepository, store Store, repos Repositories) *Service {
+	return &Service{
+		blobs:   blobs,
+		store:   store,
+		repos:   repos,
+		maxSize: DefaultMaxSize,
+	}
+}
+
+// SetMaxSize overrides the largest blob Upload accepts
+func (s *Service) SetMaxSize(maxSize int64) {
+	s.maxSize = maxSize
+}
+
+// Upload stores a blob for a repository and returns its metadata. Uploads
+// are accepted while the repository is deactivated, so a migrating account
+// can backfill its blobs before it is activated. Uploading a blob the
+// repository already has changes nothing.
+func (s *Service) Upload(ctx context.Context, did string, mimeType string, r io.Reader) (*Blob, error) {
+	repo, err := s.repository(did)
+	if err != nil {
+		return nil, err
+	}
+	if !repo.Active() && repo.Status != repository.StatusDeactivated {
+		return nil, repository.InactiveError{DID: did, Status: repo.Status}
+	}
+
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading blob: %w", err)
+	}
+	if n > s.maxSize {
+		return nil, fmt.Errorf("%w: over %d bytes", ErrBlobTooLarge, s.maxSize)
+	}
+
+	c, err := cidBuilder.Sum(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("computing blob CID: %w", err)
+	}
+	if mimeType == "" {
+		mimeType = DefaultMimeType
+	}
+
+	if err := s.store.Put(ctx, did, c, buf.Bytes()); err != nil {
+		return nil, fmt.Errorf("storing blob: %w", err)
+	}
+	blob := &Blob{
+		DID:       did,
+		CID:       c,
+		MimeType:  mimeType,
+		Size:      n,
+		CreatedAt: time.Now(),
+	}
+	if err := s.blobs.Create(blob); err != nil {
+		return nil, fmt.Errorf("recording blob: %w", err)
+	}
+
+	return blob, nil
+}
+
+// Get returns a blob's metadata and opens its bytes. Only active
+// repositories' blobs are served.
+func (s *Service) Get(ctx context.Context, did string, c cid.Cid) (*Blob, io.ReadCloser, error) {
+	if err := s.activeRepository(did); err != nil {
+		return nil, nil, err
+	}
+
+	blob, err := s.blobs.Get(did, c)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting blob: %w", err)
+	}
+	if blob == nil {
+		return nil, nil, coreerrors.NewNotFoundError(coreerrors.ResourceBlob, c)
+	}
+
+	rc, err := s.store.Open(ctx, did, c)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening blob: %w", err)
+	}
+	return blob, rc, nil
+}
+
+// List lists the CIDs of an active repository's blobs. The cursor is the
+// last CID of the previous page.
+func (s *Service) List(did string, limit int, cursor string) ([]cid.Cid, string, error) {
+	if err := s.activeRepository(did); err != nil {
+		return nil, "", err
+	}
+
+	blobs, err := s.blobs.List(did, limit, cursor)
+	if err != nil {
+		return nil, "", fmt.Errorf("listing blobs: %w", err)
+	}
+
+	cids := make([]cid.Cid, len(blobs))
+	for i, blob := range blobs {
+		cids[i] = blob.CID
+	}
+
+	var nextCursor string
+	if len(cids) == limit && len(cids) > 0 {
+		nextCursor = cids[len(cids)-1].String()
+	}
+	return cids, nextCursor, nil
+}
+
+// ListMissing lists the blobs a repository's records link to that haven't
+// been uploaded, ordered by CID. The cursor is the last CID of the previous
+// page.
+func (s *Service) ListMissing(did string, limit int, cursor string) ([]MissingBlob, string, error) {
+	refs, err := s.repos.ListBlobRefs(did)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Records may share a blob; report it once
+	byCID := make(map[cid.Cid]string, len(refs))
+	var cids []cid.Cid
+	for _, ref := range refs {
+		if _, seen := byCID[ref.CID]; !seen {
+			byCID[ref.CID] = ref.RecordURI
+			cids = append(cids, ref.CID)
+		}
+	}
+
+	have, err := s.blobs.Has(did, cids)
+	if err != nil {
+		return nil, "", fmt.Errorf("checking blobs: %w", err)
+	}
+
+	var missing []MissingBlob
+	for _, c := range cids {
+		if !have[c] && c.String() > cursor {
+			missing = append(missing, MissingBlob{CID: c, RecordURI: byCID[c]})
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i].CID.String() < missing[j].CID.String() })
+
+	var nextCursor string
+	if limit > 0 && len(missing) > limit {
+		missing = missing[:limit]
+		nextCursor = missing[limit-1].CID.String()
+	}
+	return missing, nextCursor, nil
+}
+
+// repository returns a repository that exists
+func (s *Service) repository(did string) (*repository.Repository, error) {
+	repo, err := s.repos.GetRepository(did)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	return repo, nil
+}
+
+// activeRepository checks a repository exists and may be served
+func (s *Service) activeRepository(did string) error {
+	repo, err := s.repository(did)
+	if err != nil {
+		return err
+	}
+	if !repo.Active() {
+		return repository.InactiveError{DID: did, Status: repo.Status}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package blobs_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"Coves/internal/core/blobs"
+	coreerrors "Coves/internal/core/errors"
+	"Coves/internal/core/repository"
+
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+)
+
+// fakeBlobRepo keeps blob metadata in memory
+type fakeBlobRepo struct {
+	mu    sync.Mutex
+	blobs map[string]*blobs.Blob // Keyed by DID and CID
+}
+
+func newFakeBlobRepo() *fakeBlobRepo {
+	return &fakeBlobRepo{blobs: make(map[string]*blobs.Blob)}
+}
+
+func (f *fakeBlobRepo) Create(blob *blobs.Blob) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := blob.DID + " " + blob.CID.String()
+	if _, exists := f.blobs[key]; !exists {
+		f.blobs[key] = blob
+	}
+	return nil
+}
+
+func (f *fakeBlobRepo) Get(did string, c cid.Cid) (*blobs.Blob, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.blobs[did+" "+c.String()], nil
+}
+
+func (f *fakeBlobRepo) List(did string, limit int, afterCID string) ([]*blobs.Blob, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []*blobs.Blob
+	for _, blob := range f.blobs {
+		if blob.DID == did && blob.CID.String() > afterCID {
+			out = append(out, blob)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CID.String() < out[j].CID.String() })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (f *fakeBlobRepo) Has(did string, cids []cid.Cid) (map[cid.Cid]bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	have := make(map[cid.Cid]bool)
+	for _, c := range cids {
+		if _, exists := f.blobs[did+" "+c.String()]; exists {
+			have[c] = true
+		}
+	}
+	return have, nil
+}
+
+// fakeRepos serves repositories and their blob references
+type fakeRepos struct {
+	repos map[string]*repository.Repository
+	refs  map[string][]repository.BlobRef
+}
+
+func (f *fakeRepos) GetRepository(did string) (*repository.Repository, error) {
+	repo, exists := f.repos[did]
+	if !exists {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	return repo, nil
+}
+
+func (f *fakeRepos) ListBlobRefs(did string) ([]repository.BlobRef, error) {
+	return f.refs[did], nil
+}
+
+func blobCID(t *testing.T, data string) cid.Cid {
+	t.Helper()
+	c, err := cid.V1Builder{Codec: cid.Raw, MhType: multihash.SHA2_256}.Sum([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to compute CID: %v", err)
+	}
+	return c
+}
+
+func setupService(t *testing.T) (*blobs.Service, *fakeRepos) {
+	t.Helper()
+	store, err := blobs.NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	repos := &fakeRepos{
+		repos: map[string]*repository.Repository{
+			"did:plc:active":    {DID: "did:plc:active", Status: repository.StatusActive},
+			"did:plc:migrated":  {DID: "did:plc:migrated", Status: repository.StatusDeactivated},
+			"did:plc:takendown": {DID: "did:plc:takendown", Status: repository.StatusTakendown},
+		},
+		refs: make(map[string][]repository.BlobRef),
+	}
+	return blobs.NewService(newFakeBlobRepo(), store, repos), repos
+}
+
+func TestService_UploadAndGet(t *testing.T) {
+	service, _ := setupService(t)
+	ctx := context.Background()
+
+	blob, err := service.Upload(ctx, "did:plc:active", "image/png", strings.NewReader("png bytes"))
+	if err != nil {
+		t.Fatalf("Failed to upload blob: %v", err)
+	}
+	if blob.CID != blobCID(t, "png bytes") || blob.Size != 9 || blob.MimeType != "image/png" {
+		t.Errorf("Unexpected blob %+v", blob)
+	}
+
+	got, rc, err := service.Get(ctx, "did:plc:active", blob.CID)
+	if err != nil {
+		t.Fatalf("Failed to get blob: %v", err)
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil || string(data) != "png bytes" || got.MimeType != "image/png" {
+		t.Errorf("Expected the uploaded bytes, got %q %q %v", data, got.MimeType, err)
+	}
+
+	// Blobs belong to the account that uploaded them
+	if _, _, err := service.Get(ctx, "did:plc:migrated", blob.CID); err == nil {
+		t.Error("Expected another account's blob to be refused")
+	}
+	if _, _, err := service.Get(ctx, "did:plc:active", blobCID(t, "other")); !errors.Is(err, coreerrors.NotFoundError{Resource: coreerrors.ResourceBlob}) {
+		t.Errorf("Expected a missing blob, got %v", err)
+	}
+
+	untyped, err := service.Upload(ctx, "did:plc:active", "", strings.NewReader("raw"))
+	if err != nil || untyped.MimeType != blobs.DefaultMimeType {
+		t.Errorf("Expected the default MIME type, got %v %v", untyped, err)
+	}
+}
+
+func TestService_UploadLimits(t *testing.T) {
+	service, _ := setupService(t)
+	ctx := context.Background()
+	service.SetMaxSize(4)
+
+	if _, err := service.Upload(ctx, "did:plc:active", "", strings.NewReader("four")); err != nil {
+		t.Errorf("Expected a blob at the limit to be accepted: %v", err)
+	}
+	if _, err := service.Upload(ctx, "did:plc:active", "", strings.NewReader("fives")); !errors.Is(err, blobs.ErrBlobTooLarge) {
+		t.Errorf("Expected ErrBlobTooLarge, got %v", err)
+	}
+
+	// Deactivated accounts backfill blobs, but taken down ones can't
+	if _, err := service.Upload(ctx, "did:plc:migrated", "", strings.NewReader("ok")); err != nil {
+		t.Errorf("Expected a deactivated account to upload: %v", err)
+	}
+	var inactive repository.InactiveError
+	if _, err := service.Upload(ctx, "did:plc:takendown", "", strings.NewReader("no")); !errors.As(err, &inactive) {
+		t.Errorf("Expected a taken down account to be refused, got %v", err)
+	}
+	if _, err := service.Upload(ctx, "did:plc:unknown", "", strings.NewReader("no")); !errors.Is(err, coreerrors.ErrNotFound) {
+		t.Errorf("Expected an unknown account to be refused, got %v", err)
+	}
+
+	// They aren't served until activated
+	if _, _, err := service.List("did:plc:migrated", 10, ""); !errors.As(err, &inactive) {
+		t.Errorf("Expected listing to be refused while deactivated, got %v", err)
+	}
+}
+
+func TestService_ListMissing(t *testing.T) {
+	service, repos := setupService(t)
+	ctx := context.Background()
+	did := "did:plc:migrated"
+
+	var cids []cid.Cid
+	for _, data := range []string{"a", "b", "c", "d"} {
+		c := blobCID(t, data)
+		cids = append(cids, c)
+		repos.refs[did] = append(repos.refs[did], repository.BlobRef{CID: c, RecordURI: "at://" + did + "/social.coves.test.image/" + data})
+	}
+	// A second record linking to a blob doesn't report it twice
+	repos.refs[did] = append(repos.refs[did], repository.BlobRef{CID: cids[0], RecordURI: "at://" + did + "/social.coves.test.image/again"})
+
+	if _, err := service.Upload(ctx, did, "", bytes.NewReader([]byte("b"))); err != nil {
+		t.Fatalf("Failed to upload blob: %v", err)
+	}
+
+	var missing []blobs.MissingBlob
+	cursor := ""
+	for {
+		page, next, err := service.ListMissing(did, 2, cursor)
+		if err != nil {
+			t.Fatalf("Failed to list missing blobs: %v", err)
+		}
+		missing = append(missing, page...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if len(missing) != 3 {
+		t.Fatalf("Expected 3 missing blobs, got %v", missing)
+	}
+	for i, m := range missing {
+		if m.CID == cids[1] {
+			t.Errorf("Uploaded blob %s reported missing", m.CID)
+		}
+		if i > 0 && missing[i-1].CID.String() >= m.CID.String() {
+			t.Errorf("Expected missing blobs ordered by CID, got %v", missing)
+		}
+		if m.CID == cids[0] && !strings.HasSuffix(m.RecordURI, "/a") {
+			t.Errorf("Expected the first record linking to the blob, got %s", m.RecordURI)
+		}
+	}
+
+	// Once everything is uploaded nothing is missing
+	for _, data := range []string{"a", "c", "d"} {
+		if _, err := service.Upload(ctx, did, "", strings.NewReader(data)); err != nil {
+			t.Fatalf("Failed to upload blob: %v", err)
+		}
+	}
+	if missing, _, err := service.ListMissing(did, 10, ""); err != nil || len(missing) != 0 {
+		t.Errorf("Expected no missing blobs, got %v %v", missing, err)
+	}
+}
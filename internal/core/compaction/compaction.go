@@ -0,0 +1,91 @@
+// Package compaction keeps carstore shard counts down. Every commit writes a
+// new shard, so a background scheduler periodically picks repositories with
+// many shards and merges them, recording each result.
+package compaction
+
+import (
+	"time"
+
+	"Coves/internal/atproto/carstore"
+	"Coves/internal/core/repository"
+)
+
+// What started a compaction
+const (
+	TriggerScheduled = "scheduled"
+	TriggerAdmin     = "admin"
+)
+
+// Result records one compaction of a repository
+type Result struct {
+	ID            int64
+	DID           string
+	Aggressive    bool
+	Trigger       string // TriggerScheduled or TriggerAdmin
+	StartShards   int
+	EndShards     int
+	ShardsDeleted int
+	Duration      time.Duration
+	Error         string // Empty if the compaction succeeded
+	CreatedAt     time.Time
+}
+
+// SweepResult summarises a pass over every repository
+type SweepResult struct {
+	Considered int // Repositories checked
+	Compacted  int
+	Failed     int
+	Results    []*Result
+}
+
+// Policy decides which repositories a sweep compacts and how
+type Policy struct {
+	Interval    time.Duration // Time between scheduled sweeps; zero disables them
+	Concurrency int           // Repositories compacted at once
+	MaxPerSweep int           // Most repositories compacted in one sweep, those with the most shards first
+
+	// Repositories with at least MinShards shards are compacted, unless they
+	// were written to within WriteCooldown; they are likely still being
+	// written to and will be picked up by a later sweep
+	MinShards     int
+	WriteCooldown time.Duration
+
+	// Repositories not written to for DormantAfter are compacted
+	// aggressively once they have DormantMinShards shards, since their
+	// shards won't change again
+	DormantAfter     time.Duration
+	DormantMinShards int
+}
+
+// DefaultPolicy suits a single server. Indigo doesn't merge repositories with
+// fewer than 20 shards, so lower thresholds only add work.
+var DefaultPolicy = Policy{
+	Interval:         time.Hour,
+	Concurrency:      2,
+	MaxPerSweep:      200,
+	MinShards:        50,
+	WriteCooldown:    5 * time.Minute,
+	DormantAfter:     7 * 24 * time.Hour,
+	DormantMinShards: 20,
+}
+
+// Target is what the scheduler compacts; *repository.Service implements it.
+// CompactRepository must hold the repository's write lock while it runs.
+type Target interface {
+	ListRepositories(limit int, cursor string) ([]*repository.Repository, string, error)
+	ShardCount(did string) (int, error)
+	CompactRepository(did string, aggressive bool) (*carstore.CompactionStats, error)
+}
+
+// ResultRepository defines the data access interface for compaction results
+type ResultRepository interface {
+	// Create stores a result, setting its ID and CreatedAt
+	Create(result *Result) error
+
+	// GetLatestSuccess returns the most recent successful compaction of a
+	// repository, or nil if it has never been compacted
+	GetLatestSuccess(did string) (*Result, error)
+
+	// List returns the most recent results, newest first
+	List(limit int) ([]*Result, error)
+}
@@ -0,0 +1,286 @@
+package compaction
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"Coves/internal/atproto/carstore"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// listPageSize is how many repositories a sweep reads at once
+const listPageSize = 500
+
+var (
+	compactionRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "coves_compaction_runs_total",
+		Help: "Repository compactions, by mode, trigger and result",
+	}, []string{"mode", "trigger", "result"})
+	compactionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "coves_compaction_duration_seconds",
+		Help:    "Time taken to compact a repository, including waiting for its write lock",
+		Buckets: prometheus.ExponentialBuckets(0.01, 4, 9),
+	}, []string{"mode"})
+	compactionShardsDeleted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "coves_compaction_shards_deleted_total",
+		Help: "Carstore shards removed by compaction",
+	})
+	compactionCandidates = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "coves_compaction_sweep_candidates",
+		Help: "Repositories the last sweep chose to compact",
+	})
+	compactionLastSweep = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "coves_compaction_last_sweep_timestamp_seconds",
+		Help: "When the last compaction sweep finished",
+	})
+)
+
+// Scheduler compacts repositories in the background according to a Policy
+type Scheduler struct {
+	target  Target
+	results ResultRepository
+	policy  Policy
+
+	sweeping atomic.Bool
+}
+
+// NewScheduler creates a scheduler that compacts target's repositories and
+// records results in results
+func NewScheduler(target Target, results ResultRepository, policy Policy) *Scheduler {
+	if policy.Concurrency < 1 {
+		policy.Concurrency = 1
+	}
+	return &Scheduler{
+		target:  target,
+		results: results,
+		policy:  policy,
+	}
+}
+
+// Run sweeps every policy interval until ctx is done. It returns at once if
+// the policy has no interval.
+func (s *Scheduler) Run(ctx context.Context) {
+	if s.policy.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.policy.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, ok, err := s.Sweep(ctx, TriggerScheduled)
+			if err != nil {
+				log.Printf("Compaction sweep failed: %v", err)
+			} else if ok && result.Compacted+result.Failed > 0 {
+				log.Printf("Compaction sweep checked %d repositories: %d compacted, %d failed", result.Considered, result.Compacted, result.Failed)
+			}
+		}
+	}
+}
+
+// StartSweep starts a sweep in the background, reporting false if one is
+// already running
+func (s *Scheduler) StartSweep(trigger string) bool {
+	if !s.sweeping.CompareAndSwap(false, true) {
+		return false
+	}
+	go func() {
+		defer s.sweeping.Store(false)
+		result, err := s.sweep(context.Background(), trigger)
+		if err != nil {
+			log.Printf("Compaction sweep failed: %v", err)
+			return
+		}
+		log.Printf("Compaction sweep checked %d repositories: %d compacted, %d failed", result.Considered, result.Compacted, result.Failed)
+	}()
+	return true
+}
+
+// Sweep compacts every repository the policy picks and waits for them to
+// finish. It reports false without doing anything if a sweep is already
+// running.
+func (s *Scheduler) Sweep(ctx context.Context, trigger string) (*SweepResult, bool, error) {
+	if !s.sweeping.CompareAndSwap(false, true) {
+		return nil, false, nil
+	}
+	defer s.sweeping.Store(false)
+
+	result, err := s.sweep(ctx, trigger)
+	return result, true, err
+}
+
+func (s *Scheduler) sweep(ctx context.Context, trigger string) (*SweepResult, error) {
+	picked, considered, err := s.candidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	compactionCandidates.Set(float64(len(picked)))
+
+	result := &SweepResult{Considered: considered}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, s.policy.Concurrency)
+
+	for _, c := range picked {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(c candidate) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			r, err := s.Compact(c.did, c.aggressive, trigger)
+			mu.Lock()
+			defer mu.Unlock()
+			result.Results = append(result.Results, r)
+			if err != nil {
+				log.Printf("Failed to compact %s: %v", c.did, err)
+				result.Failed++
+			} else {
+				result.Compacted++
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	compactionLastSweep.SetToCurrentTime()
+	return result, ctx.Err()
+}
+
+// candidate is a repository a sweep will compact
+type candidate struct {
+	did        string
+	shards     int
+	aggressive bool
+}
+
+// candidates picks the repositories to compact, most shards first, and
+// reports how many it considered
+func (s *Scheduler) candidates(ctx context.Context) ([]candidate, int, error) {
+	var picked []candidate
+	considered := 0
+	now := time.Now()
+
+	cursor := ""
+	for {
+		repos, next, err := s.target.ListRepositories(listPageSize, cursor)
+		if err != nil {
+			return nil, considered, err
+		}
+
+		for _, repo := range repos {
+			if err := ctx.Err(); err != nil {
+				return nil, considered, err
+			}
+			considered++
+
+			idle := now.Sub(repo.UpdatedAt)
+			if idle < s.policy.WriteCooldown {
+				continue
+			}
+			dormant := s.policy.DormantAfter > 0 && idle >= s.policy.DormantAfter
+			threshold := s.policy.MinShards
+			if dormant {
+				threshold = s.policy.DormantMinShards
+			}
+
+			shards, err := s.target.ShardCount(repo.DID)
+			if err != nil {
+				log.Printf("Failed to count shards for %s: %v", repo.DID, err)
+				continue
+			}
+			if shards < threshold {
+				continue
+			}
+
+			// Nothing has been written since the last compaction, which left
+			// what it couldn't merge; only a first aggressive pass can do more
+			last, err := s.results.GetLatestSuccess(repo.DID)
+			if err != nil {
+				return nil, considered, err
+			}
+			if last != nil && last.CreatedAt.After(repo.UpdatedAt) && (last.Aggressive || !dormant) {
+				continue
+			}
+
+			picked = append(picked, candidate{did: repo.DID, shards: shards, aggressive: dormant})
+		}
+
+		if next == "" || len(repos) == 0 {
+			break
+		}
+		cursor = next
+	}
+
+	sort.SliceStable(picked, func(i, j int) bool { return picked[i].shards > picked[j].shards })
+	if s.policy.MaxPerSweep > 0 && len(picked) > s.policy.MaxPerSweep {
+		picked = picked[:s.policy.MaxPerSweep]
+	}
+	return picked, considered, nil
+}
+
+// Compact compacts one repository and records the result, which is
+// returned even if the compaction fails
+func (s *Scheduler) Compact(did string, aggressive bool, trigger string) (*Result, error) {
+	mode := "normal"
+	if aggressive {
+		mode = "aggressive"
+	}
+
+	result := &Result{DID: did, Aggressive: aggressive, Trigger: trigger}
+	start := time.Now()
+
+	shards, err := s.target.ShardCount(did)
+	if err == nil {
+		result.StartShards = shards
+		var stats *carstore.CompactionStats
+		stats, err = s.target.CompactRepository(did, aggressive)
+		if err == nil {
+			result.ShardsDeleted = stats.ShardsDeleted
+			result.EndShards, err = s.target.ShardCount(did)
+		}
+	}
+	result.Duration = time.Since(start)
+
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+		result.Error = err.Error()
+	}
+	compactionRuns.WithLabelValues(mode, trigger, outcome).Inc()
+	compactionDuration.WithLabelValues(mode).Observe(result.Duration.Seconds())
+	compactionShardsDeleted.Add(float64(result.ShardsDeleted))
+
+	if rerr := s.results.Create(result); rerr != nil {
+		log.Printf("Failed to record compaction of %s: %v", did, rerr)
+	}
+
+	return result, err
+}
+
+// Recent returns the most recent compaction results, newest first
+func (s *Scheduler) Recent(limit int) ([]*Result, error) {
+	return s.results.List(limit)
+}
+
+// LastCompaction returns a repository's most recent successful compaction,
+// or nil if it has never been compacted
+func (s *Scheduler) LastCompaction(did string) (*Result, error) {
+	return s.results.GetLatestSuccess(did)
+}
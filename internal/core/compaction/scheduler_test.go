@@ -0,0 +1,229 @@
+package compaction_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"Coves/internal/atproto/carstore"
+	"Coves/internal/core/compaction"
+	"Coves/internal/core/repository"
+)
+
+// fakeTarget holds shard counts for repositories; compacting one leaves
+// a tenth of its shards
+type fakeTarget struct {
+	mu      sync.Mutex
+	repos   map[string]*repository.Repository
+	shards  map[string]int
+	fail    map[string]error
+	calls   map[string]bool // DID to whether it was compacted aggressively
+	running int
+	maxSeen int
+}
+
+func newFakeTarget() *fakeTarget {
+	return &fakeTarget{
+		repos:  make(map[string]*repository.Repository),
+		shards: make(map[string]int),
+		fail:   make(map[string]error),
+		calls:  make(map[string]bool),
+	}
+}
+
+func (f *fakeTarget) add(did string, shards int, idle time.Duration) {
+	f.repos[did] = &repository.Repository{DID: did, UpdatedAt: time.Now().Add(-idle)}
+	f.shards[did] = shards
+}
+
+func (f *fakeTarget) ListRepositories(limit int, cursor string) ([]*repository.Repository, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var dids []string
+	for did := range f.repos {
+		if did > cursor {
+			dids = append(dids, did)
+		}
+	}
+	sort.Strings(dids)
+	var next string
+	if len(dids) > limit {
+		dids = dids[:limit]
+		next = dids[limit-1]
+	}
+	repos := make([]*repository.Repository, len(dids))
+	for i, did := range dids {
+		repos[i] = f.repos[did]
+	}
+	return repos, next, nil
+}
+
+func (f *fakeTarget) ShardCount(did string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.shards[did], nil
+}
+
+func (f *fakeTarget) CompactRepository(did string, aggressive bool) (*carstore.CompactionStats, error) {
+	f.mu.Lock()
+	f.running++
+	if f.running > f.maxSeen {
+		f.maxSeen = f.running
+	}
+	f.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.running--
+	f.calls[did] = aggressive
+	if err := f.fail[did]; err != nil {
+		return nil, err
+	}
+	before := f.shards[did]
+	f.shards[did] = before/10 + 1
+	return &carstore.CompactionStats{StartShards: before, ShardsDeleted: before - f.shards[did]}, nil
+}
+
+// fakeResults keeps compaction results in memory
+type fakeResults struct {
+	mu      sync.Mutex
+	results []*compaction.Result
+}
+
+func (f *fakeResults) Create(result *compaction.Result) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result.ID = int64(len(f.results) + 1)
+	result.CreatedAt = time.Now()
+	f.results = append(f.results, result)
+	return nil
+}
+
+func (f *fakeResults) GetLatestSuccess(did string) (*compaction.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := len(f.results) - 1; i >= 0; i-- {
+		if r := f.results[i]; r.DID == did && r.Error == "" {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeResults) List(limit int) ([]*compaction.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []*compaction.Result
+	for i := len(f.results) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, f.results[i])
+	}
+	return out, nil
+}
+
+func TestScheduler_Sweep(t *testing.T) {
+	target := newFakeTarget()
+	target.add("did:plc:busy", 80, time.Hour)
+	target.add("did:plc:busier", 120, time.Hour)
+	target.add("did:plc:hot", 200, time.Minute)
+	target.add("did:plc:small", 10, time.Hour)
+	target.add("did:plc:dormant", 25, 30*24*time.Hour)
+	target.add("did:plc:dormantsmall", 5, 30*24*time.Hour)
+
+	results := &fakeResults{}
+	scheduler := compaction.NewScheduler(target, results, compaction.DefaultPolicy)
+
+	sweep, ok, err := scheduler.Sweep(context.Background(), compaction.TriggerScheduled)
+	if err != nil || !ok {
+		t.Fatalf("Sweep failed: ok=%v err=%v", ok, err)
+	}
+	if sweep.Considered != 6 || sweep.Compacted != 3 || sweep.Failed != 0 {
+		t.Errorf("Unexpected sweep %+v", sweep)
+	}
+
+	// Busy repositories get a normal compaction and dormant ones an
+	// aggressive one; hot and small repositories are left alone
+	want := map[string]bool{"did:plc:busy": false, "did:plc:busier": false, "did:plc:dormant": true}
+	if len(target.calls) != len(want) {
+		t.Errorf("Expected %d compactions, got %v", len(want), target.calls)
+	}
+	for did, aggressive := range want {
+		if got, ok := target.calls[did]; !ok || got != aggressive {
+			t.Errorf("%s: expected aggressive=%v, got compacted=%v aggressive=%v", did, aggressive, ok, got)
+		}
+	}
+	if target.maxSeen > compaction.DefaultPolicy.Concurrency {
+		t.Errorf("Expected at most %d concurrent compactions, saw %d", compaction.DefaultPolicy.Concurrency, target.maxSeen)
+	}
+
+	// Every result is recorded
+	recent, _ := scheduler.Recent(10)
+	if len(recent) != 3 {
+		t.Fatalf("Expected 3 recorded results, got %d", len(recent))
+	}
+	for _, r := range recent {
+		if r.Trigger != compaction.TriggerScheduled || r.EndShards >= r.StartShards || r.ShardsDeleted == 0 {
+			t.Errorf("Unexpected result %+v", r)
+		}
+	}
+
+	// Nothing has been written since, so the next sweep has nothing to do
+	// even though a dormant repository's shards are still over its threshold
+	target.shards["did:plc:dormant"] = 25
+	target.calls = make(map[string]bool)
+	if sweep, _, _ := scheduler.Sweep(context.Background(), compaction.TriggerScheduled); sweep.Compacted != 0 {
+		t.Errorf("Expected nothing to compact, got %v", target.calls)
+	}
+}
+
+func TestScheduler_MaxPerSweep(t *testing.T) {
+	target := newFakeTarget()
+	target.add("did:plc:a", 60, time.Hour)
+	target.add("did:plc:b", 90, time.Hour)
+	target.add("did:plc:c", 70, time.Hour)
+
+	policy := compaction.DefaultPolicy
+	policy.MaxPerSweep = 2
+	scheduler := compaction.NewScheduler(target, &fakeResults{}, policy)
+
+	if _, _, err := scheduler.Sweep(context.Background(), compaction.TriggerScheduled); err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+
+	// The repositories with the most shards go first
+	if _, ok := target.calls["did:plc:a"]; ok || len(target.calls) != 2 {
+		t.Errorf("Expected b and c to be compacted, got %v", target.calls)
+	}
+}
+
+func TestScheduler_CompactFailure(t *testing.T) {
+	target := newFakeTarget()
+	target.add("did:plc:broken", 60, time.Hour)
+	target.fail["did:plc:broken"] = errors.New("disk full")
+
+	results := &fakeResults{}
+	scheduler := compaction.NewScheduler(target, results, compaction.DefaultPolicy)
+
+	result, err := scheduler.Compact("did:plc:broken", false, compaction.TriggerAdmin)
+	if err == nil {
+		t.Fatal("Expected the compaction to fail")
+	}
+	if result.Error == "" || result.StartShards != 60 {
+		t.Errorf("Expected the failure to be recorded, got %+v", result)
+	}
+
+	// A failed compaction doesn't count as the latest, so the repository
+	// is picked again
+	delete(target.fail, "did:plc:broken")
+	sweep, _, err := scheduler.Sweep(context.Background(), compaction.TriggerScheduled)
+	if err != nil || sweep.Compacted != 1 {
+		t.Errorf("Expected the repository to be retried, got %+v %v", sweep, err)
+	}
+	if len(results.results) != 2 {
+		t.Errorf("Expected 2 recorded results, got %d", len(results.results))
+	}
+}
@@ -1,3 +1,6 @@
+// Package errors defines the error types the core services and storage
+// layers return. Callers test for them with errors.Is and errors.As rather
+// than by matching error text; the API maps them to responses in one place.
 package errors
 
 import (
@@ -16,15 +19,36 @@ var (
 	ErrValidationFailed = errors.New("validation failed")
 )
 
+// Resources named in NotFoundError and ConflictError. Clients see a missing
+// repository, record or block as a named XRPC error.
+const (
+	ResourceRepo       = "repository"
+	ResourceRecord     = "record"
+	ResourceBlock      = "block"
+	ResourceBlob       = "blob"
+	ResourceCommit     = "commit"
+	ResourceRevision   = "revision"
+	ResourceUser       = "user"
+	ResourceSigningKey = "signing key"
+)
+
 type ValidationError struct {
 	Field   string
 	Message string
 }
 
 func (e ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
 	return fmt.Sprintf("validation error on field '%s': %s", e.Field, e.Message)
 }
 
+// Is makes every ValidationError match ErrValidationFailed and ErrInvalidInput
+func (e ValidationError) Is(target error) bool {
+	return target == ErrValidationFailed || target == ErrInvalidInput
+}
+
 type ConflictError struct {
 	Resource string
 	Field    string
@@ -32,18 +56,45 @@ type ConflictError struct {
 }
 
 func (e ConflictError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s already exists", e.Resource)
+	}
 	return fmt.Sprintf("%s with %s '%s' already exists", e.Resource, e.Field, e.Value)
 }
 
+// Is makes every ConflictError match ErrAlreadyExists, and a ConflictError
+// with only a Resource match any conflict on that resource
+func (e ConflictError) Is(target error) bool {
+	if target == ErrAlreadyExists {
+		return true
+	}
+	t, ok := target.(ConflictError)
+	return ok && t.Resource == e.Resource && t.Field == ""
+}
+
 type NotFoundError struct {
 	Resource string
 	ID       interface{}
 }
 
 func (e NotFoundError) Error() string {
+	if e.ID == nil {
+		return fmt.Sprintf("%s not found", e.Resource)
+	}
 	return fmt.Sprintf("%s with ID '%v' not found", e.Resource, e.ID)
 }
 
+// Is makes every NotFoundError match ErrNotFound, and a NotFoundError with
+// only a Resource match any missing resource of that kind, so packages can
+// export sentinels such as NotFoundError{Resource: ResourceRecord}
+func (e NotFoundError) Is(target error) bool {
+	if target == ErrNotFound {
+		return true
+	}
+	t, ok := target.(NotFoundError)
+	return ok && t.Resource == e.Resource && t.ID == nil
+}
+
 func NewValidationError(field, message string) error {
 	return ValidationError{
 		Field:   field,
@@ -64,4 +115,4 @@ func NewNotFoundError(resource string, id interface{}) error {
 		Resource: resource,
 		ID:       id,
 	}
-}
\ No newline at end of file
+}
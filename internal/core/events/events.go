@@ -0,0 +1,71 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	indigoevents "github.com/bluesky-social/indigo/events"
+)
+
+// Event types, matching the message types of com.atproto.sync.subscribeRepos
+const (
+	TypeCommit   = "#commit"
+	TypeSync     = "#sync"
+	TypeIdentity = "#identity"
+	TypeAccount  = "#account"
+)
+
+var (
+	// ErrFutureCursor is returned when a subscriber asks to resume from a
+	// sequence number that hasn't been reached yet
+	ErrFutureCursor = errors.New("cursor in the future")
+)
+
+// Event is a sequenced entry in the repository event log
+type Event struct {
+	Seq       int64  // Assigned by the log when the event is appended
+	DID       string // Repository the event is about
+	Type      string // One of the Type* constants
+	Payload   []byte // DAG-CBOR message body, encoded with seq 0
+	CreatedAt time.Time
+}
+
+// EventService sequences repository events and streams them to subscribers
+type EventService interface {
+	// Publish appends evt to the log, setting its sequence number, and wakes
+	// any subscribers
+	Publish(ctx context.Context, evt *indigoevents.XRPCStreamEvent) error
+
+	// Appended wakes subscribers for events that were appended to the log
+	// directly, in the same transaction as the change they describe
+	Appended(events []*Event)
+
+	// Subscribe calls cb with every event after cursor, in order, then with
+	// new events as they are published, until ctx is done or cb fails. A nil
+	// cursor starts from the next published event.
+	Subscribe(ctx context.Context, cursor *int64, cb func(*indigoevents.XRPCStreamEvent) error) error
+
+	// Prune removes events older than the retention period
+	Prune() (int64, error)
+}
+
+// EventRepository defines the data access interface for the event log.
+// Events must become visible in sequence order: once an event can be read,
+// every event with a lower sequence number is either readable too or will
+// never be, so subscribers can't skip an event that is still being written.
+type EventRepository interface {
+	// Append stores an event, setting its Seq and CreatedAt
+	Append(event *Event) error
+
+	// ListAfter returns up to limit events with a sequence number greater than after
+	ListAfter(after int64, limit int) ([]*Event, error)
+
+	// SeqRange returns the lowest retained sequence number (zero if the log
+	// is empty) and the highest sequence number ever assigned, waiting for
+	// appends in progress to finish so none is skipped by starting after it
+	SeqRange() (first int64, last int64, err error)
+
+	// DeleteBefore removes events created before t
+	DeleteBefore(t time.Time) (int64, error)
+}
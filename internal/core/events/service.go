@@ -0,0 +1,241 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	indigoevents "github.com/bluesky-social/indigo/events"
+	lexutil "github.com/bluesky-social/indigo/lex/util"
+)
+
+const (
+	// subscribeBatchSize is how many events a subscriber reads from the log at once
+	subscribeBatchSize = 500
+
+	// pollInterval bounds how long a subscriber waits before checking the log
+	// again, so events appended by other processes are picked up
+	pollInterval = 5 * time.Second
+)
+
+// Service implements EventService on top of an EventRepository. Subscribers
+// always read events back from the log, so one that falls behind catches up
+// from storage instead of being dropped.
+type Service struct {
+	repo      EventRepository
+	retention time.Duration
+
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{} // wake-up channel per subscriber
+}
+
+// NewService creates a new event service. Events older than retention are
+// removed by Prune; a retention of zero keeps events forever.
+func NewService(repo EventRepository, retention time.Duration) *Service {
+	return &Service{
+		repo:      repo,
+		retention: retention,
+		subs:      make(map[chan struct{}]struct{}),
+	}
+}
+
+// Publish appends evt to the log, setting its sequence number, and wakes
+// any subscribers
+func (s *Service) Publish(ctx context.Context, evt *indigoevents.XRPCStreamEvent) error {
+	event, err := Encode(evt)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Append(event); err != nil {
+		return fmt.Errorf("appending %s event: %w", event.Type, err)
+	}
+	_, _, _, seq, _ := eventBody(evt)
+	*seq = event.Seq
+
+	s.wake()
+	return nil
+}
+
+// Appended wakes subscribers for events that were appended to the log
+// directly, in the same transaction as the change they describe
+func (s *Service) Appended(events []*Event) {
+	if len(events) > 0 {
+		s.wake()
+	}
+}
+
+// wake tells every subscriber the log has grown
+func (s *Service) wake() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for wake := range s.subs {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Subscribe calls cb with every event after cursor, in order, then with new
+// events as they are published, until ctx is done or cb fails. A nil cursor
+// starts from the next published event. A cursor ahead of the log fails with
+// ErrFutureCursor; one older than the retained events gets an OutdatedCursor
+// info message and then everything that is left.
+func (s *Service) Subscribe(ctx context.Context, cursor *int64, cb func(*indigoevents.XRPCStreamEvent) error) error {
+	// Register before reading the log so no publish is missed in between
+	wake := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.subs[wake] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subs, wake)
+		s.mu.Unlock()
+	}()
+
+	first, last, err := s.repo.SeqRange()
+	if err != nil {
+		return fmt.Errorf("reading event log bounds: %w", err)
+	}
+
+	after := last
+	if cursor != nil {
+		if *cursor > last {
+			return fmt.Errorf("%w: %d is after the latest event %d", ErrFutureCursor, *cursor, last)
+		}
+		after = *cursor
+
+		if (first == 0 && after < last) || (first > 0 && after < first-1) {
+			msg := "requested cursor exceeded limit, possibly missing events"
+			info := &indigoevents.XRPCStreamEvent{
+				RepoInfo: &comatproto.SyncSubscribeRepos_Info{Name: "OutdatedCursor", Message: &msg},
+			}
+			if err := cb(info); err != nil {
+				return err
+			}
+		}
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		batch, err := s.repo.ListAfter(after, subscribeBatchSize)
+		if err != nil {
+			return fmt.Errorf("reading events after %d: %w", after, err)
+		}
+		for _, event := range batch {
+			evt, err := Decode(event)
+			if err != nil {
+				return err
+			}
+			if err := cb(evt); err != nil {
+				return err
+			}
+			after = event.Seq
+		}
+		if len(batch) == subscribeBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune removes events older than the retention period, returning how many
+// were removed
+func (s *Service) Prune() (int64, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+
+	n, err := s.repo.DeleteBefore(time.Now().Add(-s.retention))
+	if err != nil {
+		return 0, fmt.Errorf("pruning events: %w", err)
+	}
+	return n, nil
+}
+
+// eventBody returns the message type, repository DID, body and sequence
+// number field of a publishable event
+func eventBody(evt *indigoevents.XRPCStreamEvent) (string, string, lexutil.CBOR, *int64, error) {
+	switch {
+	case evt.RepoCommit != nil:
+		return TypeCommit, evt.RepoCommit.Repo, evt.RepoCommit, &evt.RepoCommit.Seq, nil
+	case evt.RepoSync != nil:
+		return TypeSync, evt.RepoSync.Did, evt.RepoSync, &evt.RepoSync.Seq, nil
+	case evt.RepoIdentity != nil:
+		return TypeIdentity, evt.RepoIdentity.Did, evt.RepoIdentity, &evt.RepoIdentity.Seq, nil
+	case evt.RepoAccount != nil:
+		return TypeAccount, evt.RepoAccount.Did, evt.RepoAccount, &evt.RepoAccount.Seq, nil
+	default:
+		return "", "", nil, nil, fmt.Errorf("unsupported event kind")
+	}
+}
+
+// Encode converts evt into an event for the log. Its body is stored without
+// a sequence number, which is filled in when the event is read back.
+func Encode(evt *indigoevents.XRPCStreamEvent) (*Event, error) {
+	typ, did, body, seq, err := eventBody(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	*seq = 0
+	buf := new(bytes.Buffer)
+	if err := body.MarshalCBOR(buf); err != nil {
+		return nil, fmt.Errorf("encoding %s event: %w", typ, err)
+	}
+
+	return &Event{
+		DID:     did,
+		Type:    typ,
+		Payload: buf.Bytes(),
+	}, nil
+}
+
+// Decode rebuilds a stream event from the log, setting its sequence number
+func Decode(event *Event) (*indigoevents.XRPCStreamEvent, error) {
+	evt := &indigoevents.XRPCStreamEvent{}
+	r := bytes.NewReader(event.Payload)
+
+	var err error
+	switch event.Type {
+	case TypeCommit:
+		evt.RepoCommit = &comatproto.SyncSubscribeRepos_Commit{}
+		err = evt.RepoCommit.UnmarshalCBOR(r)
+		evt.RepoCommit.Seq = event.Seq
+	case TypeSync:
+		evt.RepoSync = &comatproto.SyncSubscribeRepos_Sync{}
+		err = evt.RepoSync.UnmarshalCBOR(r)
+		evt.RepoSync.Seq = event.Seq
+	case TypeIdentity:
+		evt.RepoIdentity = &comatproto.SyncSubscribeRepos_Identity{}
+		err = evt.RepoIdentity.UnmarshalCBOR(r)
+		evt.RepoIdentity.Seq = event.Seq
+	case TypeAccount:
+		evt.RepoAccount = &comatproto.SyncSubscribeRepos_Account{}
+		err = evt.RepoAccount.UnmarshalCBOR(r)
+		evt.RepoAccount.Seq = event.Seq
+	default:
+		return nil, fmt.Errorf("unknown event type %q at seq %d", event.Type, event.Seq)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s event %d: %w", event.Type, event.Seq, err)
+	}
+
+	return evt, nil
+}
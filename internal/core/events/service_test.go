@@ -0,0 +1,224 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"Coves/internal/core/events"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	indigoevents "github.com/bluesky-social/indigo/events"
+)
+
+type mockEventRepository struct {
+	mu      sync.Mutex
+	events  []*events.Event
+	lastSeq int64
+}
+
+func (m *mockEventRepository) Append(event *events.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastSeq++
+	event.Seq = m.lastSeq
+	event.CreatedAt = time.Now()
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockEventRepository) ListAfter(after int64, limit int) ([]*events.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*events.Event
+	for _, e := range m.events {
+		if e.Seq > after && len(result) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockEventRepository) SeqRange() (int64, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.events) == 0 {
+		return 0, m.lastSeq, nil
+	}
+	return m.events[0].Seq, m.lastSeq, nil
+}
+
+func (m *mockEventRepository) DeleteBefore(t time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var kept []*events.Event
+	for _, e := range m.events {
+		if !e.CreatedAt.Before(t) {
+			kept = append(kept, e)
+		}
+	}
+	n := int64(len(m.events) - len(kept))
+	m.events = kept
+	return n, nil
+}
+
+func identityEvent(did string) *indigoevents.XRPCStreamEvent {
+	return &indigoevents.XRPCStreamEvent{RepoIdentity: &comatproto.SyncSubscribeRepos_Identity{Did: did, Time: "2024-01-01T00:00:00.000Z"}}
+}
+
+// collect subscribes from cursor and returns the first n events received
+func collect(t *testing.T, s *events.Service, cursor *int64, n int) []*indigoevents.XRPCStreamEvent {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []*indigoevents.XRPCStreamEvent
+	done := errors.New("done")
+	err := s.Subscribe(ctx, cursor, func(evt *indigoevents.XRPCStreamEvent) error {
+		got = append(got, evt)
+		if len(got) == n {
+			return done
+		}
+		return nil
+	})
+	if !errors.Is(err, done) {
+		t.Fatalf("Expected %d events, got %d: %v", n, len(got), err)
+	}
+	return got
+}
+
+func TestEventService_PublishAndReplay(t *testing.T) {
+	ctx := context.Background()
+	s := events.NewService(&mockEventRepository{}, 0)
+
+	for _, did := range []string{"did:plc:a", "did:plc:b", "did:plc:c"} {
+		evt := identityEvent(did)
+		if err := s.Publish(ctx, evt); err != nil {
+			t.Fatalf("Failed to publish: %v", err)
+		}
+		if evt.RepoIdentity.Seq == 0 {
+			t.Error("Expected publish to set the sequence number")
+		}
+	}
+
+	cursor := int64(1)
+	got := collect(t, s, &cursor, 2)
+	if got[0].RepoIdentity.Did != "did:plc:b" || got[0].RepoIdentity.Seq != 2 {
+		t.Errorf("Unexpected first event %+v", got[0].RepoIdentity)
+	}
+	if got[1].RepoIdentity.Did != "did:plc:c" || got[1].RepoIdentity.Seq != 3 {
+		t.Errorf("Unexpected second event %+v", got[1].RepoIdentity)
+	}
+}
+
+func TestEventService_Live(t *testing.T) {
+	ctx := context.Background()
+	s := events.NewService(&mockEventRepository{}, 0)
+
+	if err := s.Publish(ctx, identityEvent("did:plc:old")); err != nil {
+		t.Fatalf("Failed to publish: %v", err)
+	}
+
+	// Without a cursor only events published after subscribing are sent
+	subCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	result := make(chan *indigoevents.XRPCStreamEvent, 1)
+	go s.Subscribe(subCtx, nil, func(evt *indigoevents.XRPCStreamEvent) error {
+		result <- evt
+		return errors.New("done")
+	})
+
+	// Keep publishing until the subscriber has registered and seen one
+	var got *indigoevents.XRPCStreamEvent
+	for got == nil {
+		if err := s.Publish(ctx, identityEvent("did:plc:new")); err != nil {
+			t.Fatalf("Failed to publish: %v", err)
+		}
+		select {
+		case got = <-result:
+		case <-time.After(10 * time.Millisecond):
+		case <-subCtx.Done():
+			t.Fatal("Timed out waiting for a live event")
+		}
+	}
+	if got.RepoIdentity.Did != "did:plc:new" {
+		t.Errorf("Expected live event, got %+v", got.RepoIdentity)
+	}
+}
+
+// Events written to the log alongside a repository change reach live
+// subscribers once the service is told about them
+func TestEventService_Appended(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockEventRepository{}
+	s := events.NewService(repo, 0)
+
+	subCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	result := make(chan *indigoevents.XRPCStreamEvent, 1)
+	go s.Subscribe(subCtx, nil, func(evt *indigoevents.XRPCStreamEvent) error {
+		result <- evt
+		return errors.New("done")
+	})
+
+	var got *indigoevents.XRPCStreamEvent
+	for got == nil {
+		event, err := events.Encode(identityEvent("did:plc:appended"))
+		if err != nil {
+			t.Fatalf("Failed to encode event: %v", err)
+		}
+		if err := repo.Append(event); err != nil {
+			t.Fatalf("Failed to append event: %v", err)
+		}
+		s.Appended([]*events.Event{event})
+		select {
+		case got = <-result:
+		case <-time.After(10 * time.Millisecond):
+		case <-subCtx.Done():
+			t.Fatal("Timed out waiting for an appended event")
+		}
+	}
+	if got.RepoIdentity.Did != "did:plc:appended" || got.RepoIdentity.Seq == 0 {
+		t.Errorf("Expected the appended event with its sequence number, got %+v", got.RepoIdentity)
+	}
+}
+
+func TestEventService_Cursors(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockEventRepository{}
+	s := events.NewService(repo, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if err := s.Publish(ctx, identityEvent("did:plc:a")); err != nil {
+			t.Fatalf("Failed to publish: %v", err)
+		}
+	}
+
+	future := int64(10)
+	err := s.Subscribe(ctx, &future, func(*indigoevents.XRPCStreamEvent) error { return nil })
+	if !errors.Is(err, events.ErrFutureCursor) {
+		t.Errorf("Expected ErrFutureCursor, got %v", err)
+	}
+
+	// Age the first two events past the retention period
+	repo.events[0].CreatedAt = time.Now().Add(-time.Hour)
+	repo.events[1].CreatedAt = time.Now().Add(-time.Hour)
+	n, err := s.Prune()
+	if err != nil {
+		t.Fatalf("Failed to prune: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 events pruned, got %d", n)
+	}
+
+	stale := int64(0)
+	got := collect(t, s, &stale, 2)
+	if got[0].RepoInfo == nil || got[0].RepoInfo.Name != "OutdatedCursor" {
+		t.Errorf("Expected OutdatedCursor info, got %+v", got[0])
+	}
+	if got[1].RepoIdentity == nil || got[1].RepoIdentity.Seq != 3 {
+		t.Errorf("Expected the remaining event, got %+v", got[1])
+	}
+}
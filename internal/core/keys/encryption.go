@@ -0,0 +1,59 @@
+package keys
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+// Encryptor encrypts private key material at rest using AES-256-GCM
+type Encryptor struct {
+	aead cipher.AEAD
+}
+
+// NewEncryptor creates an Encryptor from a 32-byte secret
+func NewEncryptor(secret []byte) (*Encryptor, error) {
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("encryption secret must be 32 bytes, got %d", len(secret))
+	}
+
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCM: %w", err)
+	}
+
+	return &Encryptor{aead: aead}, nil
+}
+
+// Encrypt seals plaintext, binding it to the given associated data.
+// The nonce is prepended to the returned ciphertext.
+func (e *Encryptor) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+
+	return e.aead.Seal(nonce, nonce, plaintext, associatedData), nil
+}
+
+// Decrypt opens ciphertext produced by Encrypt with the same associated data
+func (e *Encryptor) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	nonceSize := e.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	nonce, sealed := ciphertext[:nonceSize], ciphertext[nonceSize:]
+	plaintext, err := e.aead.Open(nil, nonce, sealed, associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting: %w", err)
+	}
+	return plaintext, nil
+}
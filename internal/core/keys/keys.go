@@ -0,0 +1,58 @@
+package keys
+
+import (
+	"context"
+	"time"
+)
+
+// Supported signing key algorithms
+const (
+	AlgorithmK256 = "ES256K" // secp256k1
+	AlgorithmP256 = "ES256"  // NIST P-256
+)
+
+// SigningKey represents a repository signing key stored encrypted at rest
+type SigningKey struct {
+	KeyID               string // did:key form of the public key
+	DID                 string // DID of the repository the key signs for
+	Algorithm           string // AlgorithmK256 or AlgorithmP256
+	EncryptedPrivateKey []byte // AES-GCM encrypted multibase private key
+	Active              bool   // Whether this is the current signing key for the DID
+	CreatedAt           time.Time
+	RotatedAt           *time.Time // When the key was replaced (nil while active)
+}
+
+// KeyService defines the business logic for repository signing keys
+type KeyService interface {
+	// CreateKey generates and stores a new active signing key for a DID
+	CreateKey(did string) (*SigningKey, error)
+
+	// RotateKey replaces the active signing key for a DID with a newly generated one
+	RotateKey(did string) (*SigningKey, error)
+
+	// GetActiveKey returns the current signing key for a DID
+	GetActiveKey(did string) (*SigningKey, error)
+
+	// PublicKeyDIDKey returns the active public key for a DID as a did:key
+	PublicKeyDIDKey(did string) (string, error)
+
+	// Sign signs data with the active key for a DID, returning the signature and key ID
+	Sign(ctx context.Context, did string, data []byte) ([]byte, string, error)
+
+	// DeleteKeys removes every signing key for a DID
+	DeleteKeys(did string) error
+}
+
+// KeyRepository defines the data access interface for signing keys
+type KeyRepository interface {
+	Create(key *SigningKey) error
+	GetActive(did string) (*SigningKey, error)
+	GetByID(keyID string) (*SigningKey, error)
+	ListByDID(did string) ([]*SigningKey, error)
+
+	// Rotate atomically deactivates the current active key for newKey.DID and stores newKey
+	Rotate(newKey *SigningKey) error
+
+	// DeleteByDID removes every key for did
+	DeleteByDID(did string) error
+}
@@ -0,0 +1,226 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	coreerrors "Coves/internal/core/errors"
+
+	atcrypto "github.com/bluesky-social/indigo/atproto/crypto"
+)
+
+// Service implements KeyService, keeping decrypted keys in memory once loaded
+type Service struct {
+	repo      KeyRepository
+	encryptor *Encryptor
+	algorithm string
+
+	mu    sync.RWMutex
+	cache map[string]atcrypto.PrivateKey // key ID -> decrypted private key
+}
+
+// NewService creates a new key service that generates keys with the given algorithm
+func NewService(repo KeyRepository, encryptor *Encryptor, algorithm string) (*Service, error) {
+	if algorithm != AlgorithmK256 && algorithm != AlgorithmP256 {
+		return nil, fmt.Errorf("unsupported signing key algorithm: %s", algorithm)
+	}
+
+	return &Service{
+		repo:      repo,
+		encryptor: encryptor,
+		algorithm: algorithm,
+		cache:     make(map[string]atcrypto.PrivateKey),
+	}, nil
+}
+
+// CreateKey generates and stores a new active signing key for a DID
+func (s *Service) CreateKey(did string) (*SigningKey, error) {
+	existing, err := s.repo.GetActive(did)
+	if err != nil {
+		return nil, fmt.Errorf("checking existing key: %w", err)
+	}
+	if existing != nil {
+		return nil, coreerrors.NewConflictError(coreerrors.ResourceSigningKey, "DID", did)
+	}
+
+	key, priv, err := s.generateKey(did)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(key); err != nil {
+		return nil, fmt.Errorf("saving signing key: %w", err)
+	}
+
+	s.cacheKey(key.KeyID, priv)
+	return key, nil
+}
+
+// RotateKey replaces the active signing key for a DID with a newly generated one
+func (s *Service) RotateKey(did string) (*SigningKey, error) {
+	old, err := s.repo.GetActive(did)
+	if err != nil {
+		return nil, fmt.Errorf("getting active key: %w", err)
+	}
+	if old == nil {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceSigningKey, did)
+	}
+
+	key, priv, err := s.generateKey(did)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Rotate(key); err != nil {
+		return nil, fmt.Errorf("rotating signing key: %w", err)
+	}
+
+	s.mu.Lock()
+	delete(s.cache, old.KeyID)
+	s.cache[key.KeyID] = priv
+	s.mu.Unlock()
+
+	return key, nil
+}
+
+// GetActiveKey returns the current signing key for a DID
+func (s *Service) GetActiveKey(did string) (*SigningKey, error) {
+	key, err := s.repo.GetActive(did)
+	if err != nil {
+		return nil, fmt.Errorf("getting active key: %w", err)
+	}
+	if key == nil {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceSigningKey, did)
+	}
+	return key, nil
+}
+
+// PublicKeyDIDKey returns the active public key for a DID as a did:key,
+// suitable for the verificationMethod of a DID document
+func (s *Service) PublicKeyDIDKey(did string) (string, error) {
+	key, err := s.GetActiveKey(did)
+	if err != nil {
+		return "", err
+	}
+	return key.KeyID, nil
+}
+
+// ResolveSigningKey returns the active public key for a locally hosted DID.
+// It lets the key service verify commits this server signed itself.
+func (s *Service) ResolveSigningKey(ctx context.Context, did string) (atcrypto.PublicKey, error) {
+	didKey, err := s.PublicKeyDIDKey(did)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := atcrypto.ParsePublicDIDKey(didKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key %s: %w", didKey, err)
+	}
+	return pub, nil
+}
+
+// Sign signs data with the active key for a DID, returning the signature and key ID
+func (s *Service) Sign(ctx context.Context, did string, data []byte) ([]byte, string, error) {
+	key, err := s.GetActiveKey(did)
+	if err != nil {
+		return nil, "", err
+	}
+
+	priv, err := s.privateKey(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sig, err := priv.HashAndSign(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("signing with key %s: %w", key.KeyID, err)
+	}
+
+	return sig, key.KeyID, nil
+}
+
+// DeleteKeys removes every signing key for a DID, so a repository whose
+// creation failed can be created again
+func (s *Service) DeleteKeys(did string) error {
+	existing, err := s.repo.ListByDID(did)
+	if err != nil {
+		return fmt.Errorf("listing keys: %w", err)
+	}
+
+	if err := s.repo.DeleteByDID(did); err != nil {
+		return fmt.Errorf("deleting keys: %w", err)
+	}
+
+	s.mu.Lock()
+	for _, key := range existing {
+		delete(s.cache, key.KeyID)
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// generateKey creates a new key pair and its encrypted storage form
+func (s *Service) generateKey(did string) (*SigningKey, atcrypto.PrivateKeyExportable, error) {
+	var priv atcrypto.PrivateKeyExportable
+	var err error
+	switch s.algorithm {
+	case AlgorithmP256:
+		priv, err = atcrypto.GeneratePrivateKeyP256()
+	default:
+		priv, err = atcrypto.GeneratePrivateKeyK256()
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating key: %w", err)
+	}
+
+	pub, err := priv.PublicKey()
+	if err != nil {
+		return nil, nil, fmt.Errorf("deriving public key: %w", err)
+	}
+
+	encrypted, err := s.encryptor.Encrypt([]byte(priv.Multibase()), []byte(did))
+	if err != nil {
+		return nil, nil, fmt.Errorf("encrypting key: %w", err)
+	}
+
+	return &SigningKey{
+		KeyID:               pub.DIDKey(),
+		DID:                 did,
+		Algorithm:           s.algorithm,
+		EncryptedPrivateKey: encrypted,
+		Active:              true,
+		CreatedAt:           time.Now(),
+	}, priv, nil
+}
+
+// privateKey returns the decrypted private key, loading it into the cache if needed
+func (s *Service) privateKey(key *SigningKey) (atcrypto.PrivateKey, error) {
+	s.mu.RLock()
+	priv, ok := s.cache[key.KeyID]
+	s.mu.RUnlock()
+	if ok {
+		return priv, nil
+	}
+
+	plaintext, err := s.encryptor.Decrypt(key.EncryptedPrivateKey, []byte(key.DID))
+	if err != nil {
+		return nil, fmt.Errorf("decrypting key %s: %w", key.KeyID, err)
+	}
+
+	parsed, err := atcrypto.ParsePrivateMultibase(string(plaintext))
+	if err != nil {
+		return nil, fmt.Errorf("parsing key %s: %w", key.KeyID, err)
+	}
+
+	s.cacheKey(key.KeyID, parsed)
+	return parsed, nil
+}
+
+func (s *Service) cacheKey(keyID string, priv atcrypto.PrivateKey) {
+	s.mu.Lock()
+	s.cache[keyID] = priv
+	s.mu.Unlock()
+}
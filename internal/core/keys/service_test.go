@@ -0,0 +1,205 @@
+package keys_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Coves/internal/core/keys"
+
+	atcrypto "github.com/bluesky-social/indigo/atproto/crypto"
+)
+
+type mockKeyRepository struct {
+	keys map[string]*keys.SigningKey
+}
+
+func newMockKeyRepository() *mockKeyRepository {
+	return &mockKeyRepository{keys: make(map[string]*keys.SigningKey)}
+}
+
+func (m *mockKeyRepository) Create(key *keys.SigningKey) error {
+	m.keys[key.KeyID] = key
+	return nil
+}
+
+func (m *mockKeyRepository) GetActive(did string) (*keys.SigningKey, error) {
+	for _, k := range m.keys {
+		if k.DID == did && k.Active {
+			return k, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockKeyRepository) GetByID(keyID string) (*keys.SigningKey, error) {
+	return m.keys[keyID], nil
+}
+
+func (m *mockKeyRepository) ListByDID(did string) ([]*keys.SigningKey, error) {
+	var result []*keys.SigningKey
+	for _, k := range m.keys {
+		if k.DID == did {
+			result = append(result, k)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockKeyRepository) Rotate(newKey *keys.SigningKey) error {
+	now := time.Now()
+	for _, k := range m.keys {
+		if k.DID == newKey.DID && k.Active {
+			k.Active = false
+			k.RotatedAt = &now
+		}
+	}
+	m.keys[newKey.KeyID] = newKey
+	return nil
+}
+
+func (m *mockKeyRepository) DeleteByDID(did string) error {
+	for id, k := range m.keys {
+		if k.DID == did {
+			delete(m.keys, id)
+		}
+	}
+	return nil
+}
+
+func newTestService(t *testing.T, algorithm string) (*keys.Service, *mockKeyRepository) {
+	encryptor, err := keys.NewEncryptor(make([]byte, 32))
+	if err != nil {
+		t.Fatalf("Failed to create encryptor: %v", err)
+	}
+	repo := newMockKeyRepository()
+	service, err := keys.NewService(repo, encryptor, algorithm)
+	if err != nil {
+		t.Fatalf("Failed to create key service: %v", err)
+	}
+	return service, repo
+}
+
+func TestKeyService_SignAndVerify(t *testing.T) {
+	for _, algorithm := range []string{keys.AlgorithmK256, keys.AlgorithmP256} {
+		t.Run(algorithm, func(t *testing.T) {
+			service, _ := newTestService(t, algorithm)
+			did := "did:plc:signer"
+
+			key, err := service.CreateKey(did)
+			if err != nil {
+				t.Fatalf("Failed to create key: %v", err)
+			}
+
+			data := []byte("commit bytes")
+			sig, keyID, err := service.Sign(context.Background(), did, data)
+			if err != nil {
+				t.Fatalf("Failed to sign: %v", err)
+			}
+			if keyID != key.KeyID {
+				t.Errorf("Expected key ID %s, got %s", key.KeyID, keyID)
+			}
+
+			pub, err := atcrypto.ParsePublicDIDKey(keyID)
+			if err != nil {
+				t.Fatalf("Failed to parse did:key: %v", err)
+			}
+			if err := pub.HashAndVerify(data, sig); err != nil {
+				t.Errorf("Signature did not verify: %v", err)
+			}
+		})
+	}
+}
+
+func TestKeyService_CreateKeyTwiceFails(t *testing.T) {
+	service, _ := newTestService(t, keys.AlgorithmK256)
+	did := "did:plc:dupe"
+
+	if _, err := service.CreateKey(did); err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+	if _, err := service.CreateKey(did); err == nil {
+		t.Error("Expected error creating a second active key")
+	}
+}
+
+func TestKeyService_DeleteKeys(t *testing.T) {
+	service, _ := newTestService(t, keys.AlgorithmK256)
+	did := "did:plc:discarded"
+
+	if _, err := service.CreateKey(did); err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+	if err := service.DeleteKeys(did); err != nil {
+		t.Fatalf("Failed to delete keys: %v", err)
+	}
+	if _, _, err := service.Sign(context.Background(), did, []byte("data")); err == nil {
+		t.Error("Expected signing to fail without a key")
+	}
+
+	// The DID can be given a key again
+	if _, err := service.CreateKey(did); err != nil {
+		t.Errorf("Failed to create key after deleting: %v", err)
+	}
+}
+
+func TestKeyService_RotateKey(t *testing.T) {
+	service, repo := newTestService(t, keys.AlgorithmK256)
+	did := "did:plc:rotate"
+
+	oldKey, err := service.CreateKey(did)
+	if err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+
+	newKey, err := service.RotateKey(did)
+	if err != nil {
+		t.Fatalf("Failed to rotate key: %v", err)
+	}
+	if newKey.KeyID == oldKey.KeyID {
+		t.Error("Expected a new key after rotation")
+	}
+
+	stored, _ := repo.GetByID(oldKey.KeyID)
+	if stored.Active || stored.RotatedAt == nil {
+		t.Error("Expected old key to be deactivated with a rotation time")
+	}
+
+	publicKey, err := service.PublicKeyDIDKey(did)
+	if err != nil {
+		t.Fatalf("Failed to get public key: %v", err)
+	}
+	if publicKey != newKey.KeyID {
+		t.Errorf("Expected public key %s, got %s", newKey.KeyID, publicKey)
+	}
+
+	_, keyID, err := service.Sign(context.Background(), did, []byte("data"))
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	if keyID != newKey.KeyID {
+		t.Errorf("Expected signing with rotated key %s, got %s", newKey.KeyID, keyID)
+	}
+}
+
+func TestKeyService_KeysEncryptedAtRest(t *testing.T) {
+	service, repo := newTestService(t, keys.AlgorithmK256)
+	did := "did:plc:encrypted"
+
+	key, err := service.CreateKey(did)
+	if err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+
+	// A fresh service sharing the repository must decrypt the stored key
+	encryptor, _ := keys.NewEncryptor(make([]byte, 32))
+	other, _ := keys.NewService(repo, encryptor, keys.AlgorithmK256)
+	if _, _, err := other.Sign(context.Background(), did, []byte("data")); err != nil {
+		t.Fatalf("Failed to sign with reloaded key: %v", err)
+	}
+
+	// Ciphertext is bound to the DID it was created for
+	if _, err := encryptor.Decrypt(key.EncryptedPrivateKey, []byte("did:plc:other")); err == nil {
+		t.Error("Expected decryption with the wrong DID to fail")
+	}
+}
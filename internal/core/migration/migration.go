@@ -0,0 +1,40 @@
+// Package migration moves accounts between servers by DID. An account
+// arriving creates a deactivated repository, imports the repository CAR and
+// blobs its old server exports, points its DID document here with a PLC
+// operation the old server signs, and activates. An account leaving does
+// the reverse, with this server signing the operation that hands it over.
+package migration
+
+import (
+	"context"
+
+	"Coves/internal/atproto/plc"
+	"Coves/internal/core/repository"
+)
+
+// Credentials are the DID document entries an account needs to be served here
+type Credentials struct {
+	RotationKeys        []string
+	VerificationMethods map[string]string
+	Services            map[string]plc.Service
+}
+
+// Changes are the DID document entries a PLC operation replaces; nil fields
+// keep what the DID's latest operation has
+type Changes struct {
+	RotationKeys        []string
+	AlsoKnownAs         []string
+	VerificationMethods map[string]string
+	Services            map[string]plc.Service
+}
+
+// Directory reads and updates DIDs' PLC logs
+type Directory interface {
+	LastOperation(ctx context.Context, did string) (*plc.Operation, error)
+	Submit(ctx context.Context, did string, op *plc.Operation) error
+}
+
+// Repositories is what the migration service needs from the repository service
+type Repositories interface {
+	GetRepository(did string) (*repository.Repository, error)
+}
@@ -0,0 +1,183 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"Coves/internal/atproto/plc"
+	coreerrors "Coves/internal/core/errors"
+	"Coves/internal/core/keys"
+
+	"github.com/bluesky-social/indigo/atproto/crypto"
+)
+
+// Service prepares and signs the DID changes a migration needs
+type Service struct {
+	repos       Repositories
+	keys        keys.KeyService
+	directory   Directory
+	endpoint    string
+	rotationKey crypto.PrivateKey
+}
+
+// NewService creates a migration service for the server at endpoint, its
+// public URL. PLC operations are read from and submitted to directory.
+func NewService(repos Repositories, keyService keys.KeyService, directory Directory, endpoint string) *Service {
+	return &Service{
+		repos:     repos,
+		keys:      keyService,
+		directory: directory,
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+	}
+}
+
+// SetRotationKey sets the PLC rotation key this server holds for its
+// accounts. Without one it can't sign operations, so accounts can only
+// leave by signing with a rotation key of their own.
+func (s *Service) SetRotationKey(key crypto.PrivateKey) {
+	s.rotationKey = key
+}
+
+// RecommendedCredentials returns the DID document entries an account needs
+// for this server to serve it: its signing key here, this server as its
+// PDS and this server's rotation key. Handles are left as they are.
+func (s *Service) RecommendedCredentials(did string) (*Credentials, error) {
+	if err := s.checkRepository(did); err != nil {
+		return nil, err
+	}
+
+	signingKey, err := s.keys.PublicKeyDIDKey(did)
+	if err != nil {
+		return nil, fmt.Errorf("getting signing key: %w", err)
+	}
+
+	creds := &Credentials{
+		RotationKeys:        []string{},
+		VerificationMethods: map[string]string{plc.VerificationMethodAtproto: signingKey},
+		Services: map[string]plc.Service{
+			plc.ServiceAtprotoPDS: {Type: plc.ServiceTypeAtprotoPDS, Endpoint: s.endpoint},
+		},
+	}
+	if s.rotationKey != nil {
+		rotationKey, err := s.rotationDIDKey()
+		if err != nil {
+			return nil, err
+		}
+		creds.RotationKeys = append(creds.RotationKeys, rotationKey)
+	}
+	return creds, nil
+}
+
+// SignPLCOperation signs an operation applying changes to a DID's latest
+// operation, for an account leaving this server. The DID's rotation keys
+// must include this server's.
+func (s *Service) SignPLCOperation(ctx context.Context, did string, changes Changes) (*plc.Operation, error) {
+	if s.rotationKey == nil {
+		return nil, fmt.Errorf("%w: no PLC rotation key configured", coreerrors.ErrForbidden)
+	}
+	if err := s.checkRepository(did); err != nil {
+		return nil, err
+	}
+
+	last, err := s.directory.LastOperation(ctx, did)
+	if err != nil {
+		return nil, err
+	}
+	if last.Type != plc.TypeOperation {
+		return nil, coreerrors.NewValidationError("did", fmt.Sprintf("can't update a DID whose latest operation is a %s", last.Type))
+	}
+	rotationKey, err := s.rotationDIDKey()
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(last.RotationKeys, rotationKey) {
+		return nil, fmt.Errorf("%w: this server's rotation key can't update %s", coreerrors.ErrForbidden, did)
+	}
+	prev, err := last.CID()
+	if err != nil {
+		return nil, err
+	}
+	prevStr := prev.String()
+
+	op := &plc.Operation{
+		Type:                plc.TypeOperation,
+		RotationKeys:        last.RotationKeys,
+		VerificationMethods: last.VerificationMethods,
+		AlsoKnownAs:         last.AlsoKnownAs,
+		Services:            last.Services,
+		Prev:                &prevStr,
+	}
+	if changes.RotationKeys != nil {
+		op.RotationKeys = changes.RotationKeys
+	}
+	if changes.AlsoKnownAs != nil {
+		op.AlsoKnownAs = changes.AlsoKnownAs
+	}
+	if changes.VerificationMethods != nil {
+		op.VerificationMethods = changes.VerificationMethods
+	}
+	if changes.Services != nil {
+		op.Services = changes.Services
+	}
+
+	if err := op.Sign(s.rotationKey); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// SubmitPLCOperation sends an account's signed operation to the directory,
+// for an account arriving here. The operation must leave the DID served by
+// this server with the account's signing key here, so an account can't
+// lock itself out by mistake.
+func (s *Service) SubmitPLCOperation(ctx context.Context, did string, op *plc.Operation) error {
+	if err := s.checkRepository(did); err != nil {
+		return err
+	}
+
+	signingKey, err := s.keys.PublicKeyDIDKey(did)
+	if err != nil {
+		return fmt.Errorf("getting signing key: %w", err)
+	}
+	if op.VerificationMethods[plc.VerificationMethodAtproto] != signingKey {
+		return coreerrors.NewValidationError("operation", "atproto verification method isn't this server's signing key for the account")
+	}
+	pds, ok := op.Services[plc.ServiceAtprotoPDS]
+	if !ok || pds.Type != plc.ServiceTypeAtprotoPDS || strings.TrimSuffix(pds.Endpoint, "/") != s.endpoint {
+		return coreerrors.NewValidationError("operation", "atproto_pds service isn't this server")
+	}
+	if s.rotationKey != nil {
+		rotationKey, err := s.rotationDIDKey()
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(op.RotationKeys, rotationKey) {
+			return coreerrors.NewValidationError("operation", "rotation keys don't include this server's")
+		}
+	}
+
+	return s.directory.Submit(ctx, did, op)
+}
+
+// checkRepository checks this server has a repository for did
+func (s *Service) checkRepository(did string) error {
+	repo, err := s.repos.GetRepository(did)
+	if err != nil {
+		return err
+	}
+	if repo == nil {
+		return coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	return nil
+}
+
+// rotationDIDKey returns this server's rotation key as a did:key
+func (s *Service) rotationDIDKey() (string, error) {
+	pub, err := s.rotationKey.PublicKey()
+	if err != nil {
+		return "", fmt.Errorf("getting rotation key: %w", err)
+	}
+	return pub.DIDKey(), nil
+}
@@ -0,0 +1,211 @@
+package migration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"Coves/internal/atproto/plc"
+	coreerrors "Coves/internal/core/errors"
+	"Coves/internal/core/keys"
+	"Coves/internal/core/migration"
+	"Coves/internal/core/repository"
+
+	"github.com/bluesky-social/indigo/atproto/crypto"
+)
+
+// fakeDirectory holds one operation per DID and records submissions
+type fakeDirectory struct {
+	last      map[string]*plc.Operation
+	submitted []*plc.Operation
+}
+
+func (f *fakeDirectory) LastOperation(ctx context.Context, did string) (*plc.Operation, error) {
+	op, ok := f.last[did]
+	if !ok {
+		return nil, errors.New("DID not registered")
+	}
+	return op, nil
+}
+
+func (f *fakeDirectory) Submit(ctx context.Context, did string, op *plc.Operation) error {
+	f.submitted = append(f.submitted, op)
+	return nil
+}
+
+// fakeRepos knows a fixed set of repositories
+type fakeRepos map[string]*repository.Repository
+
+func (f fakeRepos) GetRepository(did string) (*repository.Repository, error) {
+	repo, ok := f[did]
+	if !ok {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	return repo, nil
+}
+
+// fakeKeys gives every DID the same signing key
+type fakeKeys struct {
+	keys.KeyService
+	didKey string
+}
+
+func (f *fakeKeys) PublicKeyDIDKey(did string) (string, error) {
+	return f.didKey, nil
+}
+
+const testDID = "did:plc:migrationtest"
+
+func newKey(t *testing.T) crypto.PrivateKeyExportable {
+	t.Helper()
+	key, err := crypto.GeneratePrivateKeyK256()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	return key
+}
+
+func didKey(t *testing.T, key crypto.PrivateKey) string {
+	t.Helper()
+	pub, err := key.PublicKey()
+	if err != nil {
+		t.Fatalf("Failed to get public key: %v", err)
+	}
+	return pub.DIDKey()
+}
+
+func setupService(t *testing.T) (*migration.Service, *fakeDirectory, crypto.PrivateKey, string) {
+	t.Helper()
+	rotation := newKey(t)
+	signing := didKey(t, newKey(t))
+	directory := &fakeDirectory{last: map[string]*plc.Operation{}}
+	repos := fakeRepos{testDID: {DID: testDID, Status: repository.StatusActive}}
+	service := migration.NewService(repos, &fakeKeys{didKey: signing}, directory, "https://coves.example.com/")
+	service.SetRotationKey(rotation)
+	return service, directory, rotation, signing
+}
+
+func TestService_RecommendedCredentials(t *testing.T) {
+	service, _, rotation, signing := setupService(t)
+
+	creds, err := service.RecommendedCredentials(testDID)
+	if err != nil {
+		t.Fatalf("Failed to get credentials: %v", err)
+	}
+	if len(creds.RotationKeys) != 1 || creds.RotationKeys[0] != didKey(t, rotation) {
+		t.Errorf("Expected this server's rotation key, got %v", creds.RotationKeys)
+	}
+	if creds.VerificationMethods[plc.VerificationMethodAtproto] != signing {
+		t.Errorf("Expected the account's signing key, got %v", creds.VerificationMethods)
+	}
+	if pds := creds.Services[plc.ServiceAtprotoPDS]; pds.Endpoint != "https://coves.example.com" || pds.Type != plc.ServiceTypeAtprotoPDS {
+		t.Errorf("Expected this server as the PDS, got %+v", pds)
+	}
+
+	if _, err := service.RecommendedCredentials("did:plc:unknown"); !errors.Is(err, coreerrors.ErrNotFound) {
+		t.Errorf("Expected an unknown account to be refused, got %v", err)
+	}
+}
+
+func TestService_SignPLCOperation(t *testing.T) {
+	service, directory, rotation, signing := setupService(t)
+	ctx := context.Background()
+
+	// The DID is currently served here
+	last := &plc.Operation{
+		Type:                plc.TypeOperation,
+		RotationKeys:        []string{didKey(t, rotation)},
+		VerificationMethods: map[string]string{plc.VerificationMethodAtproto: signing},
+		AlsoKnownAs:         []string{"at://alice.coves.example.com"},
+		Services:            map[string]plc.Service{plc.ServiceAtprotoPDS: {Type: plc.ServiceTypeAtprotoPDS, Endpoint: "https://coves.example.com"}},
+	}
+	if err := last.Sign(rotation); err != nil {
+		t.Fatalf("Failed to sign operation: %v", err)
+	}
+	directory.last[testDID] = last
+
+	// Leaving for another server changes the key and endpoint, keeping the rest
+	newRotation := didKey(t, newKey(t))
+	newSigning := didKey(t, newKey(t))
+	op, err := service.SignPLCOperation(ctx, testDID, migration.Changes{
+		RotationKeys:        []string{newRotation},
+		VerificationMethods: map[string]string{plc.VerificationMethodAtproto: newSigning},
+		Services:            map[string]plc.Service{plc.ServiceAtprotoPDS: {Type: plc.ServiceTypeAtprotoPDS, Endpoint: "https://other.example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to sign operation: %v", err)
+	}
+	if err := op.Verify(last.RotationKeys); err != nil {
+		t.Errorf("Expected the operation to be signed by the current rotation key: %v", err)
+	}
+	prev, _ := last.CID()
+	if op.Prev == nil || *op.Prev != prev.String() {
+		t.Errorf("Expected prev %s, got %v", prev, op.Prev)
+	}
+	if op.RotationKeys[0] != newRotation || op.VerificationMethods[plc.VerificationMethodAtproto] != newSigning {
+		t.Errorf("Expected the changes to be applied, got %+v", op)
+	}
+	if len(op.AlsoKnownAs) != 1 || op.AlsoKnownAs[0] != "at://alice.coves.example.com" {
+		t.Errorf("Expected the handle to be kept, got %v", op.AlsoKnownAs)
+	}
+
+	// Once the DID has moved, this server can't sign for it
+	directory.last[testDID] = op
+	if _, err := service.SignPLCOperation(ctx, testDID, migration.Changes{}); !errors.Is(err, coreerrors.ErrForbidden) {
+		t.Errorf("Expected signing without the rotation key to be forbidden, got %v", err)
+	}
+
+	directory.last[testDID] = &plc.Operation{Type: plc.TypeTombstone}
+	if _, err := service.SignPLCOperation(ctx, testDID, migration.Changes{}); !errors.Is(err, coreerrors.ErrInvalidInput) {
+		t.Errorf("Expected a tombstoned DID to be refused, got %v", err)
+	}
+}
+
+func TestService_SubmitPLCOperation(t *testing.T) {
+	service, directory, _, _ := setupService(t)
+	ctx := context.Background()
+
+	// The old server signed an operation moving the account here
+	creds, err := service.RecommendedCredentials(testDID)
+	if err != nil {
+		t.Fatalf("Failed to get credentials: %v", err)
+	}
+	op := &plc.Operation{
+		Type:                plc.TypeOperation,
+		RotationKeys:        creds.RotationKeys,
+		VerificationMethods: creds.VerificationMethods,
+		AlsoKnownAs:         []string{"at://alice.example.com"},
+		Services:            creds.Services,
+	}
+	if err := op.Sign(newKey(t)); err != nil {
+		t.Fatalf("Failed to sign operation: %v", err)
+	}
+	if err := service.SubmitPLCOperation(ctx, testDID, op); err != nil {
+		t.Fatalf("Failed to submit operation: %v", err)
+	}
+	if len(directory.submitted) != 1 || directory.submitted[0] != op {
+		t.Errorf("Expected the operation to be submitted, got %v", directory.submitted)
+	}
+
+	// Operations that wouldn't leave the account served here are refused
+	for name, change := range map[string]func(op *plc.Operation){
+		"other signing key": func(op *plc.Operation) {
+			op.VerificationMethods = map[string]string{plc.VerificationMethodAtproto: didKey(t, newKey(t))}
+		},
+		"other server": func(op *plc.Operation) {
+			op.Services = map[string]plc.Service{plc.ServiceAtprotoPDS: {Type: plc.ServiceTypeAtprotoPDS, Endpoint: "https://other.example.com"}}
+		},
+		"no rotation key": func(op *plc.Operation) {
+			op.RotationKeys = []string{didKey(t, newKey(t))}
+		},
+	} {
+		bad := *op
+		change(&bad)
+		if err := service.SubmitPLCOperation(ctx, testDID, &bad); !errors.Is(err, coreerrors.ErrInvalidInput) {
+			t.Errorf("%s: expected the operation to be refused, got %v", name, err)
+		}
+	}
+	if len(directory.submitted) != 1 {
+		t.Errorf("Expected refused operations not to be submitted, got %d", len(directory.submitted))
+	}
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// writeLockTimeout bounds how long a write waits for another write to the
+// same repository to finish
+const writeLockTimeout = 30 * time.Second
+
+// RepoLocker serialises writes to a repository. Every change to a repository
+// head (commits, imports, deletes and compaction) runs while holding its
+// lock, so two writers never build on the same head and orphan one another's
+// commit. Writes to different repositories don't contend.
+type RepoLocker interface {
+	// Lock blocks until the caller holds the write lock for did or ctx is
+	// done. It reports whether another writer held the lock when it was
+	// called. The returned function releases the lock.
+	Lock(ctx context.Context, did string) (unlock func(), contended bool, err error)
+}
+
+var (
+	writeLockAcquired = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "coves_repo_write_lock_acquired_total",
+		Help: "Repository write locks acquired, by operation and whether another writer held the lock",
+	}, []string{"op", "contended"})
+	writeLockFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "coves_repo_write_lock_failed_total",
+		Help: "Repository write locks that could not be acquired, by operation",
+	}, []string{"op"})
+	writeLockWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "coves_repo_write_lock_wait_seconds",
+		Help:    "Time spent waiting for a repository write lock",
+		Buckets: prometheus.ExponentialBuckets(0.0005, 4, 10),
+	}, []string{"op"})
+	writeLockHeld = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "coves_repo_write_lock_held_seconds",
+		Help:    "Time a repository write lock was held",
+		Buckets: prometheus.ExponentialBuckets(0.0005, 4, 10),
+	}, []string{"op"})
+)
+
+// lockRepo takes the write lock for did on behalf of op, recording how long
+// it waited and, once released, how long it was held
+func (s *Service) lockRepo(did string, op string) (unlock func(), err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), writeLockTimeout)
+	defer cancel()
+
+	start := time.Now()
+	release, contended, err := s.locker.Lock(ctx, did)
+	if err != nil {
+		writeLockFailed.WithLabelValues(op).Inc()
+		return nil, fmt.Errorf("locking repository %s: %w", did, err)
+	}
+	acquired := time.Now()
+	writeLockWait.WithLabelValues(op).Observe(acquired.Sub(start).Seconds())
+	if contended {
+		writeLockAcquired.WithLabelValues(op, "true").Inc()
+	} else {
+		writeLockAcquired.WithLabelValues(op, "false").Inc()
+	}
+
+	return func() {
+		release()
+		writeLockHeld.WithLabelValues(op).Observe(time.Since(acquired).Seconds())
+	}, nil
+}
+
+// LocalLocker is a RepoLocker for a single process. It keeps a lock per DID
+// only while someone holds or waits for it.
+type LocalLocker struct {
+	mu    sync.Mutex
+	locks map[string]*localLock
+}
+
+type localLock struct {
+	sem  chan struct{} // holds a token while the lock is held
+	refs int           // holders and waiters
+}
+
+// NewLocalLocker creates an in-process repository locker
+func NewLocalLocker() *LocalLocker {
+	return &LocalLocker{locks: make(map[string]*localLock)}
+}
+
+// Lock takes the lock for did
+func (l *LocalLocker) Lock(ctx context.Context, did string) (func(), bool, error) {
+	l.mu.Lock()
+	lock, ok := l.locks[did]
+	if !ok {
+		lock = &localLock{sem: make(chan struct{}, 1)}
+		l.locks[did] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	contended := false
+	select {
+	case lock.sem <- struct{}{}:
+	default:
+		contended = true
+		select {
+		case lock.sem <- struct{}{}:
+		case <-ctx.Done():
+			l.release(did, lock)
+			return nil, true, ctx.Err()
+		}
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-lock.sem
+			l.release(did, lock)
+		})
+	}, contended, nil
+}
+
+// release drops a reference to did's lock, forgetting it once unused
+func (l *LocalLocker) release(did string, lock *localLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, did)
+	}
+}
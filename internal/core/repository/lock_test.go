@@ -0,0 +1,69 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Coves/internal/core/repository"
+)
+
+func TestLocalLocker(t *testing.T) {
+	locker := repository.NewLocalLocker()
+	ctx := context.Background()
+
+	unlock, contended, err := locker.Lock(ctx, "did:plc:alice")
+	if err != nil || contended {
+		t.Fatalf("Expected an uncontended lock, got contended=%v err=%v", contended, err)
+	}
+
+	// Other repositories aren't blocked
+	unlockBob, contended, err := locker.Lock(ctx, "did:plc:bob")
+	if err != nil || contended {
+		t.Fatalf("Expected bob's lock to be uncontended, got contended=%v err=%v", contended, err)
+	}
+	unlockBob()
+
+	// A second writer to the same repository waits for the first
+	acquired := make(chan bool)
+	go func() {
+		unlock, contended, err := locker.Lock(ctx, "did:plc:alice")
+		if err != nil {
+			t.Errorf("Failed to take released lock: %v", err)
+			close(acquired)
+			return
+		}
+		unlock()
+		acquired <- contended
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("Second writer took a held lock")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	if contended := <-acquired; !contended {
+		t.Error("Expected the second writer to report contention")
+	}
+
+	// Waiting gives up with the context
+	unlock, _, err = locker.Lock(ctx, "did:plc:alice")
+	if err != nil {
+		t.Fatalf("Failed to take lock: %v", err)
+	}
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := locker.Lock(timeout, "did:plc:alice"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+	unlock()
+
+	// Releasing twice is harmless and the lock is free again
+	unlock()
+	unlock, contended, err = locker.Lock(ctx, "did:plc:alice")
+	if err != nil || contended {
+		t.Fatalf("Expected the lock to be free, got contended=%v err=%v", contended, err)
+	}
+	unlock()
+}
@@ -1,22 +1,93 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"time"
 
+	coreerrors "Coves/internal/core/errors"
+	"Coves/internal/core/events"
+
+	"github.com/bluesky-social/indigo/atproto/crypto"
 	"github.com/ipfs/go-cid"
 )
 
+// ErrRepoNotFound matches the error returned for a DID with no repository
+var ErrRepoNotFound error = coreerrors.NotFoundError{Resource: coreerrors.ResourceRepo}
+
+// ErrBlockNotFound is returned when a requested block is not in the repository
+var ErrBlockNotFound error = coreerrors.NotFoundError{Resource: coreerrors.ResourceBlock}
+
+// ErrInvalidSwap is returned when a swapRecord or swapCommit precondition
+// does not match the current state of the repository
+var ErrInvalidSwap = errors.New("invalid swap")
+
+// ErrInvalidRecord is returned when a record fails validation against the
+// lexicon for its collection
+var ErrInvalidRecord = errors.New("invalid record")
+
+// Account statuses of a repository. Only active repositories are served
+// and written to; the others are kept intact so the status can be reversed.
+const (
+	StatusActive      = "active"
+	StatusDeactivated = "deactivated" // by the owner
+	StatusTakendown   = "takendown"   // by an admin, for moderation
+	StatusSuspended   = "suspended"   // by an admin, usually temporarily
+)
+
+// ValidStatus reports whether status is a known account status
+func ValidStatus(status string) bool {
+	switch status {
+	case StatusActive, StatusDeactivated, StatusTakendown, StatusSuspended:
+		return true
+	}
+	return false
+}
+
+// InactiveError is returned when a repository can't be read or written
+// because its account isn't active
+type InactiveError struct {
+	DID    string
+	Status string
+}
+
+func (e InactiveError) Error() string {
+	return fmt.Sprintf("repository %s is %s", e.DID, e.Status)
+}
+
 // Repository represents an AT Protocol data repository
 type Repository struct {
 	DID            string    // Decentralized identifier of the repository owner
 	HeadCID        cid.Cid   // CID of the latest commit
 	Revision       string    // Current revision identifier
 	RecordCount    int       // Number of records in the repository
-	StorageSize    int64     // Total storage size in bytes
+	StorageSize    int64     // Size of its carstore shards on disk, in bytes
+	Status         string    // Account status, one of the Status constants
+	StatusReason   string    // Why an admin changed the status; not shown publicly
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
 
+// RepoStats describes a repository's records and storage, counted from its
+// MST and carstore shards
+type RepoStats struct {
+	DID            string
+	Status         string
+	Revision       string
+	Shards         int            // Carstore shards holding the repository
+	DiskBytes      int64          // Total size of those shards on disk
+	RecordCount    int
+	Collections    map[string]int // Record count per collection
+	UpdatedAt      time.Time      // When the repository was last written to
+}
+
+// Active reports whether the repository's account is active
+func (r *Repository) Active() bool {
+	return r.Status == StatusActive
+}
+
 // Commit represents a signed repository commit
 type Commit struct {
 	CID            cid.Cid   // Content identifier of this commit
@@ -48,7 +119,8 @@ type CreateRecordInput struct {
 	Collection     string
 	RecordKey      string    // Optional - will be generated if not provided
 	Record         interface{}
-	Validate       bool      // Whether to validate against lexicon
+	Validate       bool      // Validate against the lexicon even outside social.coves.*
+	SwapCommit     *cid.Cid  // Optional - repository head must match this commit
 }
 
 // UpdateRecordInput represents input for updating a record
@@ -58,6 +130,8 @@ type UpdateRecordInput struct {
 	RecordKey      string
 	Record         interface{}
 	Validate       bool
+	SwapRecord     *cid.Cid  // Optional - current record must have this CID
+	SwapCommit     *cid.Cid  // Optional - repository head must match this commit
 }
 
 // GetRecordInput represents input for retrieving a record
@@ -72,6 +146,69 @@ type DeleteRecordInput struct {
 	DID            string
 	Collection     string
 	RecordKey      string
+	SwapRecord     *cid.Cid  // Optional - current record must have this CID
+	SwapCommit     *cid.Cid  // Optional - repository head must match this commit
+}
+
+// Write actions for ApplyWrites
+const (
+	WriteActionCreate = "create"
+	WriteActionUpdate = "update"
+	WriteActionDelete = "delete"
+)
+
+// WriteOp represents a single record operation in a batch write
+type WriteOp struct {
+	Action         string      // WriteActionCreate, WriteActionUpdate or WriteActionDelete
+	Collection     string
+	RecordKey      string      // Optional for creates - will be generated if not provided
+	Record         interface{} // Unused for deletes
+	SwapRecord     *cid.Cid    // Optional for updates and deletes - current record must have this CID
+}
+
+// ApplyWritesInput represents input for applying a batch of writes in one commit
+type ApplyWritesInput struct {
+	DID            string
+	Writes         []WriteOp
+	Validate       bool
+	SwapCommit     *cid.Cid  // Optional - repository head must match this commit
+}
+
+// WriteResult represents the outcome of a single operation in a batch write
+type WriteResult struct {
+	Action         string
+	URI            string
+	RecordKey      string
+	CID            cid.Cid   // Undefined for deletes
+}
+
+// ApplyWritesResult represents the commit produced by a batch write
+type ApplyWritesResult struct {
+	CommitCID      cid.Cid
+	Revision       string
+	Results        []WriteResult
+}
+
+// RecordChange is a record created, updated or deleted between two revisions
+type RecordChange struct {
+	Action         string    // WriteActionCreate, WriteActionUpdate or WriteActionDelete
+	URI            string
+	Collection     string
+	RecordKey      string
+	PrevCID        cid.Cid   // Undefined for creates
+	CID            cid.Cid   // Undefined for deletes
+	PrevValue      []byte    // CBOR of the record before the change, if values were requested
+	Value          []byte    // CBOR of the record after the change, if values were requested
+}
+
+// RepoDiff describes how a repository changed between two revisions
+type RepoDiff struct {
+	DID            string
+	FromRevision   string    // Empty when diffing from an empty repository
+	FromCommit     cid.Cid   // Undefined when diffing from an empty repository
+	ToRevision     string
+	ToCommit       cid.Cid
+	Changes        []RecordChange // In record path order
 }
 
 // RepositoryService defines the business logic for repository operations
@@ -79,24 +216,75 @@ type RepositoryService interface {
 	// Repository operations
 	CreateRepository(did string) (*Repository, error)
 	GetRepository(did string) (*Repository, error)
+	ListRepositories(limit int, cursor string) ([]*Repository, string, error)
 	DeleteRepository(did string) error
+	CreateInactiveRepository(did string) (*Repository, error) // Deactivated and empty, for an account migrating in
+	GetRepositoryStats(did string) (*RepoStats, error) // Also corrects the stored record count and storage size
+	
+	// Account status
+	DeactivateRepository(did string) (*Repository, error)                                // By the owner
+	ActivateRepository(did string) (*Repository, error)                                  // By the owner, undoing DeactivateRepository
+	UpdateRepositoryStatus(did string, status string, reason string) (*Repository, error) // By an admin
 	
 	// Record operations
 	CreateRecord(input CreateRecordInput) (*Record, error)
 	GetRecord(input GetRecordInput) (*Record, error)
 	UpdateRecord(input UpdateRecordInput) (*Record, error)
 	DeleteRecord(input DeleteRecordInput) error
+	ApplyWrites(input ApplyWritesInput) (*ApplyWritesResult, error)
 	
 	// Collection operations
 	ListRecords(did string, collection string, limit int, cursor string) ([]*Record, string, error)
 	
 	// Commit operations
 	GetCommit(did string, cid cid.Cid) (*Commit, error)
+	GetLatestCommit(did string) (*Commit, error)
 	ListCommits(did string, limit int, cursor string) ([]*Commit, string, error)
+	DiffRepository(ctx context.Context, did string, fromRev string, toRev string, values bool) (*RepoDiff, error) // Records changed between two revisions
+	
+	// Sync operations
+	GetBlocks(ctx context.Context, did string, cids []cid.Cid, w io.Writer) error                  // Writes the blocks as a CAR rooted at the head
+	GetRecordProof(ctx context.Context, did string, collection string, rkey string, w io.Writer) error // Writes a CAR with the record and its MST inclusion proof
 	
 	// Export operations
-	ExportRepository(did string) ([]byte, error) // Returns CAR file
-	ImportRepository(did string, carData []byte) error
+	ExportRepository(ctx context.Context, did string, since string, w io.Writer) error // Streams CAR file, optionally only blocks after since
+	ImportRepository(ctx context.Context, did string, r io.Reader) (*ImportResult, error) // Verifies and stores a CAR stream
+
+	// Blob references
+	ListBlobRefs(did string) ([]BlobRef, error) // Blobs the records link to, including for deactivated repositories
+}
+
+// BlobRef is a blob a repository's record links to
+type BlobRef struct {
+	CID       cid.Cid
+	RecordURI string
+}
+
+// ImportResult describes a repository stored by ImportRepository
+type ImportResult struct {
+	HeadCID        cid.Cid
+	Revision       string
+	RecordCount    int
+	Collections    map[string]int // Record count per collection
+	StorageSize    int64          // Total size of imported blocks in bytes
+}
+
+// KeyResolver resolves the public key that signs a DID's repository commits
+type KeyResolver interface {
+	ResolveSigningKey(ctx context.Context, did string) (crypto.PublicKey, error)
+}
+
+// RecordValidator checks records against the lexicon schema for their type.
+// Records are passed in the atproto data model.
+type RecordValidator interface {
+	ValidateRecord(recordData interface{}, recordType string) error
+}
+
+// EventPublisher serves repository events over subscribeRepos. Events are
+// written to the log with the change they describe, by
+// RepositoryRepository.Save or Delete, and then handed to Appended.
+type EventPublisher interface {
+	Appended(events []*events.Event)
 }
 
 // RepositoryRepository defines the data access interface for repositories
@@ -104,14 +292,20 @@ type RepositoryRepository interface {
 	// Repository operations
 	Create(repo *Repository) error
 	GetByDID(did string) (*Repository, error)
+	List(limit int, afterDID string) ([]*Repository, error) // Ordered by DID, starting after afterDID
 	Update(repo *Repository) error
-	Delete(did string) error
+	Delete(did string, evts []*events.Event) error // Appends evts in the same transaction
+	
+	// Save updates repo, records commit if it isn't nil and appends evts to
+	// the event log, setting their sequence numbers, in one transaction
+	Save(repo *Repository, commit *Commit, evts []*events.Event) error
 	
 	// Commit operations
 	CreateCommit(commit *Commit) error
 	GetCommit(did string, cid cid.Cid) (*Commit, error)
 	GetLatestCommit(did string) (*Commit, error)
-	ListCommits(did string, limit int, offset int) ([]*Commit, error)
+	GetCommitByRevision(did string, rev string) (*Commit, error)
+	ListCommits(did string, limit int, beforeRev string) ([]*Commit, error) // Newest first, revisions before beforeRev
 	
 	// Record operations
 	CreateRecord(record *Record) error
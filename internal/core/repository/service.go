@@ -3,48 +3,142 @@ package repository
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"strings"
 	"time"
 
 	"Coves/internal/atproto/carstore"
+	atrepo "Coves/internal/atproto/repo"
+	coreerrors "Coves/internal/core/errors"
+	"Coves/internal/core/events"
+	"Coves/internal/core/keys"
+
+	comatproto "github.com/bluesky-social/indigo/api/atproto"
+	atdata "github.com/bluesky-social/indigo/atproto/data"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	indigocarstore "github.com/bluesky-social/indigo/carstore"
+	indigoevents "github.com/bluesky-social/indigo/events"
+	lexutil "github.com/bluesky-social/indigo/lex/util"
+	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	ipld "github.com/ipfs/go-ipld-format"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// Limits above which a #commit event omits its blocks and is marked tooBig
+const (
+	maxCommitEventBytes = 2 << 20
+	maxCommitEventOps   = 200
 )
 
+// statusDeleted is the #account status of a deleted repository
+const statusDeleted = "deleted"
+
+// covesNSIDPrefix prefixes the collections whose records are always validated
+const covesNSIDPrefix = "social.coves."
+
 // Service implements the RepositoryService interface using Indigo's carstore
 type Service struct {
-	repo        RepositoryRepository
-	repoStore   *carstore.RepoStore
-	signingKeys map[string]interface{} // DID -> signing key
+	repo         RepositoryRepository
+	repoStore    *carstore.RepoStore
+	keys         keys.KeyService
+	keyResolver  KeyResolver
+	importLimits atrepo.ImportLimits
+	events       EventPublisher
+	validator    RecordValidator
+	locker       RepoLocker
 }
 
-// NewService creates a new repository service using carstore
-func NewService(repo RepositoryRepository, repoStore *carstore.RepoStore) *Service {
+// NewService creates a new repository service using carstore. keyResolver
+// supplies the keys used to verify the commits of imported repositories.
+func NewService(repo RepositoryRepository, repoStore *carstore.RepoStore, keyService keys.KeyService, keyResolver KeyResolver) *Service {
 	return &Service{
-		repo:        repo,
-		repoStore:   repoStore,
-		signingKeys: make(map[string]interface{}),
+		repo:         repo,
+		repoStore:    repoStore,
+		keys:         keyService,
+		keyResolver:  keyResolver,
+		importLimits: atrepo.DefaultImportLimits,
+		locker:       NewLocalLocker(),
 	}
 }
 
-// SetSigningKey sets the signing key for a DID
-func (s *Service) SetSigningKey(did string, signingKey interface{}) {
-	s.signingKeys[did] = signingKey
+// SetImportLimits overrides the size and block-count limits for imports
+func (s *Service) SetImportLimits(limits atrepo.ImportLimits) {
+	s.importLimits = limits
+}
+
+// SetEventPublisher sets where repository events are published. Without one
+// no events are emitted.
+func (s *Service) SetEventPublisher(events EventPublisher) {
+	s.events = events
+}
+
+// SetRepoLocker sets how writes to a repository are serialised. The default
+// LocalLocker is only safe while a single instance serves each repository;
+// deployments with several instances need a shared locker.
+func (s *Service) SetRepoLocker(locker RepoLocker) {
+	s.locker = locker
+}
+
+// SetRecordValidator sets the lexicon validator for record writes. Records
+// in social.coves.* collections are always validated, and others when the
+// write asks for it. Without a validator no records are validated.
+func (s *Service) SetRecordValidator(validator RecordValidator) {
+	s.validator = validator
 }
 
-// CreateRepository creates a new repository
+// CreateRepository creates a new repository with a signed genesis commit
+// over an empty MST, so it has a valid head that can be exported and verified
 func (s *Service) CreateRepository(did string) (*Repository, error) {
+	repository, err := s.createRepository(did, StatusActive)
+	if err != nil {
+		return nil, err
+	}
+
+	// The repository is announced with its genesis commit, so nothing is
+	// published for one that fails to get a head
+	repository, err = s.applyCommit(did, nil, noWrites, identityEvent(did), accountEvent(repository))
+	if err != nil {
+		s.discardRepository(did)
+		return nil, fmt.Errorf("writing genesis commit: %w", err)
+	}
+
+	return repository, nil
+}
+
+// CreateInactiveRepository creates a deactivated repository with no commits,
+// for an account migrating to this server. Its data arrives through
+// ImportRepository, and ActivateRepository makes it live once it has.
+func (s *Service) CreateInactiveRepository(did string) (*Repository, error) {
+	repository, err := s.createRepository(did, StatusDeactivated)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.save(repository, nil, identityEvent(did), accountEvent(repository)); err != nil {
+		s.discardRepository(did)
+		return nil, fmt.Errorf("saving repository: %w", err)
+	}
+
+	return repository, nil
+}
+
+// createRepository records a repository with the given status and generates
+// its signing key, but writes no commit or events
+func (s *Service) createRepository(did string, status string) (*Repository, error) {
 	// Check if repository already exists
 	existing, err := s.repo.GetByDID(did)
 	if err != nil {
 		return nil, fmt.Errorf("checking existing repository: %w", err)
 	}
 	if existing != nil {
-		return nil, fmt.Errorf("repository already exists for DID: %s", did)
+		return nil, coreerrors.NewConflictError(coreerrors.ResourceRepo, "DID", did)
 	}
 
-	// For now, just create the user mapping without importing CAR data
-	// The actual repository data will be created when records are added
 	ctx := context.Background()
 
 	// Ensure user mapping exists
@@ -53,15 +147,12 @@ func (s *Service) CreateRepository(did string) (*Repository, error) {
 		return nil, fmt.Errorf("creating user mapping: %w", err)
 	}
 
-	// Use placeholder CID for the empty repository
-
-	// Create repository record
+	// Create repository record; the first commit fills in its head and revision
 	repository := &Repository{
 		DID:         did,
-		HeadCID:     PlaceholderCID,
-		Revision:    "rev-0",
 		RecordCount: 0,
 		StorageSize: 0,
+		Status:      status,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -71,9 +162,31 @@ func (s *Service) CreateRepository(did string) (*Repository, error) {
 		return nil, fmt.Errorf("saving repository: %w", err)
 	}
 
+	// Generate the signing key used for this repository's commits
+	if _, err := s.keys.CreateKey(did); err != nil {
+		s.discardRepository(did)
+		return nil, fmt.Errorf("creating signing key: %w", err)
+	}
+
 	return repository, nil
 }
 
+// discardRepository removes everything stored for a repository whose
+// creation failed partway, so it can be retried: its signing keys, its
+// carstore data and UID mapping, and its database record. Failures are
+// logged, since the caller is already returning an error.
+func (s *Service) discardRepository(did string) {
+	if err := s.keys.DeleteKeys(did); err != nil {
+		log.Printf("failed to delete signing keys of discarded repository %s: %v", did, err)
+	}
+	if err := s.repoStore.DeleteRepo(context.Background(), did); err != nil && !errors.Is(err, coreerrors.ErrNotFound) {
+		log.Printf("failed to delete carstore data of discarded repository %s: %v", did, err)
+	}
+	if err := s.repo.Delete(did, nil); err != nil {
+		log.Printf("failed to delete discarded repository %s: %v", did, err)
+	}
+}
+
 // GetRepository retrieves a repository by DID
 func (s *Service) GetRepository(did string) (*Repository, error) {
 	repo, err := s.repo.GetByDID(did)
@@ -81,7 +194,7 @@ func (s *Service) GetRepository(did string) (*Repository, error) {
 		return nil, fmt.Errorf("getting repository: %w", err)
 	}
 	if repo == nil {
-		return nil, fmt.Errorf("repository not found for DID: %s", did)
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
 	}
 
 	// Update head CID from carstore
@@ -93,151 +206,1333 @@ func (s *Service) GetRepository(did string) (*Repository, error) {
 	return repo, nil
 }
 
+// ListRepositories lists repositories ordered by DID.
+// The cursor is the DID of the last repository in the previous page.
+func (s *Service) ListRepositories(limit int, cursor string) ([]*Repository, string, error) {
+	repos, err := s.repo.List(limit, cursor)
+	if err != nil {
+		return nil, "", fmt.Errorf("listing repositories: %w", err)
+	}
+
+	var nextCursor string
+	if len(repos) == limit && len(repos) > 0 {
+		nextCursor = repos[len(repos)-1].DID
+	}
+
+	return repos, nextCursor, nil
+}
+
 // DeleteRepository deletes a repository
 func (s *Service) DeleteRepository(did string) error {
+	unlock, err := s.lockRepo(did, "delete")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Delete from carstore
 	if err := s.repoStore.DeleteRepo(context.Background(), did); err != nil {
 		return fmt.Errorf("deleting repo from carstore: %w", err)
 	}
 
-	// Delete from database
-	if err := s.repo.Delete(did); err != nil {
+	// Delete from database, announcing the deletion with it
+	evts, err := s.encodeEvents(accountEvent(&Repository{DID: did, Status: statusDeleted}))
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(did, evts); err != nil {
 		return fmt.Errorf("deleting repository from database: %w", err)
 	}
+	s.appended(evts)
 
 	return nil
 }
 
-// ExportRepository exports a repository as a CAR file
-func (s *Service) ExportRepository(did string) ([]byte, error) {
-	// First check if repository exists in our database
+// DeactivateRepository deactivates a repository at its owner's request. Its
+// data is kept, but it isn't served or written to until it is activated.
+func (s *Service) DeactivateRepository(did string) (*Repository, error) {
+	return s.setStatus(did, StatusDeactivated, "", func(repo *Repository) error {
+		if repo.Status != StatusActive && repo.Status != StatusDeactivated {
+			return InactiveError{DID: did, Status: repo.Status}
+		}
+		return nil
+	})
+}
+
+// ActivateRepository reactivates a repository its owner deactivated. Owners
+// can't lift a takedown or suspension. Consumers are sent a #sync event for
+// the repository's head, since it may have been imported while inactive; a
+// repository that was never given any data gets a genesis commit instead.
+// Either is recorded with the status change.
+func (s *Service) ActivateRepository(did string) (*Repository, error) {
+	unlock, err := s.lockRepo(did, "status")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	repo, err := s.repo.GetByDID(did)
 	if err != nil {
 		return nil, fmt.Errorf("getting repository: %w", err)
 	}
 	if repo == nil {
-		return nil, fmt.Errorf("repository not found for DID: %s", did)
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	if repo.Status == StatusActive {
+		return repo, nil
+	}
+	if repo.Status != StatusDeactivated {
+		return nil, InactiveError{DID: did, Status: repo.Status}
 	}
 
-	// Try to read from carstore
-	carData, err := s.repoStore.ReadRepo(context.Background(), did, "")
+	repo.Status = StatusActive
+	repo.StatusReason = ""
+	repo.UpdatedAt = time.Now()
+	if !repo.HeadCID.Defined() {
+		repo, err = s.commit(repo, nil, noWrites, accountEvent(repo))
+		if err != nil {
+			return nil, fmt.Errorf("writing genesis commit: %w", err)
+		}
+		return repo, nil
+	}
+
+	session, err := s.repoStore.ReadOnlySession(did)
 	if err != nil {
-		// If no data in carstore yet, return empty CAR
-		// This happens when a repo is created but no records added yet
-		// Check for the specific error pattern from Indigo's carstore
-		errMsg := err.Error()
-		if strings.Contains(errMsg, "no data found for user") ||
-			strings.Contains(errMsg, "user not found") {
-			return []byte{}, nil
+		return nil, fmt.Errorf("opening read session: %w", err)
+	}
+	commitBlock, err := session.Get(context.Background(), repo.HeadCID)
+	if err != nil {
+		return nil, fmt.Errorf("reading head commit: %w", err)
+	}
+	sync, err := syncEvent(did, repo.Revision, commitBlock)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.save(repo, nil, accountEvent(repo), sync); err != nil {
+		return nil, fmt.Errorf("updating repository status: %w", err)
+	}
+
+	return repo, nil
+}
+
+// UpdateRepositoryStatus sets a repository's account status on behalf of an
+// admin, recording why. Takedowns and suspensions only hide the repository,
+// so setting the status back to active restores it as it was.
+func (s *Service) UpdateRepositoryStatus(did string, status string, reason string) (*Repository, error) {
+	if !ValidStatus(status) {
+		return nil, coreerrors.NewValidationError("status", fmt.Sprintf("unknown account status %q", status))
+	}
+	return s.setStatus(did, status, reason, func(*Repository) error { return nil })
+}
+
+// setStatus changes a repository's account status if check allows it, and
+// records an #account event when the status changes
+func (s *Service) setStatus(did string, status string, reason string, check func(repo *Repository) error) (*Repository, error) {
+	unlock, err := s.lockRepo(did, "status")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	repo, err := s.repo.GetByDID(did)
+	if err != nil {
+		return nil, fmt.Errorf("getting repository: %w", err)
+	}
+	if repo == nil {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	if err := check(repo); err != nil {
+		return nil, err
+	}
+
+	changed := repo.Status != status
+	repo.Status = status
+	repo.StatusReason = reason
+	repo.UpdatedAt = time.Now()
+	var evts []*indigoevents.XRPCStreamEvent
+	if changed {
+		evts = append(evts, accountEvent(repo))
+	}
+	if err := s.save(repo, nil, evts...); err != nil {
+		return nil, fmt.Errorf("updating repository status: %w", err)
+	}
+
+	return repo, nil
+}
+
+// activeRepo returns a repository that exists and is active
+func (s *Service) activeRepo(did string) (*Repository, error) {
+	repo, err := s.repo.GetByDID(did)
+	if err != nil {
+		return nil, fmt.Errorf("getting repository: %w", err)
+	}
+	if repo == nil {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	if !repo.Active() {
+		return nil, InactiveError{DID: did, Status: repo.Status}
+	}
+	return repo, nil
+}
+
+// ExportRepository streams a repository as a CAR file to w. If since is set
+// only the blocks written after that revision are exported. Export stops
+// early if ctx is cancelled.
+func (s *Service) ExportRepository(ctx context.Context, did string, since string, w io.Writer) error {
+	// First check the repository exists and may be served
+	if _, err := s.activeRepo(did); err != nil {
+		return err
+	}
+
+	// Stream from carstore
+	err := s.repoStore.ReadRepo(ctx, did, since, w)
+	if err != nil && isNoRepoData(err) {
+		// Repositories created before genesis commits were written have no
+		// data; give them a genesis commit so there is a head to export
+		if _, err := s.applyCommit(did, nil, noWrites); err != nil {
+			return fmt.Errorf("writing genesis commit: %w", err)
 		}
-		return nil, fmt.Errorf("exporting repository: %w", err)
+		err = s.repoStore.ReadRepo(ctx, did, since, w)
+	}
+	if err != nil {
+		return fmt.Errorf("exporting repository: %w", err)
 	}
 
-	return carData, nil
+	return nil
 }
 
-// ImportRepository imports a repository from a CAR file
-func (s *Service) ImportRepository(did string, carData []byte) error {
-	ctx := context.Background()
+// isNoRepoData reports whether a carstore error means the DID has no
+// repository data
+func isNoRepoData(err error) bool {
+	return errors.Is(err, ErrRepoNotFound)
+}
 
-	// If empty CAR data, just create user mapping
-	if len(carData) == 0 {
-		_, err := s.repoStore.GetOrCreateUID(ctx, did)
+// noWrites is an applyCommit function for commits that change no records,
+// such as a genesis commit over an empty MST
+func noWrites(w *atrepo.Wrapper) ([]*comatproto.SyncSubscribeRepos_RepoOp, error) {
+	return []*comatproto.SyncSubscribeRepos_RepoOp{}, nil
+}
+
+// GetBlocks writes the requested blocks from a repository to w as a CAR
+// rooted at the current head. If any block is missing nothing is written and
+// ErrBlockNotFound is returned.
+func (s *Service) GetBlocks(ctx context.Context, did string, cids []cid.Cid, w io.Writer) error {
+	if _, err := s.activeRepo(did); err != nil {
+		return err
+	}
+
+	head, err := s.repoStore.GetRepoHead(ctx, did)
+	if err != nil {
+		return fmt.Errorf("getting repo head: %w", err)
+	}
+	if !head.Defined() {
+		return fmt.Errorf("%w: repository has no commits", ErrBlockNotFound)
+	}
+
+	session, err := s.repoStore.ReadOnlySession(did)
+	if err != nil {
+		return fmt.Errorf("opening read session: %w", err)
+	}
+
+	// Collect every block before writing so a missing one can still be reported
+	blks := make([]blocks.Block, 0, len(cids))
+	for _, c := range cids {
+		// Has is scoped to this repository; Get would find other users' blocks
+		has, err := session.Has(ctx, c)
 		if err != nil {
-			return fmt.Errorf("creating user mapping: %w", err)
+			return fmt.Errorf("checking block %s: %w", c, err)
 		}
+		if !has {
+			return coreerrors.NewNotFoundError(coreerrors.ResourceBlock, c)
+		}
+		blk, err := session.Get(ctx, c)
+		if err != nil {
+			return fmt.Errorf("getting block %s: %w", c, err)
+		}
+		blks = append(blks, blk)
+	}
+
+	return atrepo.WriteCAR(w, head, blks)
+}
 
-		// Use placeholder CID for empty repository
-		headCID := PlaceholderCID
+// GetRecordProof writes a CAR to w containing the head commit, the MST
+// nodes on the path to a record, and the record itself
+func (s *Service) GetRecordProof(ctx context.Context, did string, collection string, rkey string, w io.Writer) error {
+	wrapper, err := s.openRepo(did)
+	if err != nil {
+		return err
+	}
+	if wrapper == nil {
+		return coreerrors.NewNotFoundError(coreerrors.ResourceRecord, recordURI(did, collection, rkey))
+	}
 
-		// Create repository record
-		repo := &Repository{
-			DID:         did,
-			HeadCID:     headCID,
-			Revision:    "imported-empty",
-			RecordCount: 0,
-			StorageSize: 0,
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		}
-		if err := s.repo.Create(repo); err != nil {
-			return fmt.Errorf("creating repository: %w", err)
+	blks, err := wrapper.RecordProof(collection, rkey)
+	if err != nil {
+		if errors.Is(err, atrepo.ErrRecordNotFound) {
+			return coreerrors.NewNotFoundError(coreerrors.ResourceRecord, recordURI(did, collection, rkey))
 		}
-		return nil
+		return fmt.Errorf("building record proof: %w", err)
+	}
+
+	head, err := wrapper.GetHeadCID()
+	if err != nil {
+		return err
+	}
+
+	return atrepo.WriteCAR(w, head, blks)
+}
+
+// ImportRepository verifies a repository CAR stream and stores it in a
+// deactivated repository created for a migration. Every block's CID is
+// checked, the commit signature is verified against the DID's key, and
+// records are counted per collection. Nothing is stored unless the whole CAR
+// verifies.
+func (s *Service) ImportRepository(ctx context.Context, did string, r io.Reader) (*ImportResult, error) {
+	if s.keyResolver == nil {
+		return nil, fmt.Errorf("no key resolver configured for verifying imports")
+	}
+	key, err := s.keyResolver.ResolveSigningKey(ctx, did)
+	if err != nil {
+		return nil, fmt.Errorf("resolving signing key: %w", err)
 	}
 
-	// Import non-empty CAR into carstore
-	headCID, err := s.repoStore.ImportRepo(ctx, did, bytes.NewReader(carData))
+	unlock, err := s.lockRepo(did, "import")
 	if err != nil {
-		return fmt.Errorf("importing repository: %w", err)
+		return nil, err
 	}
+	defer unlock()
 
-	// Create or update repository record
+	// Only a deactivated repository may be filled by a migration. Active
+	// ones are changed through commits, and taken down and suspended ones
+	// are kept as they are until reactivated.
 	repo, err := s.repo.GetByDID(did)
 	if err != nil {
-		return fmt.Errorf("getting repository: %w", err)
+		return nil, fmt.Errorf("getting repository: %w", err)
+	}
+	if repo == nil {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	if repo.Active() {
+		return nil, coreerrors.NewValidationError("did", fmt.Sprintf("repository %s is active; only a deactivated repository can be imported into", did))
+	}
+	if repo.Status != StatusDeactivated {
+		return nil, InactiveError{DID: did, Status: repo.Status}
+	}
+
+	// Stream and verify the CAR into a session that is only persisted on success
+	session, err := s.repoStore.NewImportSession(ctx, did)
+	if err != nil {
+		return nil, fmt.Errorf("opening import session: %w", err)
+	}
+	verified, err := atrepo.VerifyCAR(ctx, r, session, did, key, s.importLimits)
+	if err != nil {
+		return nil, fmt.Errorf("verifying repository: %w", err)
+	}
+
+	// An older commit would roll the repository back, even though it was
+	// validly signed
+	if repo.Revision != "" && verified.Commit.Rev <= repo.Revision {
+		return nil, coreerrors.NewValidationError("rev", fmt.Sprintf("imported revision %s is not newer than the current revision %s", verified.Commit.Rev, repo.Revision))
+	}
+	if _, err := session.CloseWithRoot(ctx, verified.Root, verified.Commit.Rev); err != nil {
+		return nil, fmt.Errorf("importing repository: %w", err)
+	}
+
+	result := &ImportResult{
+		HeadCID:     verified.Root,
+		Revision:    verified.Commit.Rev,
+		RecordCount: verified.RecordCount(),
+		Collections: verified.Collections,
+		StorageSize: verified.Bytes,
 	}
 
+	// Shards from before the import stay on disk until the next compaction,
+	// so the repository's storage is measured rather than taken from the CAR
+	_, storageSize, err := s.repoStore.DiskUsage(ctx, did)
+	if err != nil {
+		return nil, fmt.Errorf("measuring repository: %w", err)
+	}
+
+	// The repository stays deactivated, so nothing is announced until it is
+	// activated
+	repo.HeadCID = result.HeadCID
+	repo.Revision = result.Revision
+	repo.RecordCount = result.RecordCount
+	repo.StorageSize = storageSize
+	repo.UpdatedAt = time.Now()
+
+	// The imported commit is recorded so history and diffs start from it.
+	// It is newer than the current head, so it isn't recorded yet.
+	commit := &Commit{
+		CID:          verified.Root,
+		DID:          did,
+		Version:      int(verified.Commit.Version),
+		PrevCID:      verified.Commit.Prev,
+		DataCID:      verified.Commit.Data,
+		Revision:     verified.Commit.Rev,
+		Signature:    verified.Commit.Sig,
+		SigningKeyID: key.DIDKey(),
+		CreatedAt:    time.Now(),
+	}
+
+	if err := s.save(repo, commit); err != nil {
+		return nil, fmt.Errorf("updating repository: %w", err)
+	}
+
+	return result, nil
+}
+
+// ListBlobRefs lists the blobs referenced by a repository's records. It
+// serves deactivated repositories too, so a migrating account can find the
+// blobs it still has to upload.
+func (s *Service) ListBlobRefs(did string) ([]BlobRef, error) {
+	repo, err := s.repo.GetByDID(did)
+	if err != nil {
+		return nil, fmt.Errorf("getting repository: %w", err)
+	}
 	if repo == nil {
-		// Create new repository
-		repo = &Repository{
-			DID:         did,
-			HeadCID:     headCID,
-			Revision:    "imported",
-			RecordCount: 0, // TODO: Count records in CAR
-			StorageSize: int64(len(carData)),
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		}
-		if err := s.repo.Create(repo); err != nil {
-			return fmt.Errorf("creating repository: %w", err)
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	if !repo.Active() && repo.Status != StatusDeactivated {
+		return nil, InactiveError{DID: did, Status: repo.Status}
+	}
+
+	w, err := s.loadRepo(did)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return []BlobRef{}, nil
+	}
+
+	refs, err := w.BlobRefs()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]BlobRef, len(refs))
+	for i, ref := range refs {
+		out[i] = BlobRef{CID: ref.CID, RecordURI: recordURI(did, ref.Collection, ref.RecordKey)}
+	}
+	return out, nil
+}
+
+// CompactRepository runs garbage collection on a repository, merging its
+// shards while holding its write lock. An aggressive compaction also
+// rewrites the large shards at the start of its history, which is worth it
+// for repositories that no longer change.
+func (s *Service) CompactRepository(did string, aggressive bool) (*carstore.CompactionStats, error) {
+	unlock, err := s.lockRepo(did, "compact")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	ctx := context.Background()
+	stats, err := s.repoStore.CompactRepo(ctx, did, aggressive)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compaction doesn't count as a write, so UpdatedAt is stored unchanged
+	repo, err := s.repo.GetByDID(did)
+	if err != nil {
+		return nil, fmt.Errorf("getting repository: %w", err)
+	}
+	if repo != nil {
+		_, storageSize, err := s.repoStore.DiskUsage(ctx, did)
+		if err != nil {
+			return nil, fmt.Errorf("measuring repository: %w", err)
 		}
-	} else {
-		// Update existing repository
-		repo.HeadCID = headCID
-		repo.UpdatedAt = time.Now()
-		if err := s.repo.Update(repo); err != nil {
-			return fmt.Errorf("updating repository: %w", err)
+		if storageSize != repo.StorageSize {
+			repo.StorageSize = storageSize
+			if err := s.repo.Update(repo); err != nil {
+				return nil, fmt.Errorf("updating repository: %w", err)
+			}
 		}
 	}
 
-	return nil
+	return stats, nil
 }
 
-// CompactRepository runs garbage collection on a repository
-func (s *Service) CompactRepository(did string) error {
-	return s.repoStore.CompactRepo(context.Background(), did)
+// ShardCount returns how many carstore shards hold a repository
+func (s *Service) ShardCount(did string) (int, error) {
+	return s.repoStore.ShardCount(context.Background(), did)
 }
 
-// Note: Record-level operations would require more complex implementation
-// to work with the carstore. For now, these are placeholder implementations
-// that would need to be expanded to properly handle record CRUD operations
-// by reading the CAR, modifying the repo structure, and writing back.
+// GetRepositoryStats counts a repository's records from its MST and measures
+// its shards on disk. The stored record count and storage size are kept up
+// to date by each write; if they have drifted, they are corrected here. The
+// repository's status doesn't matter, so inactive repositories can be
+// inspected too.
+func (s *Service) GetRepositoryStats(did string) (*RepoStats, error) {
+	repo, err := s.repo.GetByDID(did)
+	if err != nil {
+		return nil, fmt.Errorf("getting repository: %w", err)
+	}
+	if repo == nil {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+
+	// The MST is walked without the write lock, so counting a large
+	// repository doesn't hold up writes to it
+	collections := map[string]int{}
+	if repo.HeadCID.Defined() {
+		session, err := s.repoStore.ReadOnlySession(did)
+		if err != nil {
+			return nil, fmt.Errorf("opening read session: %w", err)
+		}
+		w, err := atrepo.LoadWrapper(repo.HeadCID, session)
+		if err != nil {
+			return nil, err
+		}
+		if collections, err = w.CountRecords(); err != nil {
+			return nil, err
+		}
+	}
+	recordCount := 0
+	for _, n := range collections {
+		recordCount += n
+	}
+
+	// The stored totals are corrected under the lock. The record count is
+	// left alone if a commit landed while counting, since that commit
+	// adjusted it.
+	unlock, err := s.lockRepo(did, "stats")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	current, err := s.repo.GetByDID(did)
+	if err != nil {
+		return nil, fmt.Errorf("getting repository: %w", err)
+	}
+	if current == nil {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	shards, storageSize, err := s.repoStore.DiskUsage(context.Background(), did)
+	if err != nil {
+		return nil, fmt.Errorf("measuring repository: %w", err)
+	}
+
+	storedCount := current.RecordCount
+	if current.HeadCID.Equals(repo.HeadCID) {
+		storedCount = recordCount
+	}
+	if storedCount != current.RecordCount || storageSize != current.StorageSize {
+		log.Printf("Correcting accounting for %s: %d records (stored %d), %d bytes (stored %d)",
+			did, storedCount, current.RecordCount, storageSize, current.StorageSize)
+		current.RecordCount = storedCount
+		current.StorageSize = storageSize
+		if err := s.repo.Update(current); err != nil {
+			return nil, fmt.Errorf("updating repository: %w", err)
+		}
+	}
+
+	return &RepoStats{
+		DID:         did,
+		Status:      current.Status,
+		Revision:    repo.Revision,
+		Shards:      shards,
+		DiskBytes:   storageSize,
+		RecordCount: recordCount,
+		Collections: collections,
+		UpdatedAt:   current.UpdatedAt,
+	}, nil
+}
 
+// CreateRecord adds a record to the repository and produces a new commit
 func (s *Service) CreateRecord(input CreateRecordInput) (*Record, error) {
-	return nil, fmt.Errorf("record operations not yet implemented for carstore")
+	result, err := s.ApplyWrites(ApplyWritesInput{
+		DID:        input.DID,
+		Validate:   input.Validate,
+		SwapCommit: input.SwapCommit,
+		Writes: []WriteOp{{
+			Action:     WriteActionCreate,
+			Collection: input.Collection,
+			RecordKey:  input.RecordKey,
+			Record:     input.Record,
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating record: %w", err)
+	}
+
+	return newRecord(result.Results[0], input.Collection, input.Record)
 }
 
+// GetRecord retrieves a record from the repository's current head
 func (s *Service) GetRecord(input GetRecordInput) (*Record, error) {
-	return nil, fmt.Errorf("record operations not yet implemented for carstore")
+	w, err := s.openRepo(input.DID)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRecord, recordURI(input.DID, input.Collection, input.RecordKey))
+	}
+
+	recordCID, value, err := w.GetRecord(input.Collection, input.RecordKey)
+	if err != nil {
+		if errors.Is(err, atrepo.ErrRecordNotFound) {
+			return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRecord, recordURI(input.DID, input.Collection, input.RecordKey))
+		}
+		return nil, fmt.Errorf("getting record: %w", err)
+	}
+
+	return &Record{
+		URI:        recordURI(input.DID, input.Collection, input.RecordKey),
+		CID:        recordCID,
+		Collection: input.Collection,
+		RecordKey:  input.RecordKey,
+		Value:      value,
+	}, nil
 }
 
+// UpdateRecord replaces an existing record and produces a new commit
 func (s *Service) UpdateRecord(input UpdateRecordInput) (*Record, error) {
-	return nil, fmt.Errorf("record operations not yet implemented for carstore")
+	result, err := s.ApplyWrites(ApplyWritesInput{
+		DID:        input.DID,
+		Validate:   input.Validate,
+		SwapCommit: input.SwapCommit,
+		Writes: []WriteOp{{
+			Action:     WriteActionUpdate,
+			Collection: input.Collection,
+			RecordKey:  input.RecordKey,
+			Record:     input.Record,
+			SwapRecord: input.SwapRecord,
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("updating record: %w", err)
+	}
+
+	return newRecord(result.Results[0], input.Collection, input.Record)
 }
 
+// DeleteRecord removes a record from the repository and produces a new commit
 func (s *Service) DeleteRecord(input DeleteRecordInput) error {
-	return fmt.Errorf("record operations not yet implemented for carstore")
+	_, err := s.ApplyWrites(ApplyWritesInput{
+		DID:        input.DID,
+		SwapCommit: input.SwapCommit,
+		Writes: []WriteOp{{
+			Action:     WriteActionDelete,
+			Collection: input.Collection,
+			RecordKey:  input.RecordKey,
+			SwapRecord: input.SwapRecord,
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("deleting record: %w", err)
+	}
+	return nil
 }
 
+// ApplyWrites applies a batch of creates, updates and deletes atomically.
+// All operations share one delta session and produce a single commit;
+// if any operation fails, nothing is written.
+func (s *Service) ApplyWrites(input ApplyWritesInput) (*ApplyWritesResult, error) {
+	if len(input.Writes) == 0 {
+		return nil, coreerrors.NewValidationError("writes", "no writes provided")
+	}
+
+	// Check every operation up front so a bad op never touches the repository
+	for i, op := range input.Writes {
+		if err := checkWriteOp(op); err != nil {
+			return nil, fmt.Errorf("write %d: %w", i, err)
+		}
+		if err := s.validateRecord(op, input.Validate); err != nil {
+			return nil, fmt.Errorf("write %d: %w", i, err)
+		}
+	}
+
+	results := make([]WriteResult, len(input.Writes))
+	repo, err := s.applyCommit(input.DID, input.SwapCommit, func(w *atrepo.Wrapper) ([]*comatproto.SyncSubscribeRepos_RepoOp, error) {
+		ops := make([]*comatproto.SyncSubscribeRepos_RepoOp, len(input.Writes))
+		for i, op := range input.Writes {
+			result, repoOp, err := applyWriteOp(w, input.DID, op)
+			if err != nil {
+				return nil, fmt.Errorf("write %d: %w", i, err)
+			}
+			results[i] = result
+			ops[i] = repoOp
+		}
+		return ops, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApplyWritesResult{
+		CommitCID: repo.HeadCID,
+		Revision:  repo.Revision,
+		Results:   results,
+	}, nil
+}
+
+// checkWriteOp verifies that a write operation is well formed. Mistakes in
+// the caller's input are validation errors.
+func checkWriteOp(op WriteOp) error {
+	if op.Collection == "" {
+		return coreerrors.NewValidationError("collection", "missing collection")
+	}
+	if _, err := syntax.ParseNSID(op.Collection); err != nil {
+		return coreerrors.NewValidationError("collection", fmt.Sprintf("invalid collection %q: %v", op.Collection, err))
+	}
+	if op.RecordKey != "" {
+		if _, err := syntax.ParseRecordKey(op.RecordKey); err != nil {
+			return coreerrors.NewValidationError("rkey", fmt.Sprintf("invalid record key %q: %v", op.RecordKey, err))
+		}
+	}
+
+	switch op.Action {
+	case WriteActionCreate, WriteActionUpdate:
+		if op.Action == WriteActionUpdate && op.RecordKey == "" {
+			return coreerrors.NewValidationError("rkey", "missing record key for update")
+		}
+		if _, ok := op.Record.(cbg.CBORMarshaler); !ok {
+			return fmt.Errorf("record must be CBOR-marshalable, got %T", op.Record)
+		}
+		if op.Action == WriteActionCreate && op.SwapRecord != nil {
+			return coreerrors.NewValidationError("swapRecord", "swapRecord is not supported for creates")
+		}
+	case WriteActionDelete:
+		if op.RecordKey == "" {
+			return coreerrors.NewValidationError("rkey", "missing record key for delete")
+		}
+	default:
+		return coreerrors.NewValidationError("action", fmt.Sprintf("unknown write action: %q", op.Action))
+	}
+
+	return nil
+}
+
+// validateRecord checks a create or update against the lexicon for its
+// collection. Coves' own collections are always checked; others only when
+// the caller asked for validation.
+func (s *Service) validateRecord(op WriteOp, validate bool) error {
+	if s.validator == nil || op.Action == WriteActionDelete {
+		return nil
+	}
+	if !validate && !strings.HasPrefix(op.Collection, covesNSIDPrefix) {
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+	if err := op.Record.(cbg.CBORMarshaler).MarshalCBOR(buf); err != nil {
+		return fmt.Errorf("encoding record: %w", err)
+	}
+	value, err := atdata.UnmarshalCBOR(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+	if err := s.validator.ValidateRecord(value, op.Collection); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+	return nil
+}
+
+// applyWriteOp applies a single checked write operation to the MST, returning
+// its result and the operation as it appears in the commit's firehose event
+func applyWriteOp(w *atrepo.Wrapper, did string, op WriteOp) (WriteResult, *comatproto.SyncSubscribeRepos_RepoOp, error) {
+	result := WriteResult{Action: op.Action, RecordKey: op.RecordKey}
+	repoOp := &comatproto.SyncSubscribeRepos_RepoOp{Action: op.Action}
+
+	switch op.Action {
+	case WriteActionCreate:
+		recordKey := op.RecordKey
+		if recordKey != "" {
+			if _, _, err := w.GetRecord(op.Collection, recordKey); err == nil {
+				return result, nil, coreerrors.NewConflictError(coreerrors.ResourceRecord, "URI", recordURI(did, op.Collection, recordKey))
+			} else if !errors.Is(err, atrepo.ErrRecordNotFound) {
+				return result, nil, err
+			}
+		}
+
+		recordCID, rkey, err := w.CreateRecord(op.Collection, recordKey, op.Record.(cbg.CBORMarshaler))
+		if err != nil {
+			return result, nil, err
+		}
+		result.URI = recordURI(did, op.Collection, rkey)
+		result.RecordKey = rkey
+		result.CID = recordCID
+
+	case WriteActionUpdate:
+		prev, err := checkSwapRecord(w, op)
+		if err != nil {
+			return result, nil, err
+		}
+		recordCID, err := w.UpdateRecord(op.Collection, op.RecordKey, op.Record.(cbg.CBORMarshaler))
+		if err != nil {
+			return result, nil, err
+		}
+		result.URI = recordURI(did, op.Collection, op.RecordKey)
+		result.CID = recordCID
+		repoOp.Prev = lexLink(prev)
+
+	case WriteActionDelete:
+		prev, err := checkSwapRecord(w, op)
+		if err != nil {
+			return result, nil, err
+		}
+		if err := w.DeleteRecord(op.Collection, op.RecordKey); err != nil {
+			return result, nil, err
+		}
+		result.URI = recordURI(did, op.Collection, op.RecordKey)
+		repoOp.Prev = lexLink(prev)
+	}
+
+	repoOp.Path = op.Collection + "/" + result.RecordKey
+	if result.CID.Defined() {
+		repoOp.Cid = lexLink(result.CID)
+	}
+
+	return result, repoOp, nil
+}
+
+// checkSwapRecord returns the current CID of the record targeted by op,
+// verifying that it matches op.SwapRecord if one was given
+func checkSwapRecord(w *atrepo.Wrapper, op WriteOp) (cid.Cid, error) {
+	current, _, err := w.GetRecord(op.Collection, op.RecordKey)
+	if errors.Is(err, atrepo.ErrRecordNotFound) && op.SwapRecord != nil {
+		return cid.Undef, fmt.Errorf("%w: record %s/%s does not exist, expected %s", ErrInvalidSwap, op.Collection, op.RecordKey, op.SwapRecord)
+	}
+	if err != nil {
+		return cid.Undef, err
+	}
+	if op.SwapRecord != nil && !current.Equals(*op.SwapRecord) {
+		return cid.Undef, fmt.Errorf("%w: record %s/%s is at %s, expected %s", ErrInvalidSwap, op.Collection, op.RecordKey, current, op.SwapRecord)
+	}
+
+	return current, nil
+}
+
+// ListRecords lists records in a collection ordered by record key.
+// The cursor is the record key of the last record in the previous page.
 func (s *Service) ListRecords(did string, collection string, limit int, cursor string) ([]*Record, string, error) {
-	return nil, "", fmt.Errorf("record operations not yet implemented for carstore")
+	w, err := s.openRepo(did)
+	if err != nil {
+		return nil, "", err
+	}
+	if w == nil {
+		return []*Record{}, "", nil
+	}
+
+	infos, err := w.ListRecords(collection, cursor, limit)
+	if err != nil {
+		return nil, "", fmt.Errorf("listing records: %w", err)
+	}
+
+	records := make([]*Record, 0, len(infos))
+	for _, info := range infos {
+		_, value, err := w.GetRecord(info.Collection, info.RecordKey)
+		if err != nil {
+			return nil, "", fmt.Errorf("reading record %s/%s: %w", info.Collection, info.RecordKey, err)
+		}
+		records = append(records, &Record{
+			URI:        recordURI(did, info.Collection, info.RecordKey),
+			CID:        info.CID,
+			Collection: info.Collection,
+			RecordKey:  info.RecordKey,
+			Value:      value,
+		})
+	}
+
+	var nextCursor string
+	if limit > 0 && len(records) == limit {
+		nextCursor = records[len(records)-1].RecordKey
+	}
+
+	return records, nextCursor, nil
+}
+
+// openRepo opens a read-only view of the repository at its current head.
+// It returns a nil wrapper if the repository has no commits yet.
+func (s *Service) openRepo(did string) (*atrepo.Wrapper, error) {
+	if _, err := s.activeRepo(did); err != nil {
+		return nil, err
+	}
+	return s.loadRepo(did)
+}
+
+// loadRepo is openRepo without the status check
+func (s *Service) loadRepo(did string) (*atrepo.Wrapper, error) {
+	ctx := context.Background()
+	head, err := s.repoStore.GetRepoHead(ctx, did)
+	if err != nil {
+		return nil, fmt.Errorf("getting repo head: %w", err)
+	}
+	if !head.Defined() {
+		return nil, nil
+	}
+
+	session, err := s.repoStore.ReadOnlySession(did)
+	if err != nil {
+		return nil, fmt.Errorf("opening read session: %w", err)
+	}
+
+	return atrepo.LoadWrapper(head, session)
+}
+
+// applyCommit opens a delta session on the repository head, lets fn modify the
+// MST, then signs a new commit, writes it to the carstore, advances the
+// repository's head and revision and records a #commit event with the ops
+// fn returns. If swapCommit is non-nil the head must match it or
+// ErrInvalidSwap is returned. Any announce events are recorded just before
+// the #commit event. The repository's write lock is held throughout, so
+// concurrent writers commit one after another.
+func (s *Service) applyCommit(did string, swapCommit *cid.Cid, fn func(w *atrepo.Wrapper) ([]*comatproto.SyncSubscribeRepos_RepoOp, error), announce ...*indigoevents.XRPCStreamEvent) (*Repository, error) {
+	unlock, err := s.lockRepo(did, "commit")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	repo, err := s.activeRepo(did)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.commit(repo, swapCommit, fn, announce...)
 }
 
+// commit is applyCommit for a repository the caller has locked and loaded
+func (s *Service) commit(repo *Repository, swapCommit *cid.Cid, fn func(w *atrepo.Wrapper) ([]*comatproto.SyncSubscribeRepos_RepoOp, error), announce ...*indigoevents.XRPCStreamEvent) (*Repository, error) {
+	did := repo.DID
+	ctx := context.Background()
+	rev, session, err := s.openDeltaSession(ctx, did)
+	if err != nil {
+		return nil, err
+	}
+
+	// The carstore may serve the head and the DID's UID from in-memory
+	// caches, which are stale if another instance has committed since or
+	// deleted and recreated the repository. The head recorded in
+	// the database is checked under the lock, so a mismatch means the cache
+	// must be reloaded before building on it.
+	if !session.BaseCid().Equals(repo.HeadCID) {
+		if err := s.repoStore.ReloadHead(ctx, did); err != nil {
+			return nil, err
+		}
+		if rev, session, err = s.openDeltaSession(ctx, did); err != nil {
+			return nil, err
+		}
+		if head := session.BaseCid(); !head.Equals(repo.HeadCID) {
+			// The carstore holds a commit the database doesn't know about,
+			// because recording it failed after its blocks were written.
+			// It is the latest commit, so this one builds on it and brings
+			// the record up to date.
+			log.Printf("Repository %s has carstore head %s but recorded head %s", did, head, repo.HeadCID)
+		}
+	}
+
+	head := session.BaseCid()
+	if swapCommit != nil && !swapCommit.Equals(head) {
+		return nil, fmt.Errorf("%w: repository head is %s, expected %s", ErrInvalidSwap, head, swapCommit)
+	}
+
+	var w *atrepo.Wrapper
+	if head.Defined() {
+		w, err = atrepo.LoadWrapper(head, session)
+	} else {
+		w, err = atrepo.NewWrapper(did, session)
+	}
+	if err != nil {
+		return nil, err
+	}
+	prevData := w.DataCID()
+
+	ops, err := fn(w)
+	if err != nil {
+		return nil, err
+	}
+
+	var signingKeyID string
+	signedCommit, err := w.Commit(func(ctx context.Context, did string, data []byte) ([]byte, error) {
+		sig, keyID, err := s.keys.Sign(ctx, did, data)
+		signingKeyID = keyID
+		return sig, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("committing: %w", err)
+	}
+	commitCID, err := w.GetHeadCID()
+	if err != nil {
+		return nil, err
+	}
+
+	slice, err := session.CloseWithRoot(ctx, commitCID, signedCommit.Rev)
+	if err != nil {
+		return nil, fmt.Errorf("writing commit to carstore: %w", err)
+	}
+
+	commit := &Commit{
+		CID:          commitCID,
+		DID:          did,
+		Version:      int(signedCommit.Version),
+		DataCID:      signedCommit.Data,
+		Revision:     signedCommit.Rev,
+		Signature:    signedCommit.Sig,
+		SigningKeyID: signingKeyID,
+		CreatedAt:    time.Now(),
+	}
+	if prev := session.BaseCid(); prev.Defined() {
+		commit.PrevCID = &prev
+	}
+
+	evt := &comatproto.SyncSubscribeRepos_Commit{
+		Repo:   did,
+		Rev:    signedCommit.Rev,
+		Commit: lexutil.LexLink(commitCID),
+		Ops:    ops,
+		Blobs:  []lexutil.LexLink{},
+		Time:   syntax.DatetimeNow().String(),
+	}
+	if rev != "" {
+		evt.Since = &rev
+	}
+	if prevData.Defined() {
+		evt.PrevData = lexLink(prevData)
+	}
+	if len(slice) > maxCommitEventBytes || len(ops) > maxCommitEventOps {
+		// Consumers fetch the repository instead
+		evt.TooBig = true
+	} else {
+		evt.Blocks = slice
+	}
+
+	repo.HeadCID = commitCID
+	repo.Revision = signedCommit.Rev
+	repo.RecordCount += recordCountDelta(ops)
+	repo.StorageSize, err = s.repoStore.SizeAfterWrite(ctx, did, repo.StorageSize, slice)
+	if err != nil {
+		return nil, fmt.Errorf("measuring repository: %w", err)
+	}
+	repo.UpdatedAt = time.Now()
+	if err := s.save(repo, commit, append(announce, &indigoevents.XRPCStreamEvent{RepoCommit: evt})...); err != nil {
+		return nil, fmt.Errorf("recording commit: %w", err)
+	}
+
+	return repo, nil
+}
+
+// openDeltaSession opens a write session on a repository's head, returning
+// the revision it starts from
+func (s *Service) openDeltaSession(ctx context.Context, did string) (string, *indigocarstore.DeltaSession, error) {
+	rev, err := s.repoStore.GetRepoRev(ctx, did)
+	if err != nil {
+		return "", nil, fmt.Errorf("getting repo revision: %w", err)
+	}
+
+	session, err := s.repoStore.NewDeltaSession(ctx, did, &rev)
+	if err != nil {
+		return "", nil, fmt.Errorf("opening delta session: %w", err)
+	}
+	return rev, session, nil
+}
+
+// recordCountDelta returns how many records ops add to a repository
+func recordCountDelta(ops []*comatproto.SyncSubscribeRepos_RepoOp) int {
+	delta := 0
+	for _, op := range ops {
+		switch op.Action {
+		case WriteActionCreate:
+			delta++
+		case WriteActionDelete:
+			delta--
+		}
+	}
+	return delta
+}
+
+// save stores repo, and commit if it isn't nil, together with the events
+// describing the change, so the change is never recorded without them.
+// Without an event publisher no events are written.
+func (s *Service) save(repo *Repository, commit *Commit, evts ...*indigoevents.XRPCStreamEvent) error {
+	logged, err := s.encodeEvents(evts...)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Save(repo, commit, logged); err != nil {
+		return err
+	}
+	s.appended(logged)
+	return nil
+}
+
+// encodeEvents encodes evts for the event log, or returns nil without an
+// event publisher
+func (s *Service) encodeEvents(evts ...*indigoevents.XRPCStreamEvent) ([]*events.Event, error) {
+	if s.events == nil {
+		return nil, nil
+	}
+	var logged []*events.Event
+	for _, evt := range evts {
+		event, err := events.Encode(evt)
+		if err != nil {
+			return nil, err
+		}
+		logged = append(logged, event)
+	}
+	return logged, nil
+}
+
+// appended hands events that were written to the log to the publisher
+func (s *Service) appended(logged []*events.Event) {
+	if len(logged) > 0 {
+		s.events.Appended(logged)
+	}
+}
+
+// syncEvent returns a #sync event for the commit in commitBlock
+func syncEvent(did string, rev string, commitBlock blocks.Block) (*indigoevents.XRPCStreamEvent, error) {
+	var commitCAR bytes.Buffer
+	if err := atrepo.WriteCAR(&commitCAR, commitBlock.Cid(), []blocks.Block{commitBlock}); err != nil {
+		return nil, fmt.Errorf("encoding commit for #sync event: %w", err)
+	}
+	return &indigoevents.XRPCStreamEvent{RepoSync: &comatproto.SyncSubscribeRepos_Sync{
+		Did:    did,
+		Rev:    rev,
+		Blocks: commitCAR.Bytes(),
+		Time:   syntax.DatetimeNow().String(),
+	}}, nil
+}
+
+// identityEvent returns an #identity event for did
+func identityEvent(did string) *indigoevents.XRPCStreamEvent {
+	return &indigoevents.XRPCStreamEvent{RepoIdentity: &comatproto.SyncSubscribeRepos_Identity{
+		Did:  did,
+		Time: syntax.DatetimeNow().String(),
+	}}
+}
+
+// accountEvent returns an #account event with repo's status. The reason for
+// a status change is for admins and isn't published.
+func accountEvent(repo *Repository) *indigoevents.XRPCStreamEvent {
+	evt := &comatproto.SyncSubscribeRepos_Account{
+		Did:    repo.DID,
+		Active: repo.Active(),
+		Time:   syntax.DatetimeNow().String(),
+	}
+	if !repo.Active() {
+		status := repo.Status
+		evt.Status = &status
+	}
+	return &indigoevents.XRPCStreamEvent{RepoAccount: evt}
+}
+
+// lexLink converts c for use in a firehose event
+func lexLink(c cid.Cid) *lexutil.LexLink {
+	link := lexutil.LexLink(c)
+	return &link
+}
+
+// newRecord builds the Record returned from a single-record write
+func newRecord(result WriteResult, collection string, record interface{}) (*Record, error) {
+	buf := new(bytes.Buffer)
+	if err := record.(cbg.CBORMarshaler).MarshalCBOR(buf); err != nil {
+		return nil, fmt.Errorf("encoding record: %w", err)
+	}
+
+	now := time.Now()
+	return &Record{
+		URI:        result.URI,
+		CID:        result.CID,
+		Collection: collection,
+		RecordKey:  result.RecordKey,
+		Value:      buf.Bytes(),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// recordURI builds the AT-URI for a record
+func recordURI(did, collection, recordKey string) string {
+	return fmt.Sprintf("at://%s/%s/%s", did, collection, recordKey)
+}
+
+// GetCommit retrieves a commit from the repository's history
 func (s *Service) GetCommit(did string, commitCID cid.Cid) (*Commit, error) {
-	return nil, fmt.Errorf("commit operations not yet implemented for carstore")
+	if _, err := s.activeRepo(did); err != nil {
+		return nil, err
+	}
+	commit, err := s.repo.GetCommit(did, commitCID)
+	if err != nil {
+		return nil, fmt.Errorf("getting commit: %w", err)
+	}
+	if commit == nil {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceCommit, commitCID)
+	}
+	return commit, nil
+}
+
+// GetLatestCommit retrieves the most recent commit for a repository
+func (s *Service) GetLatestCommit(did string) (*Commit, error) {
+	if _, err := s.activeRepo(did); err != nil {
+		return nil, err
+	}
+	commit, err := s.repo.GetLatestCommit(did)
+	if err != nil {
+		return nil, fmt.Errorf("getting latest commit: %w", err)
+	}
+	if commit == nil {
+		return nil, fmt.Errorf("repository %s has no commits: %w", did, coreerrors.NotFoundError{Resource: coreerrors.ResourceCommit})
+	}
+	return commit, nil
 }
 
+// ListCommits lists a repository's commits newest first.
+// The cursor is the revision of the last commit in the previous page.
 func (s *Service) ListCommits(did string, limit int, cursor string) ([]*Commit, string, error) {
-	return nil, "", fmt.Errorf("commit operations not yet implemented for carstore")
+	if _, err := s.activeRepo(did); err != nil {
+		return nil, "", err
+	}
+	commits, err := s.repo.ListCommits(did, limit, cursor)
+	if err != nil {
+		return nil, "", fmt.Errorf("listing commits: %w", err)
+	}
+
+	var nextCursor string
+	if limit > 0 && len(commits) == limit {
+		nextCursor = commits[len(commits)-1].Revision
+	}
+
+	return commits, nextCursor, nil
+}
+
+// DiffRepository returns the records created, updated and deleted between
+// two revisions of a repository, for moderation evidence and for tracking
+// down bad writes. An empty fromRev diffs from an empty repository and an
+// empty toRev means the current head. With values set, changes carry the
+// records' CBOR before and after. Inactive repositories can be diffed too.
+//
+// Both revisions' blocks must still be in the carstore; compaction drops
+// blocks older commits no longer share with the head, which is reported as
+// ErrBlockNotFound.
+func (s *Service) DiffRepository(ctx context.Context, did string, fromRev string, toRev string, values bool) (*RepoDiff, error) {
+	repo, err := s.repo.GetByDID(did)
+	if err != nil {
+		return nil, fmt.Errorf("getting repository: %w", err)
+	}
+	if repo == nil {
+		return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+
+	session, err := s.repoStore.ReadOnlySession(did)
+	if err != nil {
+		return nil, fmt.Errorf("opening read session: %w", err)
+	}
+
+	diff := &RepoDiff{DID: did, FromRevision: fromRev, ToRevision: toRev}
+	if diff.ToRevision == "" {
+		if diff.ToRevision, err = s.repoStore.GetRepoRev(ctx, did); err != nil {
+			return nil, fmt.Errorf("getting repo revision: %w", err)
+		}
+		if diff.ToRevision == "" {
+			return nil, fmt.Errorf("repository %s has no commits: %w", did, coreerrors.NotFoundError{Resource: coreerrors.ResourceCommit})
+		}
+	}
+	to, err := s.loadRevision(ctx, session, did, diff.ToRevision)
+	if err != nil {
+		return nil, err
+	}
+	diff.ToCommit, _ = to.GetHeadCID()
+
+	var from *atrepo.Wrapper
+	if fromRev != "" {
+		if from, err = s.loadRevision(ctx, session, did, fromRev); err != nil {
+			return nil, err
+		}
+		diff.FromCommit, _ = from.GetHeadCID()
+	}
+
+	records, err := to.Diff(from)
+	if err != nil {
+		if ipld.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: MST nodes between %s and %s are no longer stored: %v", ErrBlockNotFound, fromRev, diff.ToRevision, err)
+		}
+		return nil, err
+	}
+
+	diff.Changes = make([]RecordChange, len(records))
+	for i, r := range records {
+		change := RecordChange{
+			Action:     WriteActionUpdate,
+			URI:        recordURI(did, r.Collection, r.RecordKey),
+			Collection: r.Collection,
+			RecordKey:  r.RecordKey,
+			PrevCID:    r.OldCID,
+			CID:        r.NewCID,
+		}
+		switch {
+		case !r.OldCID.Defined():
+			change.Action = WriteActionCreate
+		case !r.NewCID.Defined():
+			change.Action = WriteActionDelete
+		}
+		if values {
+			if change.PrevValue, err = readRecordBlock(ctx, session, r.OldCID); err != nil {
+				return nil, err
+			}
+			if change.Value, err = readRecordBlock(ctx, session, r.NewCID); err != nil {
+				return nil, err
+			}
+		}
+		diff.Changes[i] = change
+	}
+
+	return diff, nil
+}
+
+// loadRevision opens a repository as of the commit with revision rev. The
+// head is found in the carstore, so it works for imported repositories whose
+// history isn't in the commits table; older revisions are looked up there.
+func (s *Service) loadRevision(ctx context.Context, bs blockstore.Blockstore, did string, rev string) (*atrepo.Wrapper, error) {
+	headRev, err := s.repoStore.GetRepoRev(ctx, did)
+	if err != nil {
+		return nil, fmt.Errorf("getting repo revision: %w", err)
+	}
+
+	var commitCID cid.Cid
+	if rev == headRev {
+		if commitCID, err = s.repoStore.GetRepoHead(ctx, did); err != nil {
+			return nil, fmt.Errorf("getting repo head: %w", err)
+		}
+	} else {
+		commit, err := s.repo.GetCommitByRevision(did, rev)
+		if err != nil {
+			return nil, fmt.Errorf("getting commit: %w", err)
+		}
+		if commit == nil {
+			return nil, coreerrors.NewNotFoundError(coreerrors.ResourceRevision, rev)
+		}
+		commitCID = commit.CID
+	}
+
+	// Has is scoped to this repository, and tells a compacted commit apart
+	// from a corrupt one
+	has, err := bs.Has(ctx, commitCID)
+	if err != nil {
+		return nil, fmt.Errorf("checking commit %s: %w", commitCID, err)
+	}
+	if !has {
+		return nil, fmt.Errorf("%w: commit %s for revision %s is no longer stored", ErrBlockNotFound, commitCID, rev)
+	}
+
+	return atrepo.LoadWrapper(commitCID, bs)
+}
+
+// readRecordBlock returns a record's CBOR, or nil for an undefined CID
+func readRecordBlock(ctx context.Context, bs blockstore.Blockstore, c cid.Cid) ([]byte, error) {
+	if !c.Defined() {
+		return nil, nil
+	}
+	blk, err := bs.Get(ctx, c)
+	if err != nil {
+		if ipld.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: record %s is no longer stored", ErrBlockNotFound, c)
+		}
+		return nil, fmt.Errorf("getting record %s: %w", c, err)
+	}
+	return blk.RawData(), nil
 }
@@ -1,25 +1,65 @@
 package repository_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"Coves/internal/atproto/carstore"
+	atrepo "Coves/internal/atproto/repo"
+	"Coves/internal/core/compaction"
+	coreerrors "Coves/internal/core/errors"
+	"Coves/internal/core/events"
+	"Coves/internal/core/keys"
 	"Coves/internal/core/repository"
 	"Coves/internal/db/postgres"
+	"Coves/internal/validation"
 
+	indigoevents "github.com/bluesky-social/indigo/events"
+	"github.com/bluesky-social/indigo/models"
 	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	car "github.com/ipld/go-car"
 	_ "github.com/lib/pq"
 	"github.com/pressly/goose/v3"
 	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
-// Mock signing key for testing
-type mockSigningKey struct{}
+// newTestKeyService creates a key service backed by the test database with a random secret
+func newTestKeyService(tb testing.TB, sqlDB *sql.DB) *keys.Service {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		tb.Fatalf("Failed to generate key secret: %v", err)
+	}
+	encryptor, err := keys.NewEncryptor(secret)
+	if err != nil {
+		tb.Fatalf("Failed to create encryptor: %v", err)
+	}
+	keyService, err := keys.NewService(postgres.NewSigningKeyRepo(sqlDB), encryptor, keys.AlgorithmK256)
+	if err != nil {
+		tb.Fatalf("Failed to create key service: %v", err)
+	}
+	return keyService
+}
+
+// newTestService creates a repository service whose key service also
+// verifies imports, so repositories exported by the test can be re-imported
+func newTestService(tb testing.TB, sqlDB *sql.DB, repoRepo repository.RepositoryRepository, repoStore *carstore.RepoStore) *repository.Service {
+	keyService := newTestKeyService(tb, sqlDB)
+	return repository.NewService(repoRepo, repoStore, keyService, keyService)
+}
 
 // Test database connection
 func setupTestDB(t *testing.T) (*sql.DB, *gorm.DB, func()) {
@@ -53,12 +93,15 @@ func setupTestDB(t *testing.T) (*sql.DB, *gorm.DB, func()) {
 	// Cleanup function
 	cleanup := func() {
 		// Clean up test data
+		gormDB.Exec("DELETE FROM signing_keys")
 		gormDB.Exec("DELETE FROM repositories")
 		gormDB.Exec("DELETE FROM commits")
 		gormDB.Exec("DELETE FROM records")
 		gormDB.Exec("DELETE FROM user_maps")
 		gormDB.Exec("DELETE FROM car_shards")
 		gormDB.Exec("DELETE FROM block_refs")
+		gormDB.Exec("DELETE FROM repo_events")
+		gormDB.Exec("DELETE FROM repo_compactions")
 
 		// Close GORM connection
 		if sqlGormDB, err := gormDB.DB(); err == nil {
@@ -92,14 +135,11 @@ func TestRepositoryService_CreateRepository(t *testing.T) {
 
 	// Initialize repository service
 	repoRepo := postgres.NewRepositoryRepo(sqlDB)
-	service := repository.NewService(repoRepo, repoStore)
+	service := newTestService(t, sqlDB, repoRepo, repoStore)
 
 	// Test DID
 	testDID := "did:plc:testuser123"
 
-	// Set signing key
-	service.SetSigningKey(testDID, &mockSigningKey{})
-
 	// Create repository
 	repo, err := service.CreateRepository(testDID)
 	if err != nil {
@@ -156,11 +196,10 @@ func TestRepositoryService_ImportExport(t *testing.T) {
 
 	// Initialize repository service
 	repoRepo := postgres.NewRepositoryRepo(sqlDB)
-	service := repository.NewService(repoRepo, repoStore)
+	service := newTestService(t, sqlDB, repoRepo, repoStore)
 
 	// Create first repository
 	did1 := "did:plc:user1"
-	service.SetSigningKey(did1, &mockSigningKey{})
 	repo1, err := service.CreateRepository(did1)
 	if err != nil {
 		t.Fatalf("Failed to create repository 1: %v", err)
@@ -182,29 +221,50 @@ func TestRepositoryService_ImportExport(t *testing.T) {
 	t.Logf("Block refs count: %d", blockRefCount)
 
 	// Export repository
-	carData, err := service.ExportRepository(did1)
-	if err != nil {
+	var carBuf bytes.Buffer
+	if err := service.ExportRepository(context.Background(), did1, "", &carBuf); err != nil {
 		t.Fatalf("Failed to export repository: %v", err)
 	}
-	// For now, empty repositories return empty CAR data
+	carData := carBuf.Bytes()
 	t.Logf("Exported CAR data size: %d bytes", len(carData))
 
-	// Import to new DID
-	did2 := "did:plc:user2"
-	err = service.ImportRepository(did2, carData)
+	// An empty repository still exports its signed genesis commit
+	cr, err := car.NewCarReader(bytes.NewReader(carData))
 	if err != nil {
-		t.Fatalf("Failed to import repository: %v", err)
+		t.Fatalf("Failed to read exported CAR: %v", err)
+	}
+	if len(cr.Header.Roots) != 1 || cr.Header.Roots[0] != repo1.HeadCID {
+		t.Errorf("Expected CAR rooted at %s, got %v", repo1.HeadCID, cr.Header.Roots)
+	}
+
+	// Imports only fill a deactivated repository created for a migration
+	did2 := "did:plc:user2"
+	if _, err := service.ImportRepository(context.Background(), did2, bytes.NewReader(carData)); !errors.Is(err, coreerrors.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound importing into a missing repository, got %v", err)
+	}
+	if _, err := service.CreateInactiveRepository(did2); err != nil {
+		t.Fatalf("Failed to create inactive repository: %v", err)
+	}
+
+	// The commit is signed for did1, so it can't be imported as another DID
+	_, err = service.ImportRepository(context.Background(), did2, bytes.NewReader(carData))
+	if !errors.Is(err, atrepo.ErrInvalidCAR) {
+		t.Errorf("Expected ErrInvalidCAR importing another DID's repository, got %v", err)
+	}
+
+	// An empty stream isn't a repository
+	if _, err := service.ImportRepository(context.Background(), did2, bytes.NewReader(nil)); !errors.Is(err, atrepo.ErrInvalidCAR) {
+		t.Errorf("Expected ErrInvalidCAR for an empty stream, got %v", err)
 	}
 
-	// Verify imported repository
+	// Nothing was stored by the rejected imports
 	repo2, err := service.GetRepository(did2)
 	if err != nil {
-		t.Fatalf("Failed to get imported repository: %v", err)
+		t.Fatalf("Failed to get repository: %v", err)
 	}
-	if repo2.DID != did2 {
-		t.Errorf("Expected DID %s, got %s", did2, repo2.DID)
+	if repo2.HeadCID.Defined() || repo2.Status != repository.StatusDeactivated {
+		t.Errorf("Expected an empty deactivated repository, got %+v", repo2)
 	}
-	// Note: HeadCID might differ due to new import
 }
 
 func TestRepositoryService_DeleteRepository(t *testing.T) {
@@ -227,11 +287,10 @@ func TestRepositoryService_DeleteRepository(t *testing.T) {
 
 	// Initialize repository service
 	repoRepo := postgres.NewRepositoryRepo(sqlDB)
-	service := repository.NewService(repoRepo, repoStore)
+	service := newTestService(t, sqlDB, repoRepo, repoStore)
 
 	// Create repository
 	testDID := "did:plc:deletetest"
-	service.SetSigningKey(testDID, &mockSigningKey{})
 	_, err = service.CreateRepository(testDID)
 	if err != nil {
 		t.Fatalf("Failed to create repository: %v", err)
@@ -270,222 +329,1753 @@ func TestRepositoryService_CompactRepository(t *testing.T) {
 
 	// Initialize repository service
 	repoRepo := postgres.NewRepositoryRepo(sqlDB)
-	service := repository.NewService(repoRepo, repoStore)
+	service := newTestService(t, sqlDB, repoRepo, repoStore)
 
 	// Create repository
 	testDID := "did:plc:compacttest"
-	service.SetSigningKey(testDID, &mockSigningKey{})
 	_, err = service.CreateRepository(testDID)
 	if err != nil {
 		t.Fatalf("Failed to create repository: %v", err)
 	}
 
 	// Run compaction (should not error even with minimal data)
-	err = service.CompactRepository(testDID)
+	_, err = service.CompactRepository(testDID, false)
 	if err != nil {
 		t.Errorf("Failed to compact repository: %v", err)
 	}
+
+	// Every commit adds a shard until compaction merges them
+	const writes = 30
+	for i := 0; i < writes; i++ {
+		if _, err := service.CreateRecord(repository.CreateRecordInput{
+			DID:        testDID,
+			Collection: "social.coves.test.record",
+			Record:     &testRecord{Text: fmt.Sprintf("post %d", i)},
+		}); err != nil {
+			t.Fatalf("Failed to create record: %v", err)
+		}
+	}
+	before, err := service.ShardCount(testDID)
+	if err != nil {
+		t.Fatalf("Failed to count shards: %v", err)
+	}
+	if before != writes+1 {
+		t.Errorf("Expected %d shards, got %d", writes+1, before)
+	}
+	stats, err := service.CompactRepository(testDID, true)
+	if err != nil {
+		t.Fatalf("Failed to compact repository: %v", err)
+	}
+	after, _ := service.ShardCount(testDID)
+	if after >= before || stats.ShardsDeleted == 0 {
+		t.Errorf("Expected compaction to merge shards, went from %d to %d: %+v", before, after, stats)
+	}
+	records, _, err := service.ListRecords(testDID, "social.coves.test.record", 100, "")
+	if err != nil || len(records) != writes {
+		t.Errorf("Expected %d records after compaction, got %d: %v", writes, len(records), err)
+	}
+
+	// The storage size follows the merged shards
+	_, diskBytes, err := repoStore.DiskUsage(context.Background(), testDID)
+	if err != nil {
+		t.Fatalf("Failed to measure repository: %v", err)
+	}
+	repo, _ := repoRepo.GetByDID(testDID)
+	if repo.StorageSize != diskBytes {
+		t.Errorf("Expected storage size %d after compaction, got %d", diskBytes, repo.StorageSize)
+	}
 }
 
-// Test UserMapping functionality
-func TestUserMapping(t *testing.T) {
-	_, gormDB, cleanup := setupTestDB(t)
+func TestRepositoryService_StorageAccounting(t *testing.T) {
+	sqlDB, gormDB, cleanup := setupTestDB(t)
 	defer cleanup()
 
-	// Create user mapping
-	mapping, err := carstore.NewUserMapping(gormDB)
+	// Create temporary directory for carstore
+	tempDir, err := os.MkdirTemp("", "carstore_test")
 	if err != nil {
-		t.Fatalf("Failed to create user mapping: %v", err)
+		t.Fatalf("Failed to create temp dir: %v", err)
 	}
+	defer os.RemoveAll(tempDir)
 
-	// Test creating new mapping
-	did1 := "did:plc:mapping1"
-	uid1, err := mapping.GetOrCreateUID(context.Background(), did1)
+	// Initialize carstore
+	repoStore, err := carstore.NewRepoStore(gormDB, []string{tempDir})
 	if err != nil {
-		t.Fatalf("Failed to create UID for %s: %v", did1, err)
+		t.Fatalf("Failed to create repo store: %v", err)
 	}
-	if uid1 == 0 {
-		t.Error("Expected non-zero UID")
+
+	// Initialize repository service
+	repoRepo := postgres.NewRepositoryRepo(sqlDB)
+	service := newTestService(t, sqlDB, repoRepo, repoStore)
+
+	testDID := "did:plc:accountingtest"
+	if _, err := service.CreateRepository(testDID); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
 	}
 
-	// Test getting existing mapping
-	uid1Again, err := mapping.GetOrCreateUID(context.Background(), did1)
+	// The stored totals match the MST and the shards on disk after every commit
+	checkAccounting := func(step string, wantRecords int) {
+		t.Helper()
+		repo, err := repoRepo.GetByDID(testDID)
+		if err != nil {
+			t.Fatalf("%s: failed to get repository: %v", step, err)
+		}
+		_, diskBytes, err := repoStore.DiskUsage(context.Background(), testDID)
+		if err != nil {
+			t.Fatalf("%s: failed to measure repository: %v", step, err)
+		}
+		if repo.RecordCount != wantRecords || repo.StorageSize != diskBytes {
+			t.Errorf("%s: expected %d records and %d bytes, got %d and %d", step, wantRecords, diskBytes, repo.RecordCount, repo.StorageSize)
+		}
+	}
+	checkAccounting("create repository", 0)
+
+	_, err = service.ApplyWrites(repository.ApplyWritesInput{DID: testDID, Writes: []repository.WriteOp{
+		{Action: repository.WriteActionCreate, Collection: "social.coves.test.a", RecordKey: "one", Record: &testRecord{Text: "one"}},
+		{Action: repository.WriteActionCreate, Collection: "social.coves.test.a", RecordKey: "two", Record: &testRecord{Text: "two"}},
+		{Action: repository.WriteActionCreate, Collection: "social.coves.test.b", RecordKey: "three", Record: &testRecord{Text: "three"}},
+	}})
 	if err != nil {
-		t.Fatalf("Failed to get UID for %s: %v", did1, err)
+		t.Fatalf("Failed to apply writes: %v", err)
 	}
-	if uid1 != uid1Again {
-		t.Errorf("Expected same UID, got %d and %d", uid1, uid1Again)
+	checkAccounting("create records", 3)
+
+	if _, err := service.UpdateRecord(repository.UpdateRecordInput{
+		DID: testDID, Collection: "social.coves.test.a", RecordKey: "one", Record: &testRecord{Text: "updated"},
+	}); err != nil {
+		t.Fatalf("Failed to update record: %v", err)
 	}
+	checkAccounting("update record", 3)
 
-	// Test reverse lookup
-	didLookup, err := mapping.GetDID(uid1)
+	if err := service.DeleteRecord(repository.DeleteRecordInput{DID: testDID, Collection: "social.coves.test.b", RecordKey: "three"}); err != nil {
+		t.Fatalf("Failed to delete record: %v", err)
+	}
+	checkAccounting("delete record", 2)
+
+	stats, err := service.GetRepositoryStats(testDID)
 	if err != nil {
-		t.Fatalf("Failed to get DID for UID %d: %v", uid1, err)
+		t.Fatalf("Failed to get stats: %v", err)
 	}
-	if didLookup != did1 {
-		t.Errorf("Expected DID %s, got %s", did1, didLookup)
+	shards, _ := service.ShardCount(testDID)
+	if stats.RecordCount != 2 || stats.Collections["social.coves.test.a"] != 2 || len(stats.Collections) != 1 || stats.Shards != shards {
+		t.Errorf("Unexpected stats %+v", stats)
 	}
 
-	// Test second user gets different UID
-	did2 := "did:plc:mapping2"
-	uid2, err := mapping.GetOrCreateUID(context.Background(), did2)
-	if err != nil {
-		t.Fatalf("Failed to create UID for %s: %v", did2, err)
+	// Totals that drifted, say from before they were tracked, are corrected
+	repo, _ := repoRepo.GetByDID(testDID)
+	repo.RecordCount, repo.StorageSize = 0, 0
+	if err := repoRepo.Update(repo); err != nil {
+		t.Fatalf("Failed to update repository: %v", err)
 	}
-	if uid2 == uid1 {
-		t.Error("Expected different UIDs for different DIDs")
+	if _, err := service.GetRepositoryStats(testDID); err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	checkAccounting("stats", 2)
+
+	// Inactive repositories can still be inspected
+	if _, err := service.UpdateRepositoryStatus(testDID, repository.StatusTakendown, "spam"); err != nil {
+		t.Fatalf("Failed to take down repository: %v", err)
+	}
+	if stats, err := service.GetRepositoryStats(testDID); err != nil || stats.Status != repository.StatusTakendown {
+		t.Errorf("Expected stats for the taken down repository, got %+v %v", stats, err)
+	}
+
+	if _, err := service.GetRepositoryStats("did:plc:missing"); !errors.Is(err, repository.ErrRepoNotFound) {
+		t.Errorf("Expected ErrRepoNotFound, got %v", err)
 	}
 }
 
-// Test with mock repository and carstore
-func TestRepositoryService_MockedComponents(t *testing.T) {
-	// Use the existing mock repository from the old test file
-	_ = NewMockRepositoryRepository()
+// testRecord is a minimal CBOR-encodable record for exercising record operations
+type testRecord struct {
+	Text string
+}
 
-	// For unit testing without real carstore, we would need to mock RepoStore
-	// For now, this demonstrates the structure
-	t.Skip("Mocked carstore tests would require creating mock RepoStore interface")
+func (r *testRecord) MarshalCBOR(w io.Writer) error {
+	data, err := cbornode.DumpObject(map[string]interface{}{
+		"$type": "social.coves.test.record",
+		"text":  r.Text,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
 }
 
-// Benchmark repository creation
-func BenchmarkRepositoryCreation(b *testing.B) {
-	sqlDB, gormDB, cleanup := setupTestDB(&testing.T{})
+func TestRepositoryService_RecordOperations(t *testing.T) {
+	sqlDB, gormDB, cleanup := setupTestDB(t)
 	defer cleanup()
 
-	tempDir, _ := os.MkdirTemp("", "carstore_bench")
+	// Create temporary directory for carstore
+	tempDir, err := os.MkdirTemp("", "carstore_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
 	defer os.RemoveAll(tempDir)
 
+	// Initialize carstore
 	carDirs := []string{tempDir}
-	repoStore, _ := carstore.NewRepoStore(gormDB, carDirs)
+	repoStore, err := carstore.NewRepoStore(gormDB, carDirs)
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
+	}
+
+	// Initialize repository service
 	repoRepo := postgres.NewRepositoryRepo(sqlDB)
-	service := repository.NewService(repoRepo, repoStore)
+	service := newTestService(t, sqlDB, repoRepo, repoStore)
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		did := fmt.Sprintf("did:plc:bench%d", i)
-		service.SetSigningKey(did, &mockSigningKey{})
-		_, _ = service.CreateRepository(did)
+	testDID := "did:plc:recordtest"
+	if _, err := service.CreateRepository(testDID); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
 	}
-}
 
-// MockRepositoryRepository is a mock implementation of repository.RepositoryRepository
-type MockRepositoryRepository struct {
-	repositories map[string]*repository.Repository
-	commits      map[string][]*repository.Commit
-	records      map[string]*repository.Record
-}
+	collection := "social.coves.test.record"
 
-func NewMockRepositoryRepository() *MockRepositoryRepository {
-	return &MockRepositoryRepository{
-		repositories: make(map[string]*repository.Repository),
-		commits:      make(map[string][]*repository.Commit),
-		records:      make(map[string]*repository.Record),
+	// Create with a generated key
+	created, err := service.CreateRecord(repository.CreateRecordInput{
+		DID:        testDID,
+		Collection: collection,
+		Record:     &testRecord{Text: "first"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+	if created.RecordKey == "" {
+		t.Error("Expected a generated record key")
 	}
-}
 
-// Repository operations
-func (m *MockRepositoryRepository) Create(repo *repository.Repository) error {
-	m.repositories[repo.DID] = repo
-	return nil
-}
+	// Create with an explicit key
+	if _, err := service.CreateRecord(repository.CreateRecordInput{
+		DID:        testDID,
+		Collection: collection,
+		RecordKey:  "self",
+		Record:     &testRecord{Text: "second"},
+	}); err != nil {
+		t.Fatalf("Failed to create record with key: %v", err)
+	}
 
-func (m *MockRepositoryRepository) GetByDID(did string) (*repository.Repository, error) {
-	repo, exists := m.repositories[did]
-	if !exists {
-		return nil, nil
+	// Each write should advance the repository head
+	repo, err := service.GetRepository(testDID)
+	if err != nil {
+		t.Fatalf("Failed to get repository: %v", err)
+	}
+	if repo.Revision == "" {
+		t.Error("Expected revision to be set after writes")
 	}
-	return repo, nil
-}
 
-func (m *MockRepositoryRepository) Update(repo *repository.Repository) error {
-	if _, exists := m.repositories[repo.DID]; !exists {
-		return nil
+	// Read back
+	fetched, err := service.GetRecord(repository.GetRecordInput{
+		DID:        testDID,
+		Collection: collection,
+		RecordKey:  created.RecordKey,
+	})
+	if err != nil {
+		t.Fatalf("Failed to get record: %v", err)
+	}
+	if !fetched.CID.Equals(created.CID) {
+		t.Errorf("Expected CID %s, got %s", created.CID, fetched.CID)
 	}
-	m.repositories[repo.DID] = repo
-	return nil
-}
 
-func (m *MockRepositoryRepository) Delete(did string) error {
-	delete(m.repositories, did)
-	return nil
-}
+	// Update
+	updated, err := service.UpdateRecord(repository.UpdateRecordInput{
+		DID:        testDID,
+		Collection: collection,
+		RecordKey:  "self",
+		Record:     &testRecord{Text: "edited"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to update record: %v", err)
+	}
 
-// Commit operations
-func (m *MockRepositoryRepository) CreateCommit(commit *repository.Commit) error {
-	m.commits[commit.DID] = append(m.commits[commit.DID], commit)
-	return nil
-}
+	// List with pagination
+	page, cursor, err := service.ListRecords(testDID, collection, 1, "")
+	if err != nil {
+		t.Fatalf("Failed to list records: %v", err)
+	}
+	if len(page) != 1 || cursor == "" {
+		t.Fatalf("Expected 1 record and a cursor, got %d records and cursor %q", len(page), cursor)
+	}
+	rest, _, err := service.ListRecords(testDID, collection, 10, cursor)
+	if err != nil {
+		t.Fatalf("Failed to list records: %v", err)
+	}
+	if len(rest) != 1 {
+		t.Fatalf("Expected 1 remaining record, got %d", len(rest))
+	}
+	if rest[0].RecordKey == "self" && !rest[0].CID.Equals(updated.CID) {
+		t.Errorf("Expected updated CID %s, got %s", updated.CID, rest[0].CID)
+	}
 
-func (m *MockRepositoryRepository) GetCommit(did string, commitCID cid.Cid) (*repository.Commit, error) {
-	commits, exists := m.commits[did]
-	if !exists {
-		return nil, nil
+	// Every write is recorded in the commit history, newest first
+	latest, err := service.GetLatestCommit(testDID)
+	if err != nil {
+		t.Fatalf("Failed to get latest commit: %v", err)
+	}
+	if latest.Revision <= repo.Revision {
+		t.Errorf("Expected the update commit to be newer than revision %s, got %s", repo.Revision, latest.Revision)
+	}
+	commits, commitCursor, err := service.ListCommits(testDID, 2, "")
+	if err != nil {
+		t.Fatalf("Failed to list commits: %v", err)
+	}
+	if len(commits) != 2 || commitCursor == "" {
+		t.Fatalf("Expected 2 commits and a cursor, got %d commits and cursor %q", len(commits), commitCursor)
+	}
+	if commits[0].PrevCID == nil || !commits[0].PrevCID.Equals(commits[1].CID) {
+		t.Error("Expected newest commit to point at the previous commit")
+	}
+	if commits[0].SigningKeyID == "" || len(commits[0].Signature) == 0 {
+		t.Error("Expected commit to record its signature and key ID")
+	}
+	olderCommits, _, err := service.ListCommits(testDID, 10, commitCursor)
+	if err != nil {
+		t.Fatalf("Failed to list commits: %v", err)
+	}
+	if len(olderCommits) != 1 {
+		t.Errorf("Expected 1 older commit, got %d", len(olderCommits))
 	}
 
-	for _, c := range commits {
-		if c.CID.Equals(commitCID) {
-			return c, nil
-		}
+	// Delete
+	if err := service.DeleteRecord(repository.DeleteRecordInput{
+		DID:        testDID,
+		Collection: collection,
+		RecordKey:  created.RecordKey,
+	}); err != nil {
+		t.Fatalf("Failed to delete record: %v", err)
+	}
+	if _, err := service.GetRecord(repository.GetRecordInput{
+		DID:        testDID,
+		Collection: collection,
+		RecordKey:  created.RecordKey,
+	}); err == nil {
+		t.Error("Expected error getting deleted record")
 	}
-	return nil, nil
 }
 
-func (m *MockRepositoryRepository) GetLatestCommit(did string) (*repository.Commit, error) {
-	commits, exists := m.commits[did]
-	if !exists || len(commits) == 0 {
-		return nil, nil
+func TestRepositoryService_SwapPreconditions(t *testing.T) {
+	sqlDB, gormDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// Create temporary directory for carstore
+	tempDir, err := os.MkdirTemp("", "carstore_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
 	}
-	return commits[len(commits)-1], nil
-}
+	defer os.RemoveAll(tempDir)
 
-func (m *MockRepositoryRepository) ListCommits(did string, limit int, offset int) ([]*repository.Commit, error) {
-	commits, exists := m.commits[did]
-	if !exists {
-		return []*repository.Commit{}, nil
+	// Initialize carstore
+	carDirs := []string{tempDir}
+	repoStore, err := carstore.NewRepoStore(gormDB, carDirs)
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
 	}
 
-	start := offset
-	if start >= len(commits) {
-		return []*repository.Commit{}, nil
+	// Initialize repository service
+	repoRepo := postgres.NewRepositoryRepo(sqlDB)
+	service := newTestService(t, sqlDB, repoRepo, repoStore)
+
+	testDID := "did:plc:swaptest"
+	if _, err := service.CreateRepository(testDID); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
 	}
 
-	end := start + limit
-	if end > len(commits) {
-		end = len(commits)
+	collection := "social.coves.test.record"
+	created, err := service.CreateRecord(repository.CreateRecordInput{
+		DID:        testDID,
+		Collection: collection,
+		RecordKey:  "self",
+		Record:     &testRecord{Text: "original"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+	head, err := service.GetLatestCommit(testDID)
+	if err != nil {
+		t.Fatalf("Failed to get latest commit: %v", err)
 	}
 
-	return commits[start:end], nil
-}
+	// A matching swapRecord and swapCommit succeeds
+	updated, err := service.UpdateRecord(repository.UpdateRecordInput{
+		DID:        testDID,
+		Collection: collection,
+		RecordKey:  "self",
+		Record:     &testRecord{Text: "edited"},
+		SwapRecord: &created.CID,
+		SwapCommit: &head.CID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to update record with matching swap: %v", err)
+	}
 
-// Record operations
-func (m *MockRepositoryRepository) CreateRecord(record *repository.Record) error {
-	key := record.URI
-	m.records[key] = record
-	return nil
-}
+	// The stale record CID and stale head are now rejected
+	_, err = service.UpdateRecord(repository.UpdateRecordInput{
+		DID:        testDID,
+		Collection: collection,
+		RecordKey:  "self",
+		Record:     &testRecord{Text: "concurrent edit"},
+		SwapRecord: &created.CID,
+	})
+	if !errors.Is(err, repository.ErrInvalidSwap) {
+		t.Errorf("Expected ErrInvalidSwap for stale swapRecord, got %v", err)
+	}
+	err = service.DeleteRecord(repository.DeleteRecordInput{
+		DID:        testDID,
+		Collection: collection,
+		RecordKey:  "self",
+		SwapCommit: &head.CID,
+	})
+	if !errors.Is(err, repository.ErrInvalidSwap) {
+		t.Errorf("Expected ErrInvalidSwap for stale swapCommit, got %v", err)
+	}
 
-func (m *MockRepositoryRepository) GetRecord(did string, collection string, recordKey string) (*repository.Record, error) {
-	uri := "at://" + did + "/" + collection + "/" + recordKey
-	record, exists := m.records[uri]
-	if !exists {
-		return nil, nil
+	// Rejected writes leave the record untouched
+	fetched, err := service.GetRecord(repository.GetRecordInput{
+		DID:        testDID,
+		Collection: collection,
+		RecordKey:  "self",
+	})
+	if err != nil {
+		t.Fatalf("Failed to get record: %v", err)
+	}
+	if !fetched.CID.Equals(updated.CID) {
+		t.Errorf("Expected CID %s, got %s", updated.CID, fetched.CID)
 	}
-	return record, nil
 }
 
-func (m *MockRepositoryRepository) UpdateRecord(record *repository.Record) error {
-	key := record.URI
-	if _, exists := m.records[key]; !exists {
-		return nil
+// mapRecord is a CBOR-encodable record with arbitrary fields
+type mapRecord map[string]interface{}
+
+func (r mapRecord) MarshalCBOR(w io.Writer) error {
+	data, err := cbornode.DumpObject(map[string]interface{}(r))
+	if err != nil {
+		return err
 	}
-	m.records[key] = record
-	return nil
+	_, err = w.Write(data)
+	return err
 }
 
-func (m *MockRepositoryRepository) DeleteRecord(did string, collection string, recordKey string) error {
-	uri := "at://" + did + "/" + collection + "/" + recordKey
-	delete(m.records, uri)
-	return nil
+func TestRepositoryService_WriteInputErrors(t *testing.T) {
+	testDID := "did:plc:writeinputtest"
+	collection := "social.coves.test.record"
+
+	repoStore, err := carstore.OpenRepoStore(carstore.Config{Backend: carstore.BackendMemory}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
+	}
+	defer repoStore.Close()
+	keyService := newMemoryKeyService(t)
+	service := repository.NewService(NewMockRepositoryRepository(), repoStore, keyService, keyService)
+
+	repo, err := service.CreateRepository(testDID)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	head := repo.HeadCID
+
+	swap := head
+	record := &testRecord{Text: "hello"}
+	tests := []struct {
+		name   string
+		writes []repository.WriteOp
+	}{
+		{"no writes", nil},
+		{"missing collection", []repository.WriteOp{{Action: repository.WriteActionCreate, Record: record}}},
+		{"invalid collection", []repository.WriteOp{{Action: repository.WriteActionCreate, Collection: "not an nsid", Record: record}}},
+		{"invalid record key", []repository.WriteOp{{Action: repository.WriteActionCreate, Collection: collection, RecordKey: "a/b", Record: record}}},
+		{"update without record key", []repository.WriteOp{{Action: repository.WriteActionUpdate, Collection: collection, Record: record}}},
+		{"delete without record key", []repository.WriteOp{{Action: repository.WriteActionDelete, Collection: collection}}},
+		{"swapRecord on create", []repository.WriteOp{{Action: repository.WriteActionCreate, Collection: collection, Record: record, SwapRecord: &swap}}},
+		{"unknown action", []repository.WriteOp{{Action: "upsert", Collection: collection, RecordKey: "a", Record: record}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ApplyWrites(repository.ApplyWritesInput{DID: testDID, Writes: tt.writes})
+			if !errors.Is(err, coreerrors.ErrInvalidInput) {
+				t.Errorf("Expected invalid input, got %v", err)
+			}
+		})
+	}
+
+	// Nothing was committed
+	if repo, err := service.GetRepository(testDID); err != nil || repo.HeadCID != head {
+		t.Errorf("Expected the head to stay at %s, got %v %v", head, repo, err)
+	}
+}
+
+func TestRepositoryService_RecordValidation(t *testing.T) {
+	sqlDB, gormDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// Create temporary directory for carstore
+	tempDir, err := os.MkdirTemp("", "carstore_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Initialize carstore
+	carDirs := []string{tempDir}
+	repoStore, err := carstore.NewRepoStore(gormDB, carDirs)
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
+	}
+
+	// Initialize repository service with the Coves lexicons
+	repoRepo := postgres.NewRepositoryRepo(sqlDB)
+	service := newTestService(t, sqlDB, repoRepo, repoStore)
+	validator, err := validation.NewLexiconValidator("../../atproto/lexicon", false)
+	if err != nil {
+		t.Fatalf("Failed to load lexicons: %v", err)
+	}
+	service.SetRecordValidator(validator)
+
+	testDID := "did:plc:validationtest"
+	if _, err := service.CreateRepository(testDID); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	post := mapRecord{
+		"$type":     "social.coves.post.record",
+		"community": "did:plc:community",
+		"postType":  "text",
+		"createdAt": "2024-01-01T00:00:00Z",
+	}
+	if _, err := service.CreateRecord(repository.CreateRecordInput{
+		DID:        testDID,
+		Collection: "social.coves.post.record",
+		Record:     post,
+	}); err != nil {
+		t.Fatalf("Failed to create valid post: %v", err)
+	}
+
+	// Coves records are validated even when the caller didn't ask
+	invalid := mapRecord{
+		"$type":     "social.coves.post.record",
+		"postType":  "text",
+		"createdAt": "2024-01-01T00:00:00Z",
+	}
+	_, err = service.CreateRecord(repository.CreateRecordInput{
+		DID:        testDID,
+		Collection: "social.coves.post.record",
+		Record:     invalid,
+	})
+	if !errors.Is(err, repository.ErrInvalidRecord) {
+		t.Fatalf("Expected ErrInvalidRecord, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "community") {
+		t.Errorf("Expected the error to name the missing field, got %v", err)
+	}
+
+	// Other collections are only validated on request, and must then have a lexicon
+	other := mapRecord{"$type": "app.example.thing", "text": "hello"}
+	if _, err := service.CreateRecord(repository.CreateRecordInput{
+		DID:        testDID,
+		Collection: "app.example.thing",
+		Record:     other,
+	}); err != nil {
+		t.Errorf("Failed to create unvalidated record: %v", err)
+	}
+	_, err = service.CreateRecord(repository.CreateRecordInput{
+		DID:        testDID,
+		Collection: "app.example.thing",
+		Record:     other,
+		Validate:   true,
+	})
+	if !errors.Is(err, repository.ErrInvalidRecord) {
+		t.Errorf("Expected ErrInvalidRecord for a collection without a lexicon, got %v", err)
+	}
+}
+
+func TestRepositoryService_ExportSince(t *testing.T) {
+	sqlDB, gormDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// Create temporary directory for carstore
+	tempDir, err := os.MkdirTemp("", "carstore_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Initialize carstore
+	carDirs := []string{tempDir}
+	repoStore, err := carstore.NewRepoStore(gormDB, carDirs)
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
+	}
+
+	// Initialize repository service
+	repoRepo := postgres.NewRepositoryRepo(sqlDB)
+	service := newTestService(t, sqlDB, repoRepo, repoStore)
+
+	testDID := "did:plc:sincetest"
+	if _, err := service.CreateRepository(testDID); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	var revs []string
+	for i := 0; i < 3; i++ {
+		if _, err := service.CreateRecord(repository.CreateRecordInput{
+			DID:        testDID,
+			Collection: "social.coves.test.record",
+			Record:     &testRecord{Text: fmt.Sprintf("record %d", i)},
+		}); err != nil {
+			t.Fatalf("Failed to create record: %v", err)
+		}
+		commit, err := service.GetLatestCommit(testDID)
+		if err != nil {
+			t.Fatalf("Failed to get latest commit: %v", err)
+		}
+		revs = append(revs, commit.Revision)
+	}
+
+	export := func(since string) int {
+		var buf bytes.Buffer
+		if err := service.ExportRepository(context.Background(), testDID, since, &buf); err != nil {
+			t.Fatalf("Failed to export since %q: %v", since, err)
+		}
+		return buf.Len()
+	}
+
+	full := export("")
+	afterFirst := export(revs[0])
+	afterLast := export(revs[2])
+
+	if afterFirst == 0 || afterFirst >= full {
+		t.Errorf("Expected a partial export after %s, got %d of %d bytes", revs[0], afterFirst, full)
+	}
+	if afterLast == 0 || afterLast >= afterFirst {
+		t.Errorf("Expected a header-only export at the head, got %d bytes", afterLast)
+	}
+}
+
+func TestRepositoryService_ImportVerified(t *testing.T) {
+	sqlDB, gormDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// Create temporary directory for carstore
+	tempDir, err := os.MkdirTemp("", "carstore_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Initialize carstore
+	carDirs := []string{tempDir}
+	repoStore, err := carstore.NewRepoStore(gormDB, carDirs)
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
+	}
+
+	// The repository is exported by another server, and verified against the
+	// key it was signed with there
+	sourceStore, err := carstore.OpenRepoStore(carstore.Config{Backend: carstore.BackendMemory}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
+	}
+	defer sourceStore.Close()
+	sourceKeys := newMemoryKeyService(t)
+	source := repository.NewService(NewMockRepositoryRepository(), sourceStore, sourceKeys, sourceKeys)
+
+	// Initialize repository service
+	repoRepo := postgres.NewRepositoryRepo(sqlDB)
+	service := repository.NewService(repoRepo, repoStore, newTestKeyService(t, sqlDB), sourceKeys)
+
+	testDID := "did:plc:importtest"
+	if _, err := source.CreateRepository(testDID); err != nil {
+		t.Fatalf("Failed to create source repository: %v", err)
+	}
+	for _, collection := range []string{"social.coves.test.a", "social.coves.test.a", "social.coves.test.b"} {
+		if _, err := source.CreateRecord(repository.CreateRecordInput{
+			DID:        testDID,
+			Collection: collection,
+			Record:     &testRecord{Text: collection},
+		}); err != nil {
+			t.Fatalf("Failed to create record: %v", err)
+		}
+	}
+
+	var carBuf bytes.Buffer
+	if err := source.ExportRepository(context.Background(), testDID, "", &carBuf); err != nil {
+		t.Fatalf("Failed to export repository: %v", err)
+	}
+	carData := carBuf.Bytes()
+	if _, err := service.CreateInactiveRepository(testDID); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	// A corrupted block is rejected
+	tampered := append([]byte{}, carData...)
+	tampered[len(tampered)-3] ^= 0xff
+	if _, err := service.ImportRepository(context.Background(), testDID, bytes.NewReader(tampered)); !errors.Is(err, atrepo.ErrInvalidCAR) {
+		t.Errorf("Expected ErrInvalidCAR for tampered CAR, got %v", err)
+	}
+
+	// The signed export verifies and its records are counted
+	result, err := service.ImportRepository(context.Background(), testDID, bytes.NewReader(carData))
+	if err != nil {
+		t.Fatalf("Failed to import repository: %v", err)
+	}
+	if result.RecordCount != 3 || result.Collections["social.coves.test.a"] != 2 {
+		t.Errorf("Unexpected record counts: %d total, %v", result.RecordCount, result.Collections)
+	}
+	repo, err := service.GetRepository(testDID)
+	if err != nil {
+		t.Fatalf("Failed to get repository: %v", err)
+	}
+	// Storage is measured on disk rather than taken from the CAR
+	_, diskBytes, err := repoStore.DiskUsage(context.Background(), testDID)
+	if err != nil {
+		t.Fatalf("Failed to measure repository: %v", err)
+	}
+	if repo.RecordCount != 3 || repo.StorageSize != diskBytes || diskBytes == 0 {
+		t.Errorf("Expected 3 records and %d bytes on disk, got %d records and %d bytes", diskBytes, repo.RecordCount, repo.StorageSize)
+	}
+	if repo.Status != repository.StatusDeactivated {
+		t.Errorf("Expected the repository to stay deactivated, got %s", repo.Status)
+	}
+}
+
+// Test UserMapping functionality
+func TestRepositoryService_Events(t *testing.T) {
+	sqlDB, gormDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	tempDir, err := os.MkdirTemp("", "carstore_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	repoStore, err := carstore.NewRepoStore(gormDB, []string{tempDir})
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
+	}
+
+	eventService := events.NewService(postgres.NewEventRepo(sqlDB), 0)
+	service := newTestService(t, sqlDB, postgres.NewRepositoryRepo(sqlDB), repoStore)
+	service.SetEventPublisher(eventService)
+
+	// Read from the current end of the log, which may hold other tests' events
+	_, start, err := postgres.NewEventRepo(sqlDB).SeqRange()
+	if err != nil {
+		t.Fatalf("Failed to read event log: %v", err)
+	}
+
+	testDID := "did:plc:eventtest"
+	if _, err := service.CreateRepository(testDID); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	created, err := service.CreateRecord(repository.CreateRecordInput{
+		DID:        testDID,
+		Collection: "social.coves.test.record",
+		Record:     &testRecord{Text: "first"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+	if _, err := service.UpdateRecord(repository.UpdateRecordInput{
+		DID:        testDID,
+		Collection: "social.coves.test.record",
+		RecordKey:  created.RecordKey,
+		Record:     &testRecord{Text: "second"},
+	}); err != nil {
+		t.Fatalf("Failed to update record: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var got []*indigoevents.XRPCStreamEvent
+	done := errors.New("done")
+	err = eventService.Subscribe(ctx, &start, func(evt *indigoevents.XRPCStreamEvent) error {
+		got = append(got, evt)
+		if len(got) == 5 {
+			return done
+		}
+		return nil
+	})
+	if !errors.Is(err, done) {
+		t.Fatalf("Expected 5 events, got %d: %v", len(got), err)
+	}
+
+	// The repository is announced together with its genesis commit
+	if got[0].RepoIdentity == nil || got[0].RepoIdentity.Did != testDID {
+		t.Errorf("Expected #identity event, got %+v", got[0])
+	}
+	if got[1].RepoAccount == nil || !got[1].RepoAccount.Active {
+		t.Errorf("Expected active #account event, got %+v", got[1])
+	}
+	if genesis := got[2].RepoCommit; genesis == nil || len(genesis.Ops) != 0 || genesis.Since != nil {
+		t.Errorf("Expected genesis #commit event, got %+v", got[2])
+	}
+
+	create, update := got[3].RepoCommit, got[4].RepoCommit
+	if create == nil || update == nil {
+		t.Fatalf("Expected two #commit events, got %+v %+v", got[3], got[4])
+	}
+	if create.Since == nil || len(create.Ops) != 1 || create.Ops[0].Action != "create" {
+		t.Errorf("Unexpected create commit %+v", create)
+	}
+	if update.Since == nil || *update.Since != create.Rev || update.PrevData == nil {
+		t.Errorf("Expected update commit to follow %s, got %+v", create.Rev, update)
+	}
+	op := update.Ops[0]
+	if op.Action != "update" || op.Prev == nil || cid.Cid(*op.Prev) != created.CID {
+		t.Errorf("Unexpected update op %+v", op)
+	}
+	if update.Seq <= create.Seq || len(update.Blocks) == 0 {
+		t.Errorf("Unexpected update sequence %d or blocks %d", update.Seq, len(update.Blocks))
+	}
+}
+
+func TestRepositoryService_AccountStatus(t *testing.T) {
+	sqlDB, gormDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	tempDir, err := os.MkdirTemp("", "carstore_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	repoStore, err := carstore.NewRepoStore(gormDB, []string{tempDir})
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
+	}
+
+	eventService := events.NewService(postgres.NewEventRepo(sqlDB), 0)
+	service := newTestService(t, sqlDB, postgres.NewRepositoryRepo(sqlDB), repoStore)
+	service.SetEventPublisher(eventService)
+
+	testDID := "did:plc:statustest"
+	if _, err := service.CreateRepository(testDID); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	created, err := service.CreateRecord(repository.CreateRecordInput{
+		DID:        testDID,
+		Collection: "social.coves.test.record",
+		Record:     &testRecord{Text: "before"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+
+	_, start, err := postgres.NewEventRepo(sqlDB).SeqRange()
+	if err != nil {
+		t.Fatalf("Failed to read event log: %v", err)
+	}
+
+	// A takedown hides the repository from reads and refuses writes
+	repo, err := service.UpdateRepositoryStatus(testDID, repository.StatusTakendown, "spam")
+	if err != nil {
+		t.Fatalf("Failed to take down repository: %v", err)
+	}
+	if repo.Active() || repo.StatusReason != "spam" {
+		t.Errorf("Expected a takedown for spam, got %q %q", repo.Status, repo.StatusReason)
+	}
+
+	var inactive repository.InactiveError
+	if err := service.ExportRepository(context.Background(), testDID, "", io.Discard); !errors.As(err, &inactive) || inactive.Status != repository.StatusTakendown {
+		t.Errorf("Expected export to fail with a takedown, got %v", err)
+	}
+	if _, err := service.GetRecord(repository.GetRecordInput{DID: testDID, Collection: "social.coves.test.record", RecordKey: created.RecordKey}); !errors.As(err, &inactive) {
+		t.Errorf("Expected reads to fail with a takedown, got %v", err)
+	}
+	if _, err := service.CreateRecord(repository.CreateRecordInput{
+		DID:        testDID,
+		Collection: "social.coves.test.record",
+		Record:     &testRecord{Text: "during"},
+	}); !errors.As(err, &inactive) {
+		t.Errorf("Expected writes to fail with a takedown, got %v", err)
+	}
+
+	// Owners can't lift it, but an admin can, and nothing was lost
+	if _, err := service.ActivateRepository(testDID); !errors.As(err, &inactive) {
+		t.Errorf("Expected the owner to be refused, got %v", err)
+	}
+	if _, err := service.UpdateRepositoryStatus(testDID, repository.StatusActive, ""); err != nil {
+		t.Fatalf("Failed to restore repository: %v", err)
+	}
+	if _, err := service.GetRecord(repository.GetRecordInput{DID: testDID, Collection: "social.coves.test.record", RecordKey: created.RecordKey}); err != nil {
+		t.Errorf("Expected the record to survive the takedown: %v", err)
+	}
+
+	// Owners can deactivate and reactivate themselves
+	if _, err := service.DeactivateRepository(testDID); err != nil {
+		t.Fatalf("Failed to deactivate repository: %v", err)
+	}
+	if _, err := service.ActivateRepository(testDID); err != nil {
+		t.Fatalf("Failed to activate repository: %v", err)
+	}
+
+	// Each change was announced with an #account event
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var got []*indigoevents.XRPCStreamEvent
+	done := errors.New("done")
+	err = eventService.Subscribe(ctx, &start, func(evt *indigoevents.XRPCStreamEvent) error {
+		got = append(got, evt)
+		if len(got) == 4 {
+			return done
+		}
+		return nil
+	})
+	if !errors.Is(err, done) {
+		t.Fatalf("Expected 4 events, got %d: %v", len(got), err)
+	}
+	for i, want := range []string{"takendown", "", "deactivated", ""} {
+		acct := got[i].RepoAccount
+		if acct == nil || acct.Did != testDID {
+			t.Fatalf("Expected #account event, got %+v", got[i])
+		}
+		status := ""
+		if acct.Status != nil {
+			status = *acct.Status
+		}
+		if status != want || acct.Active != (want == "") {
+			t.Errorf("Event %d: expected status %q, got active=%v status=%q", i, want, acct.Active, status)
+		}
+	}
+}
+
+func TestRepositoryService_ConcurrentWrites(t *testing.T) {
+	sqlDB, gormDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	tempDir, err := os.MkdirTemp("", "carstore_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	repoStore, err := carstore.NewRepoStore(gormDB, []string{tempDir})
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
+	}
+
+	service := newTestService(t, sqlDB, postgres.NewRepositoryRepo(sqlDB), repoStore)
+	service.SetRepoLocker(postgres.NewAdvisoryLocker(sqlDB))
+
+	dids := []string{"did:plc:burstone", "did:plc:bursttwo"}
+	for _, did := range dids {
+		if _, err := service.CreateRepository(did); err != nil {
+			t.Fatalf("Failed to create repository: %v", err)
+		}
+	}
+
+	// A burst of writes to each repository, all at once
+	const writes = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, writes*len(dids))
+	for _, did := range dids {
+		for i := 0; i < writes; i++ {
+			wg.Add(1)
+			go func(did string, i int) {
+				defer wg.Done()
+				_, err := service.CreateRecord(repository.CreateRecordInput{
+					DID:        did,
+					Collection: "social.coves.test.record",
+					Record:     &testRecord{Text: fmt.Sprintf("post %d", i)},
+				})
+				errs <- err
+			}(did, i)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Concurrent write failed: %v", err)
+		}
+	}
+
+	// Every write landed, each on top of the one before
+	for _, did := range dids {
+		records, _, err := service.ListRecords(did, "social.coves.test.record", 100, "")
+		if err != nil {
+			t.Fatalf("Failed to list records: %v", err)
+		}
+		if len(records) != writes {
+			t.Errorf("%s: expected %d records, got %d", did, writes, len(records))
+		}
+		commits, _, err := service.ListCommits(did, 100, "")
+		if err != nil {
+			t.Fatalf("Failed to list commits: %v", err)
+		}
+		if len(commits) != writes+1 {
+			t.Errorf("%s: expected %d commits, got %d", did, writes+1, len(commits))
+		}
+	}
+}
+
+// Two instances sharing the database and shard directory take turns writing
+// to a repository. Each caches the head it last wrote, so each must notice
+// the other's commits rather than fork the repository from a stale head.
+func TestRepositoryService_TwoInstances(t *testing.T) {
+	sqlDB, gormDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	tempDir, err := os.MkdirTemp("", "carstore_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	openInstance := func() *repository.Service {
+		repoStore, err := carstore.NewRepoStore(gormDB, []string{tempDir})
+		if err != nil {
+			t.Fatalf("Failed to create repo store: %v", err)
+		}
+		service := newTestService(t, sqlDB, postgres.NewRepositoryRepo(sqlDB), repoStore)
+		service.SetRepoLocker(postgres.NewAdvisoryLocker(sqlDB))
+		return service
+	}
+	first, second := openInstance(), openInstance()
+
+	testDID := "did:plc:twoinstances"
+	if _, err := first.CreateRepository(testDID); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	var created []*repository.Record
+	for i, service := range []*repository.Service{first, second, first, second} {
+		record, err := service.CreateRecord(repository.CreateRecordInput{
+			DID:        testDID,
+			Collection: "social.coves.test.record",
+			Record:     &testRecord{Text: fmt.Sprintf("post %d", i)},
+		})
+		if err != nil {
+			t.Fatalf("Write %d failed: %v", i, err)
+		}
+		created = append(created, record)
+	}
+
+	// Every commit builds on the one before, whichever instance wrote it
+	commits, _, err := first.ListCommits(testDID, 100, "")
+	if err != nil {
+		t.Fatalf("Failed to list commits: %v", err)
+	}
+	if len(commits) != len(created)+1 {
+		t.Fatalf("Expected %d commits, got %d", len(created)+1, len(commits))
+	}
+	for i := 0; i < len(commits)-1; i++ {
+		if commits[i].PrevCID == nil || !commits[i].PrevCID.Equals(commits[i+1].CID) {
+			t.Errorf("Commit %s doesn't follow %s", commits[i].Revision, commits[i+1].Revision)
+		}
+	}
+
+	// No write was lost, as seen from a third instance
+	third := openInstance()
+	for _, record := range created {
+		if _, err := third.GetRecord(repository.GetRecordInput{
+			DID:        testDID,
+			Collection: "social.coves.test.record",
+			RecordKey:  record.RecordKey,
+		}); err != nil {
+			t.Errorf("Record %s is missing: %v", record.RecordKey, err)
+		}
+	}
+}
+
+func TestAdvisoryLocker(t *testing.T) {
+	sqlDB, _, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// Two lockers stand in for two instances sharing the database
+	first, second := postgres.NewAdvisoryLocker(sqlDB), postgres.NewAdvisoryLocker(sqlDB)
+	ctx := context.Background()
+
+	unlock, contended, err := first.Lock(ctx, "did:plc:locktest")
+	if err != nil || contended {
+		t.Fatalf("Expected an uncontended lock, got contended=%v err=%v", contended, err)
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	if _, contended, err := second.Lock(timeout, "did:plc:locktest"); err == nil || !contended {
+		t.Errorf("Expected the other instance to wait and time out, got contended=%v err=%v", contended, err)
+	}
+
+	// Other repositories aren't blocked
+	unlockOther, _, err := second.Lock(ctx, "did:plc:otherlock")
+	if err != nil {
+		t.Fatalf("Failed to lock another repository: %v", err)
+	}
+	unlockOther()
+
+	unlock()
+	unlock, _, err = second.Lock(ctx, "did:plc:locktest")
+	if err != nil {
+		t.Fatalf("Failed to take released lock: %v", err)
+	}
+	unlock()
+	// Releasing twice is harmless and doesn't free someone else's lock
+	unlock()
+
+	unlock, _, err = first.Lock(ctx, "did:plc:locktest")
+	if err != nil {
+		t.Fatalf("Failed to take released lock: %v", err)
+	}
+	defer unlock()
+	timeout, cancel = context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	if _, _, err := second.Lock(timeout, "did:plc:locktest"); err == nil {
+		t.Error("Expected the lock to still be held after a repeated unlock")
+	}
+}
+
+func TestCompactionRepo(t *testing.T) {
+	sqlDB, _, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	results := postgres.NewCompactionRepo(sqlDB)
+	did := "did:plc:compactiontest"
+
+	if last, err := results.GetLatestSuccess(did); err != nil || last != nil {
+		t.Fatalf("Expected no compactions yet, got %+v %v", last, err)
+	}
+
+	ok := &compaction.Result{DID: did, Trigger: compaction.TriggerScheduled, StartShards: 60, EndShards: 10, ShardsDeleted: 50, Duration: 1500 * time.Millisecond}
+	if err := results.Create(ok); err != nil {
+		t.Fatalf("Failed to record compaction: %v", err)
+	}
+	if ok.ID == 0 || ok.CreatedAt.IsZero() {
+		t.Errorf("Expected ID and CreatedAt to be set, got %+v", ok)
+	}
+	failed := &compaction.Result{DID: did, Aggressive: true, Trigger: compaction.TriggerAdmin, StartShards: 10, Error: "disk full"}
+	if err := results.Create(failed); err != nil {
+		t.Fatalf("Failed to record compaction: %v", err)
+	}
+
+	// Failures don't count as the latest compaction
+	last, err := results.GetLatestSuccess(did)
+	if err != nil || last == nil || last.ID != ok.ID || last.Duration != ok.Duration {
+		t.Errorf("Expected the successful compaction, got %+v %v", last, err)
+	}
+
+	list, err := results.List(10)
+	if err != nil {
+		t.Fatalf("Failed to list compactions: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != failed.ID || list[0].Error != "disk full" || !list[0].Aggressive {
+		t.Errorf("Expected both compactions newest first, got %+v", list)
+	}
+}
+
+func TestUserMapping(t *testing.T) {
+	_, gormDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// Create user mapping
+	mapping, err := carstore.NewUserMapping(gormDB, 0)
+	if err != nil {
+		t.Fatalf("Failed to create user mapping: %v", err)
+	}
+
+	// Test creating new mapping
+	did1 := "did:plc:mapping1"
+	uid1, err := mapping.GetOrCreateUID(context.Background(), did1)
+	if err != nil {
+		t.Fatalf("Failed to create UID for %s: %v", did1, err)
+	}
+	if uid1 == 0 {
+		t.Error("Expected non-zero UID")
+	}
+
+	// Test getting existing mapping
+	uid1Again, err := mapping.GetOrCreateUID(context.Background(), did1)
+	if err != nil {
+		t.Fatalf("Failed to get UID for %s: %v", did1, err)
+	}
+	if uid1 != uid1Again {
+		t.Errorf("Expected same UID, got %d and %d", uid1, uid1Again)
+	}
+
+	// Test reverse lookup
+	didLookup, err := mapping.GetDID(context.Background(), uid1)
+	if err != nil {
+		t.Fatalf("Failed to get DID for UID %d: %v", uid1, err)
+	}
+	if didLookup != did1 {
+		t.Errorf("Expected DID %s, got %s", did1, didLookup)
+	}
+
+	// Test second user gets different UID
+	did2 := "did:plc:mapping2"
+	uid2, err := mapping.GetOrCreateUID(context.Background(), did2)
+	if err != nil {
+		t.Fatalf("Failed to create UID for %s: %v", did2, err)
+	}
+	if uid2 == uid1 {
+		t.Error("Expected different UIDs for different DIDs")
+	}
+
+	// A deleted mapping isn't reused
+	if err := mapping.DeleteUID(context.Background(), did1); err != nil {
+		t.Fatalf("Failed to delete UID for %s: %v", did1, err)
+	}
+	if _, err := mapping.GetUID(context.Background(), did1); err == nil {
+		t.Error("Expected no UID after deleting the mapping")
+	}
+	uid1New, err := mapping.GetOrCreateUID(context.Background(), did1)
+	if err != nil {
+		t.Fatalf("Failed to recreate UID for %s: %v", did1, err)
+	}
+	if uid1New == uid1 || uid1New == uid2 {
+		t.Errorf("Expected a fresh UID, got %d", uid1New)
+	}
+}
+
+// Instances sharing a database see each other's mappings, agree on UIDs
+// they create at once, and keep working once their cache is full
+func TestUserMapping_SharedDatabase(t *testing.T) {
+	gormDB, err := gorm.Open(sqlite.Open(t.TempDir()+"/meta.sqlite3?_busy_timeout=10000"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	first, err := carstore.NewUserMapping(gormDB, 2)
+	if err != nil {
+		t.Fatalf("Failed to create user mapping: %v", err)
+	}
+	second, err := carstore.NewUserMapping(gormDB, 2)
+	if err != nil {
+		t.Fatalf("Failed to create user mapping: %v", err)
+	}
+	ctx := context.Background()
+
+	// A miss isn't cached, so the second instance sees the UID as soon as
+	// the first creates it
+	if _, err := second.GetUID(ctx, "did:plc:shared"); !errors.Is(err, coreerrors.ErrNotFound) {
+		t.Fatalf("Expected no UID yet, got %v", err)
+	}
+	uid, err := first.GetOrCreateUID(ctx, "did:plc:shared")
+	if err != nil {
+		t.Fatalf("Failed to create UID: %v", err)
+	}
+	if got, err := second.GetUID(ctx, "did:plc:shared"); err != nil || got != uid {
+		t.Errorf("Expected UID %d from the other instance, got %d %v", uid, got, err)
+	}
+	if did, err := second.GetDID(ctx, uid); err != nil || did != "did:plc:shared" {
+		t.Errorf("Expected did:plc:shared for UID %d, got %q %v", uid, did, err)
+	}
+
+	// Both instances creating the same DIDs at once end up with one UID each
+	dids := []string{"did:plc:race1", "did:plc:race2", "did:plc:race3", "did:plc:race4"}
+	uids := make([][2]models.Uid, len(dids))
+	var wg sync.WaitGroup
+	for i, did := range dids {
+		for j, mapping := range []*carstore.UserMapping{first, second} {
+			wg.Add(1)
+			go func(i, j int, did string, mapping *carstore.UserMapping) {
+				defer wg.Done()
+				uid, err := mapping.GetOrCreateUID(ctx, did)
+				if err != nil {
+					t.Errorf("Failed to create UID for %s: %v", did, err)
+				}
+				uids[i][j] = uid
+			}(i, j, did, mapping)
+		}
+	}
+	wg.Wait()
+	seen := map[models.Uid]bool{uid: true}
+	for i, pair := range uids {
+		if pair[0] != pair[1] || pair[0] == 0 || seen[pair[0]] {
+			t.Errorf("Expected one new UID for %s, got %v", dids[i], pair)
+		}
+		seen[pair[0]] = true
+	}
+
+	// The cache holds two DIDs, so the rest are read back from the database
+	for i, did := range dids {
+		if got, err := first.GetUID(ctx, did); err != nil || got != uids[i][0] {
+			t.Errorf("Expected UID %d for %s, got %d %v", uids[i][0], did, got, err)
+		}
+	}
+
+	var rows int64
+	gormDB.Model(&carstore.UserMap{}).Count(&rows)
+	if rows != int64(len(dids)+1) {
+		t.Errorf("Expected %d mappings, got %d", len(dids)+1, rows)
+	}
+
+	// A repository deleted and created again by one instance gets a fresh
+	// UID, which the other picks up when it starts a repository or reloads
+	// a head, however recently it cached the old one
+	if got, err := first.GetUID(ctx, "did:plc:shared"); err != nil || got != uid {
+		t.Fatalf("Expected cached UID %d, got %d %v", uid, got, err)
+	}
+	if err := second.DeleteUID(ctx, "did:plc:shared"); err != nil {
+		t.Fatalf("Failed to delete UID: %v", err)
+	}
+	recreated, err := first.GetOrCreateUID(ctx, "did:plc:shared")
+	if err != nil || recreated == uid {
+		t.Errorf("Expected a fresh UID after the deletion, got %d %v", recreated, err)
+	}
+	if got, err := second.GetUID(ctx, "did:plc:shared"); err != nil || got != recreated {
+		t.Fatalf("Expected UID %d, got %d %v", recreated, got, err)
+	}
+	if err := first.DeleteUID(ctx, "did:plc:shared"); err != nil {
+		t.Fatalf("Failed to delete UID: %v", err)
+	}
+	again, err := first.GetOrCreateUID(ctx, "did:plc:shared")
+	if err != nil {
+		t.Fatalf("Failed to recreate UID: %v", err)
+	}
+	if got, err := second.ReloadUID(ctx, "did:plc:shared"); err != nil || got != again {
+		t.Errorf("Expected reloaded UID %d, got %d %v", again, got, err)
+	}
+}
+
+// newMemoryKeyService creates a key service that keeps keys in memory
+func newMemoryKeyService(tb testing.TB) *keys.Service {
+	encryptor, err := keys.NewEncryptor(make([]byte, 32))
+	if err != nil {
+		tb.Fatalf("Failed to create encryptor: %v", err)
+	}
+	keyService, err := keys.NewService(NewMockKeyRepository(), encryptor, keys.AlgorithmK256)
+	if err != nil {
+		tb.Fatalf("Failed to create key service: %v", err)
+	}
+	return keyService
+}
+
+// failingSaveRepository fails to save while fail is set
+type failingSaveRepository struct {
+	*MockRepositoryRepository
+	fail bool
+}
+
+func (f *failingSaveRepository) Save(repo *repository.Repository, commit *repository.Commit, evts []*events.Event) error {
+	if f.fail {
+		return errors.New("save failed")
+	}
+	return f.MockRepositoryRepository.Save(repo, commit, evts)
+}
+
+// A repository whose genesis commit can't be recorded is removed along with
+// its signing key and user mapping, announces nothing and can be retried
+func TestRepositoryService_CreateRepositoryRollback(t *testing.T) {
+	testDID := "did:plc:rollbacktest"
+
+	repoStore, err := carstore.OpenRepoStore(carstore.Config{Backend: carstore.BackendMemory}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
+	}
+	defer repoStore.Close()
+	keyService := newMemoryKeyService(t)
+	repoRepo := &failingSaveRepository{MockRepositoryRepository: NewMockRepositoryRepository(), fail: true}
+	service := repository.NewService(repoRepo, repoStore, keyService, keyService)
+	recorder := &eventRecorder{}
+	service.SetEventPublisher(recorder)
+
+	if _, err := service.CreateRepository(testDID); err == nil {
+		t.Fatal("Expected creating the repository to fail")
+	}
+	if repo, _ := repoRepo.GetByDID(testDID); repo != nil {
+		t.Error("Expected the repository record to be removed")
+	}
+	if _, err := keyService.GetActiveKey(testDID); err == nil {
+		t.Error("Expected the signing key to be removed")
+	}
+	if has, _ := repoStore.HasRepo(context.Background(), testDID); has {
+		t.Error("Expected the carstore data to be removed")
+	}
+	if len(recorder.events) != 0 {
+		t.Errorf("Expected no events for a failed creation, got %d", len(recorder.events))
+	}
+
+	repoRepo.fail = false
+	repo, err := service.CreateRepository(testDID)
+	if err != nil {
+		t.Fatalf("Failed to create repository on retry: %v", err)
+	}
+	if !repo.HeadCID.Defined() {
+		t.Error("Expected the retried repository to have a head")
+	}
+	if len(recorder.events) != 3 || recorder.events[0].RepoIdentity == nil || recorder.events[2].RepoCommit == nil {
+		t.Errorf("Expected #identity, #account and #commit events, got %d events", len(recorder.events))
+	}
+}
+
+// A stale signing key doesn't leave a repository behind that blocks trying
+// again, and a failed import leaves the migrating repository to import into
+// again
+func TestRepositoryService_CreateCleanup(t *testing.T) {
+	ctx := context.Background()
+	testDID := "did:plc:cleanuptest"
+
+	repoStore, err := carstore.OpenRepoStore(carstore.Config{Backend: carstore.BackendMemory}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
+	}
+	defer repoStore.Close()
+	keyService := newMemoryKeyService(t)
+	repoRepo := &failingSaveRepository{MockRepositoryRepository: NewMockRepositoryRepository()}
+	service := repository.NewService(repoRepo, repoStore, keyService, keyService)
+
+	// A key left over from an earlier attempt makes key creation fail
+	if _, err := keyService.CreateKey(testDID); err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+	if _, err := service.CreateInactiveRepository(testDID); err == nil {
+		t.Fatal("Expected creating the repository to fail")
+	}
+	if repo, _ := repoRepo.GetByDID(testDID); repo != nil {
+		t.Error("Expected the repository record to be removed")
+	}
+	if _, err := service.CreateInactiveRepository(testDID); err != nil {
+		t.Fatalf("Failed to create repository on retry: %v", err)
+	}
+
+	// Import a repository exported by another server, verified against the
+	// key it was signed with there
+	sourceStore, err := carstore.OpenRepoStore(carstore.Config{Backend: carstore.BackendMemory}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
+	}
+	defer sourceStore.Close()
+	sourceKeys := newMemoryKeyService(t)
+	source := repository.NewService(NewMockRepositoryRepository(), sourceStore, sourceKeys, sourceKeys)
+	importDID := "did:plc:cleanupimport"
+	if _, err := source.CreateRepository(importDID); err != nil {
+		t.Fatalf("Failed to create source repository: %v", err)
+	}
+	var exported bytes.Buffer
+	if err := source.ExportRepository(ctx, importDID, "", &exported); err != nil {
+		t.Fatalf("Failed to export repository: %v", err)
+	}
+	service = repository.NewService(repoRepo, repoStore, keyService, sourceKeys)
+	if _, err := service.CreateInactiveRepository(importDID); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	repoRepo.fail = true
+	if _, err := service.ImportRepository(ctx, importDID, bytes.NewReader(exported.Bytes())); err == nil {
+		t.Fatal("Expected the import to fail")
+	}
+	if repo, _ := repoRepo.GetByDID(importDID); repo == nil || repo.HeadCID.Defined() || repo.Status != repository.StatusDeactivated {
+		t.Errorf("Expected the repository to stay deactivated without a head, got %+v", repo)
+	}
+
+	repoRepo.fail = false
+	if _, err := service.ImportRepository(ctx, importDID, bytes.NewReader(exported.Bytes())); err != nil {
+		t.Fatalf("Failed to import on retry: %v", err)
+	}
+}
+
+// Every carstore backend runs repository writes without Postgres: the file
+// backend on a SQLite metadata database, the others on their own
+func TestRepositoryService_Backends(t *testing.T) {
+	backends := map[string]func(t *testing.T, dir string) (*carstore.RepoStore, error){
+		carstore.BackendFile: func(t *testing.T, dir string) (*carstore.RepoStore, error) {
+			gormDB, err := gorm.Open(sqlite.Open(dir+"/meta.sqlite3"), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("Failed to open metadata database: %v", err)
+			}
+			return carstore.OpenRepoStore(carstore.Config{Backend: carstore.BackendFile, Dir: dir + "/cars"}, gormDB)
+		},
+		carstore.BackendSQLite: func(t *testing.T, dir string) (*carstore.RepoStore, error) {
+			return carstore.OpenRepoStore(carstore.Config{Backend: carstore.BackendSQLite, Dir: dir}, nil)
+		},
+		carstore.BackendMemory: func(t *testing.T, dir string) (*carstore.RepoStore, error) {
+			return carstore.OpenRepoStore(carstore.Config{Backend: carstore.BackendMemory}, nil)
+		},
+	}
+
+	for name, open := range backends {
+		t.Run(name, func(t *testing.T) {
+			repoStore, err := open(t, t.TempDir())
+			if err != nil {
+				t.Fatalf("Failed to create repo store: %v", err)
+			}
+			defer repoStore.Close()
+
+			keyService := newMemoryKeyService(t)
+			service := repository.NewService(NewMockRepositoryRepository(), repoStore, keyService, keyService)
+			ctx := context.Background()
+
+			testDID := "did:plc:backendtest"
+			if _, err := service.CreateRepository(testDID); err != nil {
+				t.Fatalf("Failed to create repository: %v", err)
+			}
+
+			var revs []string
+			var created []*repository.Record
+			var head cid.Cid
+			for i := 0; i < 3; i++ {
+				record, err := service.CreateRecord(repository.CreateRecordInput{
+					DID:        testDID,
+					Collection: "social.coves.test.record",
+					Record:     &testRecord{Text: fmt.Sprintf("record %d", i)},
+				})
+				if err != nil {
+					t.Fatalf("Failed to create record: %v", err)
+				}
+				created = append(created, record)
+				head, err = repoStore.GetRepoHead(ctx, testDID)
+				if err != nil {
+					t.Fatalf("Failed to get repo head: %v", err)
+				}
+				rev, err := repoStore.GetRepoRev(ctx, testDID)
+				if err != nil {
+					t.Fatalf("Failed to get repo rev: %v", err)
+				}
+				repo, _ := service.GetRepository(testDID)
+				if head != repo.HeadCID || rev != repo.Revision {
+					t.Errorf("Expected carstore head %s at %s, got %s at %s", repo.HeadCID, repo.Revision, head, rev)
+				}
+				// The stored size follows what the backend measures
+				if _, size, err := repoStore.DiskUsage(ctx, testDID); err != nil || size != repo.StorageSize {
+					t.Errorf("Expected storage size %d, got %d (%v)", size, repo.StorageSize, err)
+				}
+				revs = append(revs, rev)
+			}
+
+			got, err := service.GetRecord(repository.GetRecordInput{DID: testDID, Collection: "social.coves.test.record", RecordKey: created[1].RecordKey})
+			if err != nil || got.CID != created[1].CID {
+				t.Errorf("Expected record %s, got %+v %v", created[1].CID, got, err)
+			}
+
+			export := func(since string) []byte {
+				var buf bytes.Buffer
+				if err := service.ExportRepository(ctx, testDID, since, &buf); err != nil {
+					t.Fatalf("Failed to export since %q: %v", since, err)
+				}
+				cr, err := car.NewCarReader(bytes.NewReader(buf.Bytes()))
+				if err != nil {
+					t.Fatalf("Failed to read CAR exported since %q: %v", since, err)
+				}
+				if len(cr.Header.Roots) != 1 || cr.Header.Roots[0] != head {
+					t.Errorf("Expected CAR exported since %q rooted at the head, got %v", since, cr.Header.Roots)
+				}
+				return buf.Bytes()
+			}
+			full := export("")
+			afterFirst := export(revs[0])
+			afterLast := export(revs[2])
+			if len(afterFirst) >= len(full) || len(afterLast) >= len(afterFirst) {
+				t.Errorf("Expected smaller exports from later revisions, got %d, %d and %d bytes", len(full), len(afterFirst), len(afterLast))
+			}
+
+			// An active repository only changes through commits
+			if _, err := service.ImportRepository(ctx, testDID, bytes.NewReader(full)); !errors.Is(err, coreerrors.ErrInvalidInput) {
+				t.Fatalf("Expected importing into an active repository to be rejected, got %v", err)
+			}
+
+			stats, err := service.GetRepositoryStats(testDID)
+			if err != nil {
+				t.Fatalf("Failed to get repository stats: %v", err)
+			}
+			if stats.RecordCount != 3 || stats.DiskBytes == 0 {
+				t.Errorf("Expected 3 records stored, got %+v", stats)
+			}
+
+			// Deleting drops the UID mapping too, so the DID gets a fresh UID
+			// that no backend has cached a head for
+			uid, _ := repoStore.GetOrCreateUID(ctx, testDID)
+			if err := service.DeleteRepository(testDID); err != nil {
+				t.Fatalf("Failed to delete repository: %v", err)
+			}
+			if has, _ := repoStore.HasRepo(ctx, testDID); has {
+				t.Error("Expected the deleted repository to be gone from the carstore")
+			}
+			newUID, err := repoStore.GetOrCreateUID(ctx, testDID)
+			if err != nil {
+				t.Fatalf("Failed to create UID: %v", err)
+			}
+			if newUID == uid {
+				t.Errorf("Expected a fresh UID, got %d again", uid)
+			}
+			if head, _ := repoStore.GetRepoHead(ctx, testDID); head.Defined() {
+				t.Errorf("Expected no head for the fresh UID, got %s", head)
+			}
+		})
+	}
+}
+
+// Benchmark repository creation
+func BenchmarkRepositoryCreation(b *testing.B) {
+	sqlDB, gormDB, cleanup := setupTestDB(&testing.T{})
+	defer cleanup()
+
+	tempDir, _ := os.MkdirTemp("", "carstore_bench")
+	defer os.RemoveAll(tempDir)
+
+	carDirs := []string{tempDir}
+	repoStore, _ := carstore.NewRepoStore(gormDB, carDirs)
+	repoRepo := postgres.NewRepositoryRepo(sqlDB)
+	service := newTestService(b, sqlDB, repoRepo, repoStore)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		did := fmt.Sprintf("did:plc:bench%d", i)
+		_, _ = service.CreateRepository(did)
+	}
+}
+
+// MockRepositoryRepository is a mock implementation of repository.RepositoryRepository
+type MockRepositoryRepository struct {
+	repositories map[string]*repository.Repository
+	commits      map[string][]*repository.Commit
+	records      map[string]*repository.Record
+	lastSeq      int64
+}
+
+func NewMockRepositoryRepository() *MockRepositoryRepository {
+	return &MockRepositoryRepository{
+		repositories: make(map[string]*repository.Repository),
+		commits:      make(map[string][]*repository.Commit),
+		records:      make(map[string]*repository.Record),
+	}
+}
+
+// Repository operations
+// Repositories are stored and returned as copies, like database rows, so
+// changes a failed write made to them aren't kept
+func (m *MockRepositoryRepository) Create(repo *repository.Repository) error {
+	stored := *repo
+	m.repositories[repo.DID] = &stored
+	return nil
+}
+
+func (m *MockRepositoryRepository) GetByDID(did string) (*repository.Repository, error) {
+	repo, exists := m.repositories[did]
+	if !exists {
+		return nil, nil
+	}
+	found := *repo
+	return &found, nil
+}
+
+func (m *MockRepositoryRepository) List(limit int, afterDID string) ([]*repository.Repository, error) {
+	var repos []*repository.Repository
+	for did, repo := range m.repositories {
+		if did > afterDID {
+			found := *repo
+			repos = append(repos, &found)
+		}
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].DID < repos[j].DID })
+	if len(repos) > limit {
+		repos = repos[:limit]
+	}
+	return repos, nil
+}
+
+func (m *MockRepositoryRepository) Update(repo *repository.Repository) error {
+	if _, exists := m.repositories[repo.DID]; !exists {
+		return nil
+	}
+	stored := *repo
+	m.repositories[repo.DID] = &stored
+	return nil
+}
+
+func (m *MockRepositoryRepository) Delete(did string, evts []*events.Event) error {
+	delete(m.repositories, did)
+	m.sequence(evts)
+	return nil
+}
+
+func (m *MockRepositoryRepository) Save(repo *repository.Repository, commit *repository.Commit, evts []*events.Event) error {
+	if commit != nil {
+		m.CreateCommit(commit)
+	}
+	m.Update(repo)
+	m.sequence(evts)
+	return nil
+}
+
+// sequence numbers evts as the event log would
+func (m *MockRepositoryRepository) sequence(evts []*events.Event) {
+	for _, event := range evts {
+		m.lastSeq++
+		event.Seq = m.lastSeq
+		event.CreatedAt = time.Now()
+	}
+}
+
+// Commit operations
+func (m *MockRepositoryRepository) CreateCommit(commit *repository.Commit) error {
+	m.commits[commit.DID] = append(m.commits[commit.DID], commit)
+	return nil
+}
+
+func (m *MockRepositoryRepository) GetCommit(did string, commitCID cid.Cid) (*repository.Commit, error) {
+	commits, exists := m.commits[did]
+	if !exists {
+		return nil, nil
+	}
+
+	for _, c := range commits {
+		if c.CID.Equals(commitCID) {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockRepositoryRepository) GetLatestCommit(did string) (*repository.Commit, error) {
+	commits, exists := m.commits[did]
+	if !exists || len(commits) == 0 {
+		return nil, nil
+	}
+	return commits[len(commits)-1], nil
+}
+
+func (m *MockRepositoryRepository) GetCommitByRevision(did string, rev string) (*repository.Commit, error) {
+	for _, c := range m.commits[did] {
+		if c.Revision == rev {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockRepositoryRepository) ListCommits(did string, limit int, beforeRev string) ([]*repository.Commit, error) {
+	commits := m.commits[did]
+
+	// Commits are appended in revision order; walk newest first
+	result := []*repository.Commit{}
+	for i := len(commits) - 1; i >= 0 && len(result) < limit; i-- {
+		if beforeRev != "" && commits[i].Revision >= beforeRev {
+			continue
+		}
+		result = append(result, commits[i])
+	}
+
+	return result, nil
+}
+
+// Record operations
+func (m *MockRepositoryRepository) CreateRecord(record *repository.Record) error {
+	key := record.URI
+	m.records[key] = record
+	return nil
+}
+
+func (m *MockRepositoryRepository) GetRecord(did string, collection string, recordKey string) (*repository.Record, error) {
+	uri := "at://" + did + "/" + collection + "/" + recordKey
+	record, exists := m.records[uri]
+	if !exists {
+		return nil, nil
+	}
+	return record, nil
+}
+
+func (m *MockRepositoryRepository) UpdateRecord(record *repository.Record) error {
+	key := record.URI
+	if _, exists := m.records[key]; !exists {
+		return nil
+	}
+	m.records[key] = record
+	return nil
+}
+
+func (m *MockRepositoryRepository) DeleteRecord(did string, collection string, recordKey string) error {
+	uri := "at://" + did + "/" + collection + "/" + recordKey
+	delete(m.records, uri)
+	return nil
 }
 
 func (m *MockRepositoryRepository) ListRecords(did string, collection string, limit int, offset int) ([]*repository.Record, error) {
@@ -511,3 +2101,373 @@ func (m *MockRepositoryRepository) ListRecords(did string, collection string, li
 
 	return records[start:end], nil
 }
+
+// MockKeyRepository keeps signing keys in memory
+type MockKeyRepository struct {
+	keys map[string]*keys.SigningKey
+}
+
+func NewMockKeyRepository() *MockKeyRepository {
+	return &MockKeyRepository{keys: make(map[string]*keys.SigningKey)}
+}
+
+func (m *MockKeyRepository) Create(key *keys.SigningKey) error {
+	m.keys[key.KeyID] = key
+	return nil
+}
+
+func (m *MockKeyRepository) GetActive(did string) (*keys.SigningKey, error) {
+	for _, k := range m.keys {
+		if k.DID == did && k.Active {
+			return k, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockKeyRepository) GetByID(keyID string) (*keys.SigningKey, error) {
+	return m.keys[keyID], nil
+}
+
+func (m *MockKeyRepository) ListByDID(did string) ([]*keys.SigningKey, error) {
+	var result []*keys.SigningKey
+	for _, k := range m.keys {
+		if k.DID == did {
+			result = append(result, k)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockKeyRepository) Rotate(newKey *keys.SigningKey) error {
+	for _, k := range m.keys {
+		if k.DID == newKey.DID {
+			k.Active = false
+		}
+	}
+	m.keys[newKey.KeyID] = newKey
+	return nil
+}
+
+func (m *MockKeyRepository) DeleteByDID(did string) error {
+	for id, k := range m.keys {
+		if k.DID == did {
+			delete(m.keys, id)
+		}
+	}
+	return nil
+}
+
+// eventRecorder is an EventPublisher that keeps the events it is handed
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []*indigoevents.XRPCStreamEvent
+}
+
+func (r *eventRecorder) Appended(logged []*events.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, event := range logged {
+		evt, err := events.Decode(event)
+		if err != nil {
+			panic(err)
+		}
+		r.events = append(r.events, evt)
+	}
+}
+
+// imageRecord is a record that links to a blob
+type imageRecord struct {
+	Image cid.Cid
+}
+
+func (r *imageRecord) MarshalCBOR(w io.Writer) error {
+	data, err := cbornode.DumpObject(map[string]interface{}{
+		"$type": "social.coves.test.image",
+		"image": map[string]interface{}{
+			"$type":    "blob",
+			"ref":      r.Image,
+			"mimeType": "image/png",
+			"size":     int64(4),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// An account migrates in by creating a deactivated repository, importing
+// the CAR exported by its old server and activating it
+func TestRepositoryService_Migration(t *testing.T) {
+	ctx := context.Background()
+	testDID := "did:plc:migrationtest"
+
+	openService := func(resolver repository.KeyResolver) (*repository.Service, *keys.Service) {
+		repoStore, err := carstore.OpenRepoStore(carstore.Config{Backend: carstore.BackendMemory}, nil)
+		if err != nil {
+			t.Fatalf("Failed to create repo store: %v", err)
+		}
+		t.Cleanup(func() { repoStore.Close() })
+		keyService := newMemoryKeyService(t)
+		if resolver == nil {
+			resolver = keyService
+		}
+		return repository.NewService(NewMockRepositoryRepository(), repoStore, keyService, resolver), keyService
+	}
+
+	// The old server holds the repository and its signing key
+	source, sourceKeys := openService(nil)
+	if _, err := source.CreateRepository(testDID); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	var older bytes.Buffer
+	if err := source.ExportRepository(ctx, testDID, "", &older); err != nil {
+		t.Fatalf("Failed to export repository: %v", err)
+	}
+	image, err := cid.Decode("bafkreibme22gw2h7y2h7tg2fhqotaqjucnbc24deqo72b6mkl2egezxhvy")
+	if err != nil {
+		t.Fatalf("Failed to decode CID: %v", err)
+	}
+	created, err := source.CreateRecord(repository.CreateRecordInput{
+		DID:        testDID,
+		Collection: "social.coves.test.image",
+		Record:     &imageRecord{Image: image},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create record: %v", err)
+	}
+	var exported bytes.Buffer
+	if err := source.ExportRepository(ctx, testDID, "", &exported); err != nil {
+		t.Fatalf("Failed to export repository: %v", err)
+	}
+
+	// Until the DID document changes, imports verify against the old key
+	service, _ := openService(sourceKeys)
+	events := &eventRecorder{}
+	service.SetEventPublisher(events)
+
+	// Imports only fill a repository created for the migration
+	if _, err := service.ImportRepository(ctx, testDID, bytes.NewReader(exported.Bytes())); !errors.Is(err, coreerrors.ErrNotFound) {
+		t.Errorf("Expected importing into a missing repository to fail with ErrNotFound, got %v", err)
+	}
+
+	repo, err := service.CreateInactiveRepository(testDID)
+	if err != nil {
+		t.Fatalf("Failed to create inactive repository: %v", err)
+	}
+	if repo.Active() || repo.Status != repository.StatusDeactivated {
+		t.Fatalf("Expected a deactivated repository, got %q", repo.Status)
+	}
+	if refs, err := service.ListBlobRefs(testDID); err != nil || len(refs) != 0 {
+		t.Errorf("Expected no blobs before the import, got %v %v", refs, err)
+	}
+
+	result, err := service.ImportRepository(ctx, testDID, bytes.NewReader(exported.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed to import into the inactive repository: %v", err)
+	}
+	if result.RecordCount != 1 {
+		t.Errorf("Expected 1 imported record, got %d", result.RecordCount)
+	}
+
+	refs, err := service.ListBlobRefs(testDID)
+	if err != nil {
+		t.Fatalf("Failed to list blobs: %v", err)
+	}
+	if len(refs) != 1 || refs[0].CID != image || refs[0].RecordURI != created.URI {
+		t.Errorf("Expected the image blob from %s, got %v", created.URI, refs)
+	}
+
+	// An import can't roll the repository back to an older commit or
+	// replay the current one
+	for name, car := range map[string][]byte{"older": older.Bytes(), "same": exported.Bytes()} {
+		if _, err := service.ImportRepository(ctx, testDID, bytes.NewReader(car)); !errors.Is(err, coreerrors.ErrInvalidInput) {
+			t.Errorf("Expected the %s import to be rejected, got %v", name, err)
+		}
+	}
+
+	// Diffs start from the imported commit
+	diff, err := service.DiffRepository(ctx, testDID, "", result.Revision, false)
+	if err != nil {
+		t.Fatalf("Failed to diff the imported repository: %v", err)
+	}
+	if len(diff.Changes) != 1 || diff.Changes[0].URI != created.URI {
+		t.Errorf("Expected the imported record in the diff, got %+v", diff.Changes)
+	}
+
+	// The repository stays hidden until it is activated
+	var inactive repository.InactiveError
+	if _, err := service.CreateRecord(repository.CreateRecordInput{
+		DID:        testDID,
+		Collection: "social.coves.test.record",
+		Record:     &testRecord{Text: "too early"},
+	}); !errors.As(err, &inactive) {
+		t.Errorf("Expected writes to fail while deactivated, got %v", err)
+	}
+
+	if _, err := service.ActivateRepository(testDID); err != nil {
+		t.Fatalf("Failed to activate repository: %v", err)
+	}
+	// The imported commit starts the history
+	commits, _, err := service.ListCommits(testDID, 10, "")
+	if err != nil {
+		t.Fatalf("Failed to list commits: %v", err)
+	}
+	if len(commits) != 1 || commits[0].CID != result.HeadCID || commits[0].Revision != result.Revision {
+		t.Fatalf("Expected the imported commit %s, got %v", result.HeadCID, commits)
+	}
+	if _, err := service.CreateRecord(repository.CreateRecordInput{
+		DID:        testDID,
+		Collection: "social.coves.test.record",
+		Record:     &testRecord{Text: "migrated"},
+	}); err != nil {
+		t.Fatalf("Failed to write after activation: %v", err)
+	}
+
+	// The import is only announced once the account is active
+	var kinds []string
+	for _, evt := range events.events {
+		switch {
+		case evt.RepoIdentity != nil:
+			kinds = append(kinds, "identity")
+		case evt.RepoAccount != nil:
+			kinds = append(kinds, fmt.Sprintf("account:%v", evt.RepoAccount.Active))
+		case evt.RepoSync != nil:
+			if evt.RepoSync.Rev != result.Revision {
+				t.Errorf("Expected #sync at %s, got %s", result.Revision, evt.RepoSync.Rev)
+			}
+			kinds = append(kinds, "sync")
+		case evt.RepoCommit != nil:
+			kinds = append(kinds, "commit")
+		}
+	}
+	want := []string{"identity", "account:false", "account:true", "sync", "commit"}
+	if fmt.Sprint(kinds) != fmt.Sprint(want) {
+		t.Errorf("Expected events %v, got %v", want, kinds)
+	}
+
+	// An account activated without importing anything gets a genesis commit
+	emptyDID := "did:plc:migrationempty"
+	if _, err := service.CreateInactiveRepository(emptyDID); err != nil {
+		t.Fatalf("Failed to create inactive repository: %v", err)
+	}
+	if err := service.ExportRepository(ctx, emptyDID, "", io.Discard); !errors.As(err, &inactive) {
+		t.Errorf("Expected export to fail while deactivated, got %v", err)
+	}
+	seen := len(events.events)
+	repo, err = service.ActivateRepository(emptyDID)
+	if err != nil {
+		t.Fatalf("Failed to activate repository: %v", err)
+	}
+	if !repo.HeadCID.Defined() || repo.Revision == "" {
+		t.Errorf("Expected a genesis commit, got head %s at %q", repo.HeadCID, repo.Revision)
+	}
+	activated := events.events[seen:]
+	if len(activated) != 2 || activated[0].RepoAccount == nil || !activated[0].RepoAccount.Active || activated[1].RepoCommit == nil {
+		t.Errorf("Expected an #account and a genesis #commit, got %+v", activated)
+	}
+
+	// Deleting the repository is announced with it
+	if err := service.DeleteRepository(emptyDID); err != nil {
+		t.Fatalf("Failed to delete repository: %v", err)
+	}
+	last := events.events[len(events.events)-1].RepoAccount
+	if last == nil || last.Did != emptyDID || last.Active || last.Status == nil || *last.Status != "deleted" {
+		t.Errorf("Expected a deleted #account event, got %+v", last)
+	}
+}
+
+func TestRepositoryService_DiffRepository(t *testing.T) {
+	ctx := context.Background()
+	testDID := "did:plc:difftest"
+	collection := "social.coves.test.record"
+
+	repoStore, err := carstore.OpenRepoStore(carstore.Config{Backend: carstore.BackendMemory}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create repo store: %v", err)
+	}
+	defer repoStore.Close()
+	keyService := newMemoryKeyService(t)
+	service := repository.NewService(NewMockRepositoryRepository(), repoStore, keyService, keyService)
+
+	if _, err := service.CreateRepository(testDID); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	first, err := service.ApplyWrites(repository.ApplyWritesInput{
+		DID: testDID,
+		Writes: []repository.WriteOp{
+			{Action: repository.WriteActionCreate, Collection: collection, RecordKey: "a", Record: &testRecord{Text: "a"}},
+			{Action: repository.WriteActionCreate, Collection: collection, RecordKey: "b", Record: &testRecord{Text: "b"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to write records: %v", err)
+	}
+	second, err := service.ApplyWrites(repository.ApplyWritesInput{
+		DID: testDID,
+		Writes: []repository.WriteOp{
+			{Action: repository.WriteActionUpdate, Collection: collection, RecordKey: "a", Record: &testRecord{Text: "a2"}},
+			{Action: repository.WriteActionDelete, Collection: collection, RecordKey: "b"},
+			{Action: repository.WriteActionCreate, Collection: collection, RecordKey: "c", Record: &testRecord{Text: "c"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to write records: %v", err)
+	}
+
+	diff, err := service.DiffRepository(ctx, testDID, first.Revision, second.Revision, true)
+	if err != nil {
+		t.Fatalf("Failed to diff repository: %v", err)
+	}
+	if diff.FromCommit != first.CommitCID || diff.ToCommit != second.CommitCID {
+		t.Errorf("Expected commits %s..%s, got %s..%s", first.CommitCID, second.CommitCID, diff.FromCommit, diff.ToCommit)
+	}
+	var got []string
+	for _, change := range diff.Changes {
+		var before, after map[string]interface{}
+		if change.PrevValue != nil {
+			if err := atrepo.DecodeRecord(change.PrevValue, &before); err != nil {
+				t.Fatalf("Failed to decode value: %v", err)
+			}
+		}
+		if change.Value != nil {
+			if err := atrepo.DecodeRecord(change.Value, &after); err != nil {
+				t.Fatalf("Failed to decode value: %v", err)
+			}
+		}
+		got = append(got, fmt.Sprintf("%s %s %v->%v", change.Action, change.RecordKey, before["text"], after["text"]))
+	}
+	want := []string{"update a a->a2", "delete b b-><nil>", "create c <nil>->c"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected changes %v, got %v", want, got)
+	}
+	if change := diff.Changes[0]; change.URI != "at://"+testDID+"/"+collection+"/a" || change.CID != second.Results[0].CID {
+		t.Errorf("Unexpected update %+v", change)
+	}
+
+	// From nothing to the head, without values; inactive repositories can be diffed too
+	if _, err := service.DeactivateRepository(testDID); err != nil {
+		t.Fatalf("Failed to deactivate repository: %v", err)
+	}
+	diff, err = service.DiffRepository(ctx, testDID, "", "", false)
+	if err != nil {
+		t.Fatalf("Failed to diff repository: %v", err)
+	}
+	if diff.ToRevision != second.Revision || len(diff.Changes) != 2 {
+		t.Fatalf("Expected the two current records at %s, got %+v", second.Revision, diff)
+	}
+	for _, change := range diff.Changes {
+		if change.Action != repository.WriteActionCreate || change.Value != nil {
+			t.Errorf("Expected a create without values, got %+v", change)
+		}
+	}
+
+	if _, err := service.DiffRepository(ctx, testDID, "2222222222222", "", false); !errors.Is(err, coreerrors.NotFoundError{Resource: coreerrors.ResourceRevision}) {
+		t.Errorf("Expected an unknown revision to be not found, got %v", err)
+	}
+	if _, err := service.DiffRepository(ctx, "did:plc:unknown", "", "", false); !errors.Is(err, repository.ErrRepoNotFound) {
+		t.Errorf("Expected ErrRepoNotFound, got %v", err)
+	}
+}
@@ -1,8 +1,19 @@
 package users
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+
+	coreerrors "Coves/internal/core/errors"
+)
+
+// ErrUserNotFound is returned when no user matches the lookup
+var ErrUserNotFound error = coreerrors.NotFoundError{Resource: coreerrors.ResourceUser}
+
+var (
+	errEmailTaken    = coreerrors.ConflictError{Resource: "email"}
+	errUsernameTaken = coreerrors.ConflictError{Resource: "username"}
 )
 
 type UserService struct {
@@ -25,12 +36,12 @@ func (s *UserService) CreateUser(req CreateUserRequest) (*User, error) {
 	
 	existingUser, _ := s.userRepo.GetByEmail(req.Email)
 	if existingUser != nil {
-		return nil, fmt.Errorf("service: email already exists")
+		return nil, fmt.Errorf("service: %w", errEmailTaken)
 	}
 	
 	existingUser, _ = s.userRepo.GetByUsername(req.Username)
 	if existingUser != nil {
-		return nil, fmt.Errorf("service: username already exists")
+		return nil, fmt.Errorf("service: %w", errUsernameTaken)
 	}
 	
 	user := &User{
@@ -43,13 +54,13 @@ func (s *UserService) CreateUser(req CreateUserRequest) (*User, error) {
 
 func (s *UserService) GetUserByID(id int) (*User, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("service: invalid user ID")
+		return nil, fmt.Errorf("service: %w", coreerrors.NewValidationError("", "invalid user ID"))
 	}
 	
 	user, err := s.userRepo.GetByID(id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return nil, fmt.Errorf("service: user not found")
+		if errors.Is(err, coreerrors.ErrNotFound) {
+			return nil, fmt.Errorf("service: %w", ErrUserNotFound)
 		}
 		return nil, fmt.Errorf("service: %w", err)
 	}
@@ -60,13 +71,13 @@ func (s *UserService) GetUserByID(id int) (*User, error) {
 func (s *UserService) GetUserByEmail(email string) (*User, error) {
 	email = strings.TrimSpace(strings.ToLower(email))
 	if email == "" {
-		return nil, fmt.Errorf("service: email is required")
+		return nil, fmt.Errorf("service: %w", coreerrors.NewValidationError("", "email is required"))
 	}
 	
 	user, err := s.userRepo.GetByEmail(email)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return nil, fmt.Errorf("service: user not found")
+		if errors.Is(err, coreerrors.ErrNotFound) {
+			return nil, fmt.Errorf("service: %w", ErrUserNotFound)
 		}
 		return nil, fmt.Errorf("service: %w", err)
 	}
@@ -77,13 +88,13 @@ func (s *UserService) GetUserByEmail(email string) (*User, error) {
 func (s *UserService) GetUserByUsername(username string) (*User, error) {
 	username = strings.TrimSpace(username)
 	if username == "" {
-		return nil, fmt.Errorf("service: username is required")
+		return nil, fmt.Errorf("service: %w", coreerrors.NewValidationError("", "username is required"))
 	}
 	
 	user, err := s.userRepo.GetByUsername(username)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return nil, fmt.Errorf("service: user not found")
+		if errors.Is(err, coreerrors.ErrNotFound) {
+			return nil, fmt.Errorf("service: %w", ErrUserNotFound)
 		}
 		return nil, fmt.Errorf("service: %w", err)
 	}
@@ -102,7 +113,7 @@ func (s *UserService) UpdateUser(id int, req UpdateUserRequest) (*User, error) {
 		if req.Email != user.Email {
 			existingUser, _ := s.userRepo.GetByEmail(req.Email)
 			if existingUser != nil && existingUser.ID != id {
-				return nil, fmt.Errorf("service: email already exists")
+				return nil, fmt.Errorf("service: %w", errEmailTaken)
 			}
 		}
 		user.Email = req.Email
@@ -113,7 +124,7 @@ func (s *UserService) UpdateUser(id int, req UpdateUserRequest) (*User, error) {
 		if req.Username != user.Username {
 			existingUser, _ := s.userRepo.GetByUsername(req.Username)
 			if existingUser != nil && existingUser.ID != id {
-				return nil, fmt.Errorf("service: username already exists")
+				return nil, fmt.Errorf("service: %w", errUsernameTaken)
 			}
 		}
 		user.Username = req.Username
@@ -124,13 +135,13 @@ func (s *UserService) UpdateUser(id int, req UpdateUserRequest) (*User, error) {
 
 func (s *UserService) DeleteUser(id int) error {
 	if id <= 0 {
-		return fmt.Errorf("service: invalid user ID")
+		return fmt.Errorf("service: %w", coreerrors.NewValidationError("", "invalid user ID"))
 	}
 	
 	err := s.userRepo.Delete(id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return fmt.Errorf("service: user not found")
+		if errors.Is(err, coreerrors.ErrNotFound) {
+			return fmt.Errorf("service: %w", ErrUserNotFound)
 		}
 		return fmt.Errorf("service: %w", err)
 	}
@@ -140,19 +151,19 @@ func (s *UserService) DeleteUser(id int) error {
 
 func (s *UserService) validateCreateRequest(req CreateUserRequest) error {
 	if strings.TrimSpace(req.Email) == "" {
-		return fmt.Errorf("service: email is required")
+		return fmt.Errorf("service: %w", coreerrors.NewValidationError("", "email is required"))
 	}
 	
 	if strings.TrimSpace(req.Username) == "" {
-		return fmt.Errorf("service: username is required")
+		return fmt.Errorf("service: %w", coreerrors.NewValidationError("", "username is required"))
 	}
 	
 	if !strings.Contains(req.Email, "@") {
-		return fmt.Errorf("service: invalid email format")
+		return fmt.Errorf("service: %w", coreerrors.NewValidationError("", "invalid email format"))
 	}
 	
 	if len(req.Username) < 3 {
-		return fmt.Errorf("service: username must be at least 3 characters")
+		return fmt.Errorf("service: %w", coreerrors.NewValidationError("", "username must be at least 3 characters"))
 	}
 	
 	return nil
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	coreerrors "Coves/internal/core/errors"
 	"Coves/internal/core/users"
 )
 
@@ -42,7 +43,7 @@ func (m *mockUserRepository) GetByID(id int) (*users.User, error) {
 	
 	user, exists := m.users[id]
 	if !exists {
-		return nil, fmt.Errorf("repository: user not found")
+		return nil, fmt.Errorf("repository: %w", coreerrors.ErrNotFound)
 	}
 	return user, nil
 }
@@ -57,7 +58,7 @@ func (m *mockUserRepository) GetByEmail(email string) (*users.User, error) {
 			return user, nil
 		}
 	}
-	return nil, fmt.Errorf("repository: user not found")
+	return nil, fmt.Errorf("repository: %w", coreerrors.ErrNotFound)
 }
 
 func (m *mockUserRepository) GetByUsername(username string) (*users.User, error) {
@@ -70,7 +71,7 @@ func (m *mockUserRepository) GetByUsername(username string) (*users.User, error)
 			return user, nil
 		}
 	}
-	return nil, fmt.Errorf("repository: user not found")
+	return nil, fmt.Errorf("repository: %w", coreerrors.ErrNotFound)
 }
 
 func (m *mockUserRepository) Update(user *users.User) (*users.User, error) {
@@ -79,7 +80,7 @@ func (m *mockUserRepository) Update(user *users.User) (*users.User, error) {
 	}
 	
 	if _, exists := m.users[user.ID]; !exists {
-		return nil, fmt.Errorf("repository: user not found")
+		return nil, fmt.Errorf("repository: %w", coreerrors.ErrNotFound)
 	}
 	
 	user.UpdatedAt = time.Now()
@@ -93,7 +94,7 @@ func (m *mockUserRepository) Delete(id int) error {
 	}
 	
 	if _, exists := m.users[id]; !exists {
-		return fmt.Errorf("repository: user not found")
+		return fmt.Errorf("repository: %w", coreerrors.ErrNotFound)
 	}
 	
 	delete(m.users, id)
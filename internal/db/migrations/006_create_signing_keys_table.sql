@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Signing keys used to sign repository commits.
+-- Private keys are stored AES-GCM encrypted; key_id is the did:key of the public key.
+CREATE TABLE signing_keys (
+    key_id VARCHAR(256) PRIMARY KEY,
+    did VARCHAR(256) NOT NULL,
+    algorithm VARCHAR(16) NOT NULL,
+    encrypted_private_key BYTEA NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    rotated_at TIMESTAMP,
+    FOREIGN KEY (did) REFERENCES repositories(did) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_signing_keys_did ON signing_keys(did);
+
+-- At most one active key per repository
+CREATE UNIQUE INDEX idx_signing_keys_active_did ON signing_keys(did) WHERE active;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS signing_keys;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Commit history is paginated by revision, newest first
+CREATE INDEX idx_commits_did_revision ON commits(did, revision DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_commits_did_revision;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Sequenced log of repository events served by com.atproto.sync.subscribeRepos.
+-- payload is the DAG-CBOR message body; its seq field is filled in from the seq column.
+CREATE TABLE repo_events (
+    seq BIGSERIAL PRIMARY KEY,
+    did VARCHAR(256) NOT NULL,
+    event_type VARCHAR(32) NOT NULL,
+    payload BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Old events are pruned by age
+CREATE INDEX idx_repo_events_created_at ON repo_events(created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS repo_events;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Account status of each repository: active, deactivated by the owner, or
+-- taken down or suspended by an admin. Inactive repositories keep their data.
+ALTER TABLE repositories ADD COLUMN status VARCHAR(32) NOT NULL DEFAULT 'active';
+ALTER TABLE repositories ADD COLUMN status_reason TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE repositories DROP COLUMN IF EXISTS status_reason;
+ALTER TABLE repositories DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Results of carstore compactions, scheduled or started by an admin
+CREATE TABLE repo_compactions (
+    id BIGSERIAL PRIMARY KEY,
+    did VARCHAR(256) NOT NULL,
+    aggressive BOOLEAN NOT NULL,
+    trigger VARCHAR(32) NOT NULL,
+    start_shards INTEGER NOT NULL,
+    end_shards INTEGER NOT NULL,
+    shards_deleted INTEGER NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- The scheduler looks up each repository's latest compaction
+CREATE INDEX idx_repo_compactions_did_created_at ON repo_compactions(did, created_at DESC);
+CREATE INDEX idx_repo_compactions_created_at ON repo_compactions(created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS repo_compactions;
+-- +goose StatementEnd
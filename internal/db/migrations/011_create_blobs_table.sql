@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Blobs uploaded for each account; the bytes are kept in the blob store
+CREATE TABLE blobs (
+    did VARCHAR(256) NOT NULL,
+    cid VARCHAR(256) NOT NULL,
+    mime_type VARCHAR(256) NOT NULL,
+    size BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (did, cid)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS blobs;
+-- +goose StatementEnd
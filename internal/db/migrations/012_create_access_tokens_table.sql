@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Account access tokens, stored as SHA-256 hashes of the token
+CREATE TABLE access_tokens (
+    token_hash BYTEA PRIMARY KEY,
+    did VARCHAR(256) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (did) REFERENCES repositories(did) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_access_tokens_did ON access_tokens(did);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS access_tokens;
+-- +goose StatementEnd
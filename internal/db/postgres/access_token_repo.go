@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"Coves/internal/core/auth"
+)
+
+// AccessTokenRepo implements auth.TokenRepository using PostgreSQL
+type AccessTokenRepo struct {
+	db *sql.DB
+}
+
+// NewAccessTokenRepo creates a new PostgreSQL access token repository
+func NewAccessTokenRepo(db *sql.DB) *AccessTokenRepo {
+	return &AccessTokenRepo{db: db}
+}
+
+func (r *AccessTokenRepo) Create(token *auth.AccessToken) error {
+	query := `
+		INSERT INTO access_tokens (token_hash, did, created_at)
+		VALUES ($1, $2, $3)`
+
+	if _, err := r.db.Exec(query, token.Hash, token.DID, token.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create access token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AccessTokenRepo) GetByHash(hash []byte) (*auth.AccessToken, error) {
+	query := `SELECT token_hash, did, created_at FROM access_tokens WHERE token_hash = $1`
+
+	var token auth.AccessToken
+	err := r.db.QueryRow(query, hash).Scan(&token.Hash, &token.DID, &token.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"Coves/internal/core/blobs"
+
+	"github.com/ipfs/go-cid"
+	"github.com/lib/pq"
+)
+
+// BlobRepo implements blobs.BlobRepository using PostgreSQL
+type BlobRepo struct {
+	db *sql.DB
+}
+
+// NewBlobRepo creates a new PostgreSQL blob repository
+func NewBlobRepo(db *sql.DB) *BlobRepo {
+	return &BlobRepo{db: db}
+}
+
+func (r *BlobRepo) Create(blob *blobs.Blob) error {
+	query := `
+		INSERT INTO blobs (did, cid, mime_type, size, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (did, cid) DO NOTHING`
+
+	_, err := r.db.Exec(query, blob.DID, blob.CID.String(), blob.MimeType, blob.Size, blob.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record blob: %w", err)
+	}
+
+	return nil
+}
+
+func (r *BlobRepo) Get(did string, c cid.Cid) (*blobs.Blob, error) {
+	query := `
+		SELECT did, cid, mime_type, size, created_at
+		FROM blobs
+		WHERE did = $1 AND cid = $2`
+
+	blob, err := scanBlob(r.db.QueryRow(query, did, c.String()))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+
+	return blob, nil
+}
+
+func (r *BlobRepo) List(did string, limit int, afterCID string) ([]*blobs.Blob, error) {
+	query := `
+		SELECT did, cid, mime_type, size, created_at
+		FROM blobs
+		WHERE did = $1 AND cid > $2
+		ORDER BY cid
+		LIMIT $3`
+
+	rows, err := r.db.Query(query, did, afterCID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blobs: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*blobs.Blob
+	for rows.Next() {
+		blob, err := scanBlob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan blob: %w", err)
+		}
+		result = append(result, blob)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *BlobRepo) Has(did string, cids []cid.Cid) (map[cid.Cid]bool, error) {
+	have := make(map[cid.Cid]bool)
+	if len(cids) == 0 {
+		return have, nil
+	}
+
+	strs := make([]string, len(cids))
+	for i, c := range cids {
+		strs[i] = c.String()
+	}
+
+	rows, err := r.db.Query(`SELECT cid FROM blobs WHERE did = $1 AND cid = ANY($2)`, did, pq.Array(strs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check blobs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cidStr string
+		if err := rows.Scan(&cidStr); err != nil {
+			return nil, fmt.Errorf("failed to scan blob: %w", err)
+		}
+		c, err := cid.Parse(cidStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse blob CID: %w", err)
+		}
+		have[c] = true
+	}
+
+	return have, rows.Err()
+}
+
+// scanBlob reads a blobs row
+func scanBlob(row interface{ Scan(dest ...any) error }) (*blobs.Blob, error) {
+	var blob blobs.Blob
+	var cidStr string
+	if err := row.Scan(&blob.DID, &cidStr, &blob.MimeType, &blob.Size, &blob.CreatedAt); err != nil {
+		return nil, err
+	}
+	c, err := cid.Parse(cidStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse blob CID: %w", err)
+	}
+	blob.CID = c
+	return &blob, nil
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Coves/internal/core/compaction"
+)
+
+// CompactionRepo implements compaction.ResultRepository using PostgreSQL
+type CompactionRepo struct {
+	db *sql.DB
+}
+
+// NewCompactionRepo creates a new PostgreSQL compaction result repository
+func NewCompactionRepo(db *sql.DB) *CompactionRepo {
+	return &CompactionRepo{db: db}
+}
+
+func (r *CompactionRepo) Create(result *compaction.Result) error {
+	query := `
+		INSERT INTO repo_compactions (did, aggressive, trigger, start_shards, end_shards, shards_deleted, duration_ms, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query,
+		result.DID,
+		result.Aggressive,
+		result.Trigger,
+		result.StartShards,
+		result.EndShards,
+		result.ShardsDeleted,
+		result.Duration.Milliseconds(),
+		result.Error,
+	).Scan(&result.ID, &result.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record compaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CompactionRepo) GetLatestSuccess(did string) (*compaction.Result, error) {
+	query := `
+		SELECT id, did, aggressive, trigger, start_shards, end_shards, shards_deleted, duration_ms, error, created_at
+		FROM repo_compactions
+		WHERE did = $1 AND error = ''
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1`
+
+	result, err := scanCompaction(r.db.QueryRow(query, did))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest compaction: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *CompactionRepo) List(limit int) ([]*compaction.Result, error) {
+	query := `
+		SELECT id, did, aggressive, trigger, start_shards, end_shards, shards_deleted, duration_ms, error, created_at
+		FROM repo_compactions
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compactions: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*compaction.Result
+	for rows.Next() {
+		result, err := scanCompaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan compaction: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+// scanCompaction reads a repo_compactions row
+func scanCompaction(row interface{ Scan(dest ...any) error }) (*compaction.Result, error) {
+	var result compaction.Result
+	var durationMS int64
+	err := row.Scan(
+		&result.ID,
+		&result.DID,
+		&result.Aggressive,
+		&result.Trigger,
+		&result.StartShards,
+		&result.EndShards,
+		&result.ShardsDeleted,
+		&durationMS,
+		&result.Error,
+		&result.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	result.Duration = time.Duration(durationMS) * time.Millisecond
+	return &result, nil
+}
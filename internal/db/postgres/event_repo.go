@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Coves/internal/core/events"
+)
+
+// eventLogLockKey is the advisory lock that orders appends to the event log
+var eventLogLockKey = advisoryLockKey("coves.events")
+
+// EventRepo implements events.EventRepository using PostgreSQL
+type EventRepo struct {
+	db *sql.DB
+}
+
+// NewEventRepo creates a new PostgreSQL event log repository
+func NewEventRepo(db *sql.DB) *EventRepo {
+	return &EventRepo{db: db}
+}
+
+func (r *EventRepo) Append(event *events.Event) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := appendEvents(tx, []*events.Event{event}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit event: %w", err)
+	}
+
+	return nil
+}
+
+// appendEvents inserts events into the log as part of tx, setting their Seq
+// and CreatedAt. It holds the event log lock until tx ends, so appends commit
+// in sequence order and readers never see a sequence number while a lower
+// one is still uncommitted. It should come last in tx to keep the lock brief.
+func appendEvents(tx *sql.Tx, evts []*events.Event) error {
+	if len(evts) == 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1)`, eventLogLockKey); err != nil {
+		return fmt.Errorf("failed to lock event log: %w", err)
+	}
+
+	query := `
+		INSERT INTO repo_events (did, event_type, payload)
+		VALUES ($1, $2, $3)
+		RETURNING seq, created_at`
+
+	for _, event := range evts {
+		err := tx.QueryRow(query, event.DID, event.Type, event.Payload).Scan(&event.Seq, &event.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to append event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *EventRepo) ListAfter(after int64, limit int) ([]*events.Event, error) {
+	query := `
+		SELECT seq, did, event_type, payload, created_at
+		FROM repo_events
+		WHERE seq > $1
+		ORDER BY seq
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*events.Event
+	for rows.Next() {
+		var event events.Event
+		if err := rows.Scan(&event.Seq, &event.DID, &event.Type, &event.Payload, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		result = append(result, &event)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *EventRepo) SeqRange() (int64, int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Wait for appends in progress, whose sequence numbers are already taken
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock_shared($1)`, eventLogLockKey); err != nil {
+		return 0, 0, fmt.Errorf("failed to lock event log: %w", err)
+	}
+
+	var first int64
+	if err := tx.QueryRow(`SELECT COALESCE(MIN(seq), 0) FROM repo_events`).Scan(&first); err != nil {
+		return 0, 0, fmt.Errorf("failed to get first event: %w", err)
+	}
+
+	// Read the sequence itself so the position survives pruning every event
+	var last int64
+	var called bool
+	if err := tx.QueryRow(`SELECT last_value, is_called FROM repo_events_seq_seq`).Scan(&last, &called); err != nil {
+		return 0, 0, fmt.Errorf("failed to get last event: %w", err)
+	}
+	if !called {
+		last = 0
+	}
+
+	return first, last, nil
+}
+
+func (r *EventRepo) DeleteBefore(t time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM repo_events WHERE created_at < $1`, t)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete events: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sync"
+
+	"Coves/internal/core/repository"
+)
+
+// repoLockNamespace is hashed with each DID so our advisory lock keys don't
+// collide with other users of advisory locks in the same database
+const repoLockNamespace = "coves.repo:"
+
+// AdvisoryLocker implements repository.RepoLocker with PostgreSQL session
+// advisory locks, so writes to a repository are serialised across every
+// instance sharing the database. Writers in the same process queue on a
+// local lock first, so only one connection per repository waits in Postgres.
+type AdvisoryLocker struct {
+	db    *sql.DB
+	local *repository.LocalLocker
+}
+
+// NewAdvisoryLocker creates a repository locker backed by db. Each held lock
+// uses a pooled connection until it is released.
+func NewAdvisoryLocker(db *sql.DB) *AdvisoryLocker {
+	return &AdvisoryLocker{db: db, local: repository.NewLocalLocker()}
+}
+
+// Lock takes the advisory lock for did
+func (l *AdvisoryLocker) Lock(ctx context.Context, did string) (func(), bool, error) {
+	unlockLocal, contended, err := l.local.Lock(ctx, did)
+	if err != nil {
+		return nil, contended, err
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		unlockLocal()
+		return nil, contended, fmt.Errorf("failed to get connection for advisory lock: %w", err)
+	}
+
+	key := repoLockKey(did)
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Close()
+		unlockLocal()
+		return nil, contended, fmt.Errorf("failed to try advisory lock: %w", err)
+	}
+	if !acquired {
+		// Another instance is writing to this repository
+		contended = true
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key); err != nil {
+			discardConn(conn)
+			unlockLocal()
+			return nil, contended, fmt.Errorf("failed to take advisory lock: %w", err)
+		}
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { l.unlock(conn, key, did, unlockLocal) })
+	}, contended, nil
+}
+
+// unlock releases the advisory lock held on conn, then the local lock
+func (l *AdvisoryLocker) unlock(conn *sql.Conn, key int64, did string, unlockLocal func()) {
+	var released bool
+	err := conn.QueryRowContext(context.Background(), "SELECT pg_advisory_unlock($1)", key).Scan(&released)
+	if err != nil || !released {
+		// The session may still hold the lock, so it must not go back to the pool
+		log.Printf("failed to release advisory lock for %s (released=%v): %v", did, released, err)
+		discardConn(conn)
+	} else {
+		conn.Close()
+	}
+	unlockLocal()
+}
+
+// repoLockKey returns the advisory lock key for did
+func repoLockKey(did string) int64 {
+	return advisoryLockKey(repoLockNamespace + did)
+}
+
+// advisoryLockKey hashes name into an advisory lock key
+func advisoryLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// discardConn closes conn's underlying connection rather than returning it
+// to the pool, which ends its session and with it any advisory locks it holds
+func discardConn(conn *sql.Conn) {
+	conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	conn.Close()
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	coreerrors "Coves/internal/core/errors"
+	"Coves/internal/core/events"
 	"Coves/internal/core/repository"
 	"github.com/ipfs/go-cid"
 	"github.com/lib/pq"
@@ -24,15 +26,17 @@ func NewRepositoryRepo(db *sql.DB) *RepositoryRepo {
 
 func (r *RepositoryRepo) Create(repo *repository.Repository) error {
 	query := `
-		INSERT INTO repositories (did, head_cid, revision, record_count, storage_size, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+		INSERT INTO repositories (did, head_cid, revision, record_count, storage_size, status, status_reason, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
 	
 	_, err := r.db.Exec(query,
 		repo.DID,
-		repo.HeadCID.String(),
+		headCIDString(repo.HeadCID),
 		repo.Revision,
 		repo.RecordCount,
 		repo.StorageSize,
+		repoStatus(repo.Status),
+		repo.StatusReason,
 		repo.CreatedAt,
 		repo.UpdatedAt,
 	)
@@ -45,7 +49,7 @@ func (r *RepositoryRepo) Create(repo *repository.Repository) error {
 
 func (r *RepositoryRepo) GetByDID(did string) (*repository.Repository, error) {
 	query := `
-		SELECT did, head_cid, revision, record_count, storage_size, created_at, updated_at
+		SELECT did, head_cid, revision, record_count, storage_size, status, status_reason, created_at, updated_at
 		FROM repositories
 		WHERE did = $1`
 	
@@ -58,6 +62,8 @@ func (r *RepositoryRepo) GetByDID(did string) (*repository.Repository, error) {
 		&repo.Revision,
 		&repo.RecordCount,
 		&repo.StorageSize,
+		&repo.Status,
+		&repo.StatusReason,
 		&repo.CreatedAt,
 		&repo.UpdatedAt,
 	)
@@ -68,7 +74,7 @@ func (r *RepositoryRepo) GetByDID(did string) (*repository.Repository, error) {
 		return nil, fmt.Errorf("failed to get repository: %w", err)
 	}
 	
-	repo.HeadCID, err = cid.Parse(headCIDStr)
+	repo.HeadCID, err = parseHeadCID(headCIDStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse head CID: %w", err)
 	}
@@ -76,19 +82,101 @@ func (r *RepositoryRepo) GetByDID(did string) (*repository.Repository, error) {
 	return &repo, nil
 }
 
+func (r *RepositoryRepo) List(limit int, afterDID string) ([]*repository.Repository, error) {
+	query := `
+		SELECT did, head_cid, revision, record_count, storage_size, status, status_reason, created_at, updated_at
+		FROM repositories
+		WHERE did > $2
+		ORDER BY did
+		LIMIT $1`
+	
+	rows, err := r.db.Query(query, limit, afterDID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+	defer rows.Close()
+	
+	var repos []*repository.Repository
+	for rows.Next() {
+		var repo repository.Repository
+		var headCIDStr string
+		
+		err := rows.Scan(
+			&repo.DID,
+			&headCIDStr,
+			&repo.Revision,
+			&repo.RecordCount,
+			&repo.StorageSize,
+			&repo.Status,
+			&repo.StatusReason,
+			&repo.CreatedAt,
+			&repo.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan repository: %w", err)
+		}
+		
+		repo.HeadCID, err = parseHeadCID(headCIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse head CID: %w", err)
+		}
+		
+		repos = append(repos, &repo)
+	}
+	
+	return repos, nil
+}
+
 func (r *RepositoryRepo) Update(repo *repository.Repository) error {
+	return updateRepository(r.db, repo)
+}
+
+func (r *RepositoryRepo) Save(repo *repository.Repository, commit *repository.Commit, evts []*events.Event) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	
+	if commit != nil {
+		if err := createCommit(tx, commit); err != nil {
+			return err
+		}
+	}
+	if err := updateRepository(tx, repo); err != nil {
+		return err
+	}
+	if err := appendEvents(tx, evts); err != nil {
+		return err
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit repository update: %w", err)
+	}
+	
+	return nil
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func updateRepository(db execer, repo *repository.Repository) error {
 	query := `
 		UPDATE repositories
-		SET head_cid = $2, revision = $3, record_count = $4, storage_size = $5, updated_at = $6
+		SET head_cid = $2, revision = $3, record_count = $4, storage_size = $5, status = $6, status_reason = $7, updated_at = $8
 		WHERE did = $1`
 	
-	result, err := r.db.Exec(query,
+	result, err := db.Exec(query,
 		repo.DID,
-		repo.HeadCID.String(),
+		headCIDString(repo.HeadCID),
 		repo.Revision,
 		repo.RecordCount,
 		repo.StorageSize,
-		time.Now(),
+		repoStatus(repo.Status),
+		repo.StatusReason,
+		repo.UpdatedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update repository: %w", err)
@@ -99,16 +187,22 @@ func (r *RepositoryRepo) Update(repo *repository.Repository) error {
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("repository not found: %s", repo.DID)
+		return coreerrors.NewNotFoundError(coreerrors.ResourceRepo, repo.DID)
 	}
 	
 	return nil
 }
 
-func (r *RepositoryRepo) Delete(did string) error {
+func (r *RepositoryRepo) Delete(did string, evts []*events.Event) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	
 	query := `DELETE FROM repositories WHERE did = $1`
 	
-	result, err := r.db.Exec(query, did)
+	result, err := tx.Exec(query, did)
 	if err != nil {
 		return fmt.Errorf("failed to delete repository: %w", err)
 	}
@@ -118,7 +212,14 @@ func (r *RepositoryRepo) Delete(did string) error {
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("repository not found: %s", did)
+		return coreerrors.NewNotFoundError(coreerrors.ResourceRepo, did)
+	}
+	if err := appendEvents(tx, evts); err != nil {
+		return err
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit repository deletion: %w", err)
 	}
 	
 	return nil
@@ -127,6 +228,10 @@ func (r *RepositoryRepo) Delete(did string) error {
 // Commit operations
 
 func (r *RepositoryRepo) CreateCommit(commit *repository.Commit) error {
+	return createCommit(r.db, commit)
+}
+
+func createCommit(db execer, commit *repository.Commit) error {
 	query := `
 		INSERT INTO commits (cid, did, version, prev_cid, data_cid, revision, signature, signing_key_id, created_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
@@ -137,7 +242,7 @@ func (r *RepositoryRepo) CreateCommit(commit *repository.Commit) error {
 		prevCID = &s
 	}
 	
-	_, err := r.db.Exec(query,
+	_, err := db.Exec(query,
 		commit.CID.String(),
 		commit.DID,
 		commit.Version,
@@ -209,7 +314,7 @@ func (r *RepositoryRepo) GetLatestCommit(did string) (*repository.Commit, error)
 		SELECT cid, did, version, prev_cid, data_cid, revision, signature, signing_key_id, created_at
 		FROM commits
 		WHERE did = $1
-		ORDER BY created_at DESC
+		ORDER BY revision DESC
 		LIMIT 1`
 	
 	var commit repository.Commit
@@ -255,15 +360,65 @@ func (r *RepositoryRepo) GetLatestCommit(did string) (*repository.Commit, error)
 	return &commit, nil
 }
 
-func (r *RepositoryRepo) ListCommits(did string, limit int, offset int) ([]*repository.Commit, error) {
+func (r *RepositoryRepo) GetCommitByRevision(did string, rev string) (*repository.Commit, error) {
 	query := `
 		SELECT cid, did, version, prev_cid, data_cid, revision, signature, signing_key_id, created_at
 		FROM commits
-		WHERE did = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3`
+		WHERE did = $1 AND revision = $2
+		LIMIT 1`
+	
+	var commit repository.Commit
+	var cidStr, dataCIDStr string
+	var prevCIDStr sql.NullString
+	
+	err := r.db.QueryRow(query, did, rev).Scan(
+		&cidStr,
+		&commit.DID,
+		&commit.Version,
+		&prevCIDStr,
+		&dataCIDStr,
+		&commit.Revision,
+		&commit.Signature,
+		&commit.SigningKeyID,
+		&commit.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit by revision: %w", err)
+	}
+	
+	commit.CID, err = cid.Parse(cidStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse commit CID: %w", err)
+	}
+	
+	commit.DataCID, err = cid.Parse(dataCIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse data CID: %w", err)
+	}
+	
+	if prevCIDStr.Valid {
+		prevCID, err := cid.Parse(prevCIDStr.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse prev CID: %w", err)
+		}
+		commit.PrevCID = &prevCID
+	}
 	
-	rows, err := r.db.Query(query, did, limit, offset)
+	return &commit, nil
+}
+
+func (r *RepositoryRepo) ListCommits(did string, limit int, beforeRev string) ([]*repository.Commit, error) {
+	query := `
+		SELECT cid, did, version, prev_cid, data_cid, revision, signature, signing_key_id, created_at
+		FROM commits
+		WHERE did = $1 AND ($3 = '' OR revision < $3)
+		ORDER BY revision DESC
+		LIMIT $2`
+	
+	rows, err := r.db.Query(query, did, limit, beforeRev)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list commits: %w", err)
 	}
@@ -332,7 +487,7 @@ func (r *RepositoryRepo) CreateRecord(record *repository.Record) error {
 	)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique_violation
-			return fmt.Errorf("record already exists: %s", record.URI)
+			return coreerrors.NewConflictError(coreerrors.ResourceRecord, "URI", record.URI)
 		}
 		return fmt.Errorf("failed to create record: %w", err)
 	}
@@ -396,7 +551,7 @@ func (r *RepositoryRepo) UpdateRecord(record *repository.Record) error {
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("record not found: %s", record.URI)
+		return coreerrors.NewNotFoundError(coreerrors.ResourceRecord, record.URI)
 	}
 	
 	return nil
@@ -415,7 +570,7 @@ func (r *RepositoryRepo) DeleteRecord(did string, collection string, recordKey s
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("record not found")
+		return coreerrors.NewNotFoundError(coreerrors.ResourceRecord, fmt.Sprintf("at://%s/%s/%s", did, collection, recordKey))
 	}
 	
 	return nil
@@ -463,3 +618,28 @@ func (r *RepositoryRepo) ListRecords(did string, collection string, limit int, o
 	return records, nil
 }
 
+// headCIDString encodes a head CID for storage. A repository only lacks a
+// head while its genesis commit is being written.
+func headCIDString(c cid.Cid) string {
+	if !c.Defined() {
+		return ""
+	}
+	return c.String()
+}
+
+// parseHeadCID decodes a head CID stored by headCIDString
+func parseHeadCID(s string) (cid.Cid, error) {
+	if s == "" {
+		return cid.Undef, nil
+	}
+	return cid.Parse(s)
+}
+
+// repoStatus returns the status to store for a repository, treating an unset
+// status as active
+func repoStatus(status string) string {
+	if status == "" {
+		return repository.StatusActive
+	}
+	return status
+}
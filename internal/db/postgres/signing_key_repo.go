@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Coves/internal/core/keys"
+)
+
+// SigningKeyRepo implements keys.KeyRepository using PostgreSQL
+type SigningKeyRepo struct {
+	db *sql.DB
+}
+
+// NewSigningKeyRepo creates a new PostgreSQL signing key repository
+func NewSigningKeyRepo(db *sql.DB) *SigningKeyRepo {
+	return &SigningKeyRepo{db: db}
+}
+
+const signingKeyColumns = `key_id, did, algorithm, encrypted_private_key, active, created_at, rotated_at`
+
+func (r *SigningKeyRepo) Create(key *keys.SigningKey) error {
+	query := `
+		INSERT INTO signing_keys (key_id, did, algorithm, encrypted_private_key, active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.Exec(query,
+		key.KeyID,
+		key.DID,
+		key.Algorithm,
+		key.EncryptedPrivateKey,
+		key.Active,
+		key.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create signing key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SigningKeyRepo) GetActive(did string) (*keys.SigningKey, error) {
+	query := `SELECT ` + signingKeyColumns + ` FROM signing_keys WHERE did = $1 AND active`
+
+	key, err := scanSigningKey(r.db.QueryRow(query, did))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active signing key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *SigningKeyRepo) GetByID(keyID string) (*keys.SigningKey, error) {
+	query := `SELECT ` + signingKeyColumns + ` FROM signing_keys WHERE key_id = $1`
+
+	key, err := scanSigningKey(r.db.QueryRow(query, keyID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *SigningKeyRepo) ListByDID(did string) ([]*keys.SigningKey, error) {
+	query := `SELECT ` + signingKeyColumns + ` FROM signing_keys WHERE did = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, did)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*keys.SigningKey
+	for rows.Next() {
+		key, err := scanSigningKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan signing key: %w", err)
+		}
+		result = append(result, key)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *SigningKeyRepo) Rotate(newKey *keys.SigningKey) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`UPDATE signing_keys SET active = FALSE, rotated_at = $2 WHERE did = $1 AND active`,
+		newKey.DID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to deactivate signing key: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO signing_keys (key_id, did, algorithm, encrypted_private_key, active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		newKey.KeyID,
+		newKey.DID,
+		newKey.Algorithm,
+		newKey.EncryptedPrivateKey,
+		newKey.Active,
+		newKey.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create signing key: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit key rotation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SigningKeyRepo) DeleteByDID(did string) error {
+	if _, err := r.db.Exec(`DELETE FROM signing_keys WHERE did = $1`, did); err != nil {
+		return fmt.Errorf("failed to delete signing keys: %w", err)
+	}
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSigningKey(row rowScanner) (*keys.SigningKey, error) {
+	var key keys.SigningKey
+	var rotatedAt sql.NullTime
+
+	err := row.Scan(
+		&key.KeyID,
+		&key.DID,
+		&key.Algorithm,
+		&key.EncryptedPrivateKey,
+		&key.Active,
+		&key.CreatedAt,
+		&rotatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if rotatedAt.Valid {
+		key.RotatedAt = &rotatedAt.Time
+	}
+
+	return &key, nil
+}
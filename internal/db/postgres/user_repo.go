@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 
+	coreerrors "Coves/internal/core/errors"
 	"Coves/internal/core/users"
 )
 
@@ -39,7 +40,7 @@ func (r *PostgresUserRepo) GetByID(id int) (*users.User, error) {
 		Scan(&user.ID, &user.Email, &user.Username, &user.CreatedAt, &user.UpdatedAt)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("repository: user not found")
+		return nil, fmt.Errorf("repository: %w", coreerrors.NewNotFoundError(coreerrors.ResourceUser, id))
 	}
 	if err != nil {
 		return nil, fmt.Errorf("repository: failed to get user: %w", err)
@@ -56,7 +57,7 @@ func (r *PostgresUserRepo) GetByEmail(email string) (*users.User, error) {
 		Scan(&user.ID, &user.Email, &user.Username, &user.CreatedAt, &user.UpdatedAt)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("repository: user not found")
+		return nil, fmt.Errorf("repository: %w", coreerrors.NewNotFoundError(coreerrors.ResourceUser, email))
 	}
 	if err != nil {
 		return nil, fmt.Errorf("repository: failed to get user by email: %w", err)
@@ -73,7 +74,7 @@ func (r *PostgresUserRepo) GetByUsername(username string) (*users.User, error) {
 		Scan(&user.ID, &user.Email, &user.Username, &user.CreatedAt, &user.UpdatedAt)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("repository: user not found")
+		return nil, fmt.Errorf("repository: %w", coreerrors.NewNotFoundError(coreerrors.ResourceUser, username))
 	}
 	if err != nil {
 		return nil, fmt.Errorf("repository: failed to get user by username: %w", err)
@@ -93,7 +94,7 @@ func (r *PostgresUserRepo) Update(user *users.User) (*users.User, error) {
 		Scan(&user.ID, &user.Email, &user.Username, &user.CreatedAt, &user.UpdatedAt)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("repository: user not found")
+		return nil, fmt.Errorf("repository: %w", coreerrors.NewNotFoundError(coreerrors.ResourceUser, user.ID))
 	}
 	if err != nil {
 		return nil, fmt.Errorf("repository: failed to update user: %w", err)
@@ -116,7 +117,7 @@ func (r *PostgresUserRepo) Delete(id int) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("repository: user not found")
+		return fmt.Errorf("repository: %w", coreerrors.NewNotFoundError(coreerrors.ResourceUser, id))
 	}
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	atdata "github.com/bluesky-social/indigo/atproto/data"
 	lexicon "github.com/bluesky-social/indigo/atproto/lexicon"
 )
 
@@ -34,39 +35,50 @@ func NewLexiconValidator(schemaPath string, strict bool) (*LexiconValidator, err
 	}, nil
 }
 
-// ValidateRecord validates a record against its schema
+// ValidateRecord validates a record against its schema. The record may be a
+// map, JSON bytes or string, or any value that marshals to JSON; it is first
+// parsed into the atproto data model, so integers, $link, $bytes and blobs are
+// checked as their lexicon types. A failure is returned as a *FieldError
+// naming the field that failed.
 func (v *LexiconValidator) ValidateRecord(recordData interface{}, recordType string) error {
-	// Convert to map if needed
-	var data map[string]interface{}
-	
+	var raw []byte
 	switch rd := recordData.(type) {
-	case map[string]interface{}:
-		data = rd
 	case []byte:
-		if err := json.Unmarshal(rd, &data); err != nil {
-			return fmt.Errorf("failed to parse JSON: %w", err)
-		}
+		raw = rd
 	case string:
-		if err := json.Unmarshal([]byte(rd), &data); err != nil {
-			return fmt.Errorf("failed to parse JSON: %w", err)
-		}
+		raw = []byte(rd)
 	default:
-		// Try to marshal and unmarshal to convert struct to map
 		jsonBytes, err := json.Marshal(recordData)
 		if err != nil {
 			return fmt.Errorf("failed to convert record to JSON: %w", err)
 		}
-		if err := json.Unmarshal(jsonBytes, &data); err != nil {
-			return fmt.Errorf("failed to parse JSON: %w", err)
-		}
+		raw = jsonBytes
+	}
+
+	data, err := atdata.UnmarshalJSON(raw)
+	if err != nil {
+		return &FieldError{Err: fmt.Errorf("record is not valid atproto data: %w", err)}
+	}
+
+	def, err := v.catalog.Resolve(recordType)
+	if err != nil {
+		return &FieldError{Err: fmt.Errorf("no lexicon for %s: %w", recordType, err)}
+	}
+	schema, ok := def.Def.(lexicon.SchemaRecord)
+	if !ok {
+		return &FieldError{Err: fmt.Errorf("lexicon %s is not a record type", recordType)}
 	}
 
 	// Ensure $type field matches recordType
-	if typeField, ok := data["$type"].(string); ok && typeField != recordType {
-		return fmt.Errorf("$type field '%s' does not match expected type '%s'", typeField, recordType)
+	typeField, ok := data["$type"].(string)
+	if !ok {
+		return &FieldError{Path: "$type", Err: fmt.Errorf("required field missing")}
+	}
+	if typeField != recordType {
+		return &FieldError{Path: "$type", Err: fmt.Errorf("'%s' does not match expected type '%s'", typeField, recordType)}
 	}
 
-	return lexicon.ValidateRecord(v.catalog, data, recordType, v.flags)
+	return v.validateObject(recordType, schema.Record, data, "")
 }
 
 // ValidateActorProfile validates an actor profile record
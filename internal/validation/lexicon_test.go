@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -132,4 +133,63 @@ func TestStrictValidation(t *testing.T) {
 	if err := validator.ValidateActorProfile(profile); err == nil {
 		t.Error("Expected strict validation to fail on datetime without timezone")
 	}
-}
\ No newline at end of file
+}
+
+func TestValidateRecordFieldPath(t *testing.T) {
+	validator, err := NewLexiconValidator("../../internal/atproto/lexicon", false)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	post := func(mutate func(map[string]interface{})) map[string]interface{} {
+		p := map[string]interface{}{
+			"$type":     "social.coves.post.record",
+			"community": "did:plc:test123",
+			"postType":  "text",
+			"createdAt": "2024-01-01T00:00:00Z",
+			"facets": []interface{}{
+				map[string]interface{}{
+					"index": map[string]interface{}{"byteStart": 0, "byteEnd": 4},
+					"features": []interface{}{
+						map[string]interface{}{"$type": "social.coves.richtext.facet#bold"},
+					},
+				},
+			},
+		}
+		mutate(p)
+		return p
+	}
+
+	if err := validator.ValidatePost(post(func(map[string]interface{}) {})); err != nil {
+		t.Fatalf("Valid post failed validation: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(map[string]interface{})
+		path   string
+	}{
+		{"missing required field", func(p map[string]interface{}) { delete(p, "community") }, "community"},
+		{"bad enum", func(p map[string]interface{}) { p["postType"] = "invalid" }, "postType"},
+		{"missing $type", func(p map[string]interface{}) { delete(p, "$type") }, "$type"},
+		{"nested ref", func(p map[string]interface{}) {
+			p["facets"].([]interface{})[0].(map[string]interface{})["index"] = map[string]interface{}{"byteStart": -1, "byteEnd": 4}
+		}, "facets[0].index.byteStart"},
+		{"integer as string", func(p map[string]interface{}) {
+			p["facets"].([]interface{})[0].(map[string]interface{})["index"] = map[string]interface{}{"byteStart": "0", "byteEnd": 4}
+		}, "facets[0].index.byteStart"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidatePost(post(tt.mutate))
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("Expected a FieldError, got %v", err)
+			}
+			if fieldErr.Path != tt.path {
+				t.Errorf("Expected failure at %q, got %q (%v)", tt.path, fieldErr.Path, err)
+			}
+		})
+	}
+}
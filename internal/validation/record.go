@@ -0,0 +1,185 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	lexicon "github.com/bluesky-social/indigo/atproto/lexicon"
+)
+
+// FieldError reports the field at which a record failed validation. Path is
+// a dotted field path with array indices, such as "facets[0].index.byteStart",
+// and is empty when the record as a whole is invalid.
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// The walk below mirrors indigo's lexicon.ValidateRecord, which reports the
+// first failure without saying where it occurred. Leaf types are checked with
+// indigo's own validators; objects, arrays, refs and unions are walked here so
+// the failing path can be reported. base is the NSID that relative refs such
+// as "#byteSlice" resolve against.
+
+func (v *LexiconValidator) validateData(base string, def any, d any, path string) error {
+	var err error
+	switch s := def.(type) {
+	case lexicon.SchemaNull:
+		err = s.Validate(d)
+	case lexicon.SchemaBoolean:
+		err = s.Validate(d)
+	case lexicon.SchemaInteger:
+		err = s.Validate(d)
+	case lexicon.SchemaString:
+		err = s.Validate(d, v.flags)
+	case lexicon.SchemaBytes:
+		err = s.Validate(d)
+	case lexicon.SchemaCIDLink:
+		err = s.Validate(d)
+	case lexicon.SchemaBlob:
+		err = s.Validate(d, v.flags)
+	case lexicon.SchemaUnknown:
+		err = s.Validate(d)
+	case lexicon.SchemaToken:
+		err = s.Validate(d)
+	case lexicon.SchemaArray:
+		arr, ok := d.([]any)
+		if !ok {
+			return &FieldError{Path: path, Err: fmt.Errorf("expected an array, got %s", reflect.TypeOf(d))}
+		}
+		return v.validateArray(base, s, arr, path)
+	case lexicon.SchemaObject:
+		obj, ok := d.(map[string]any)
+		if !ok {
+			return &FieldError{Path: path, Err: fmt.Errorf("expected an object, got %s", reflect.TypeOf(d))}
+		}
+		return v.validateObject(base, s, obj, path)
+	case lexicon.SchemaRef:
+		ref := resolveRef(base, s.Ref)
+		next, err := v.catalog.Resolve(ref)
+		if err != nil {
+			return &FieldError{Path: path, Err: err}
+		}
+		return v.validateData(refNSID(ref), next.Def, d, path)
+	case lexicon.SchemaUnion:
+		return v.validateUnion(base, s, d, path)
+	default:
+		err = fmt.Errorf("unhandled schema type: %s", reflect.TypeOf(def))
+	}
+
+	if err != nil {
+		return &FieldError{Path: path, Err: err}
+	}
+	return nil
+}
+
+func (v *LexiconValidator) validateObject(base string, s lexicon.SchemaObject, d map[string]any, path string) error {
+	for _, k := range s.Required {
+		if _, ok := d[k]; !ok {
+			return &FieldError{Path: fieldPath(path, k), Err: fmt.Errorf("required field missing")}
+		}
+	}
+
+	// Check fields in a fixed order so the same record always reports the same path
+	keys := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		val, ok := d[k]
+		if !ok || (val == nil && s.IsNullable(k)) {
+			continue
+		}
+		if err := v.validateData(base, s.Properties[k].Inner, val, fieldPath(path, k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *LexiconValidator) validateArray(base string, s lexicon.SchemaArray, arr []any, path string) error {
+	if (s.MinLength != nil && len(arr) < *s.MinLength) || (s.MaxLength != nil && len(arr) > *s.MaxLength) {
+		return &FieldError{Path: path, Err: fmt.Errorf("array length out of bounds: %d", len(arr))}
+	}
+	for i, val := range arr {
+		if err := v.validateData(base, s.Items.Inner, val, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *LexiconValidator) validateUnion(base string, s lexicon.SchemaUnion, d any, path string) error {
+	obj, ok := d.(map[string]any)
+	if !ok {
+		return &FieldError{Path: path, Err: fmt.Errorf("union data is not an object")}
+	}
+	t, ok := obj["$type"].(string)
+	if !ok {
+		return &FieldError{Path: fieldPath(path, "$type"), Err: fmt.Errorf("union data must have a string $type")}
+	}
+
+	for _, r := range s.Refs {
+		ref := resolveRef(base, r)
+		if ref != t && ref != t+"#main" && ref+"#main" != t {
+			continue
+		}
+		def, err := v.catalog.Resolve(ref)
+		if err != nil {
+			return &FieldError{Path: path, Err: err}
+		}
+		return v.validateData(refNSID(ref), def.Def, d, path)
+	}
+	if s.Closed != nil && *s.Closed {
+		return &FieldError{Path: fieldPath(path, "$type"), Err: fmt.Errorf("%s is not a variant of the closed union", t)}
+	}
+
+	// Open unions may hold types we don't know. Those are only an error in
+	// strict mode; known types are still checked.
+	def, err := v.catalog.Resolve(t)
+	if err != nil {
+		if v.flags&lexicon.StrictRecursiveValidation != 0 {
+			return &FieldError{Path: fieldPath(path, "$type"), Err: fmt.Errorf("could not strictly validate open union variant %s", t)}
+		}
+		return nil
+	}
+	return v.validateData(refNSID(t), def.Def, d, path)
+}
+
+// resolveRef expands a ref relative to base, so "#byteSlice" in
+// social.coves.richtext.facet becomes "social.coves.richtext.facet#byteSlice"
+func resolveRef(base, ref string) string {
+	if strings.HasPrefix(ref, "#") {
+		return base + ref
+	}
+	return ref
+}
+
+// refNSID returns the NSID part of a ref
+func refNSID(ref string) string {
+	nsid, _, _ := strings.Cut(ref, "#")
+	return nsid
+}
+
+// fieldPath appends a field name to a path
+func fieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
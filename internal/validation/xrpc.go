@@ -0,0 +1,156 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	atdata "github.com/bluesky-social/indigo/atproto/data"
+	lexicon "github.com/bluesky-social/indigo/atproto/lexicon"
+)
+
+// Method types, as named by the lexicon "type" of a method's main def
+const (
+	MethodQuery     = "query"
+	MethodProcedure = "procedure"
+)
+
+// Method is an XRPC method declared in a lexicon
+type Method struct {
+	NSID       string
+	Type       string // MethodQuery or MethodProcedure
+	Parameters lexicon.SchemaParams
+	Input      *lexicon.SchemaBody // nil for queries and procedures without input
+	Output     *lexicon.SchemaBody // nil when the method has no output
+}
+
+// Method looks up the query or procedure declared by nsid
+func (v *LexiconValidator) Method(nsid string) (*Method, error) {
+	def, err := v.catalog.Resolve(nsid)
+	if err != nil {
+		return nil, err
+	}
+	switch s := def.Def.(type) {
+	case lexicon.SchemaQuery:
+		return &Method{NSID: nsid, Type: MethodQuery, Parameters: s.Parameters, Output: s.Output}, nil
+	case lexicon.SchemaProcedure:
+		return &Method{NSID: nsid, Type: MethodProcedure, Parameters: s.Parameters, Input: s.Input, Output: s.Output}, nil
+	default:
+		return nil, fmt.Errorf("lexicon %s is not a query or procedure", nsid)
+	}
+}
+
+// ParseParams parses a method's query string against its lexicon parameters.
+// Values come back typed as the lexicon declares them: string, int64, bool,
+// or []any of those for arrays. Missing parameters take their defaults, and
+// parameters the lexicon doesn't declare are ignored. A failure is returned
+// as a *FieldError naming the parameter.
+func (v *LexiconValidator) ParseParams(m *Method, query url.Values) (map[string]any, error) {
+	required := make(map[string]bool, len(m.Parameters.Required))
+	for _, name := range m.Parameters.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(m.Parameters.Properties))
+	for name := range m.Parameters.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make(map[string]any)
+	for _, name := range names {
+		def := m.Parameters.Properties[name].Inner
+		raw, ok := query[name]
+
+		var val any
+		var err error
+		if !ok || len(raw) == 0 {
+			val, ok = paramDefault(def)
+			if !ok {
+				if required[name] {
+					return nil, &FieldError{Path: name, Err: fmt.Errorf("required parameter missing")}
+				}
+				continue
+			}
+		} else if arr, isArray := def.(lexicon.SchemaArray); isArray {
+			items := make([]any, len(raw))
+			for i, s := range raw {
+				if items[i], err = parseParam(arr.Items.Inner, s); err != nil {
+					return nil, &FieldError{Path: fmt.Sprintf("%s[%d]", name, i), Err: err}
+				}
+			}
+			val = items
+		} else {
+			if len(raw) > 1 {
+				return nil, &FieldError{Path: name, Err: fmt.Errorf("expected a single value, got %d", len(raw))}
+			}
+			if val, err = parseParam(def, raw[0]); err != nil {
+				return nil, &FieldError{Path: name, Err: err}
+			}
+		}
+
+		if err := v.validateData(m.NSID, def, val, name); err != nil {
+			return nil, err
+		}
+		params[name] = val
+	}
+	return params, nil
+}
+
+// ValidateBody checks a JSON input or output body against its lexicon
+// schema. Bodies without a schema, or with a non-JSON encoding, are not
+// checked.
+func (v *LexiconValidator) ValidateBody(m *Method, body *lexicon.SchemaBody, raw []byte) error {
+	if body == nil || body.Schema == nil || body.Encoding != "application/json" {
+		return nil
+	}
+	data, err := atdata.UnmarshalJSON(raw)
+	if err != nil {
+		return &FieldError{Err: fmt.Errorf("body is not valid atproto data: %w", err)}
+	}
+	return v.validateData(m.NSID, body.Schema.Inner, data, "")
+}
+
+// parseParam parses a single query string value as its lexicon type
+func parseParam(def any, s string) (any, error) {
+	switch def.(type) {
+	case lexicon.SchemaInteger:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer, got %q", s)
+		}
+		return n, nil
+	case lexicon.SchemaBoolean:
+		switch s {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return nil, fmt.Errorf("expected true or false, got %q", s)
+	case lexicon.SchemaString:
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unsupported parameter type %T", def)
+	}
+}
+
+// paramDefault returns a parameter's lexicon default, if it has one
+func paramDefault(def any) (any, bool) {
+	switch s := def.(type) {
+	case lexicon.SchemaInteger:
+		if s.Default != nil {
+			return int64(*s.Default), true
+		}
+	case lexicon.SchemaBoolean:
+		if s.Default != nil {
+			return *s.Default, true
+		}
+	case lexicon.SchemaString:
+		if s.Default != nil {
+			return *s.Default, true
+		}
+	}
+	return nil, false
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"Coves/internal/atproto/carstore"
+	"Coves/internal/core/keys"
 	"Coves/internal/core/repository"
 	"Coves/internal/db/postgres"
 	"database/sql"
@@ -64,12 +65,22 @@ func TestRepositoryIntegration(t *testing.T) {
 	// Create repository repo
 	repoRepo := postgres.NewRepositoryRepo(sqlDB)
 	
-	// Create service with both repo and repoStore
-	service := repository.NewService(repoRepo, repoStore)
+	// Create key service with a throwaway encryption secret
+	keyEncryptor, err := keys.NewEncryptor(make([]byte, 32))
+	if err != nil {
+		t.Fatalf("Failed to create key encryptor: %v", err)
+	}
+	keyService, err := keys.NewService(postgres.NewSigningKeyRepo(sqlDB), keyEncryptor, keys.AlgorithmK256)
+	if err != nil {
+		t.Fatalf("Failed to create key service: %v", err)
+	}
+	
+	// Create service with repo, repoStore and key service, which also
+	// resolves keys for verifying imports of locally hosted DIDs
+	service := repository.NewService(repoRepo, repoStore, keyService, keyService)
 	
 	// Test creating a repository
 	did := "did:plc:testuser123"
-	service.SetSigningKey(did, "mock-signing-key")
 	
 	repo, err := service.CreateRepository(did)
 	if err != nil {